@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/internal/infra/catalogfile"
+	"merch-store-service/internal/infra/config"
+)
+
+func main() {
+	action := flag.String("action", "export", "Specify catalog action: export, import")
+	formatName := flag.String("format", "", "Catalog file format: yaml, csv (defaults to the file extension, or yaml)")
+	path := flag.String("file", "", "Catalog file (required for import; export writes to stdout when empty)")
+	dryRun := flag.Bool("dry-run", false, "Only show the changes an import would make")
+	allowEmpty := flag.Bool("allow-empty", false, "Allow importing a catalog with no products, which archives every product")
+
+	cfg := config.LoadConfig()
+
+	if *action == "import" && *path == "" {
+		log.Fatal("Catalog import needs a file: -file=<path>")
+	}
+
+	format, err := catalogfile.ParseFormat(*formatName, *path)
+	if err != nil {
+		log.Fatalf("Invalid format: %v", err)
+	}
+
+	storage, err := repository.New(cfg)
+	if err != nil {
+		log.Fatalf("Could not connect to database: %v", err)
+	}
+
+	switch *action {
+	case "export":
+		err = exportCatalog(storage, format, *path)
+	case "import":
+		err = importCatalog(storage, format, *path, *dryRun, *allowEmpty)
+	default:
+		fmt.Println("Usage: catalog -action=[export|import] -format=[yaml|csv] -file=<path> -dry-run -allow-empty -config=<path>")
+		return
+	}
+
+	var rowErrs models.CatalogErrors
+	if errors.As(err, &rowErrs) {
+		for _, rowErr := range rowErrs {
+			fmt.Fprintln(os.Stderr, rowErr.Error())
+		}
+		log.Fatalf("Catalog file has %d error(s), nothing was imported", len(rowErrs))
+	}
+	if err != nil {
+		log.Fatalf("Catalog %s failed: %v", *action, err)
+	}
+}
+
+func exportCatalog(storage *repository.Storage, format catalogfile.Format, path string) error {
+	entries, err := storage.ExportCatalog(context.Background())
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return catalogfile.Write(w, format, entries)
+}
+
+func importCatalog(storage *repository.Storage, format catalogfile.Format, path string, dryRun, allowEmpty bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	entries, err := catalogfile.Read(f, format)
+	if err != nil {
+		return err
+	}
+
+	// An empty file would archive the whole catalog, which is almost
+	// always a mistake such as a truncated export.
+	if len(entries) == 0 && !allowEmpty {
+		return errors.New("catalog file lists no products; pass -allow-empty to archive every product")
+	}
+
+	changes, err := storage.ImportCatalog(context.Background(), entries, dryRun)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[models.CatalogChangeKind]int)
+	for _, change := range changes {
+		fmt.Println(change)
+		counts[change.Kind]++
+	}
+	fmt.Printf("%d to create, %d to update, %d to restore, %d to archive\n",
+		counts[models.CatalogCreate], counts[models.CatalogUpdate], counts[models.CatalogRestore], counts[models.CatalogArchive])
+
+	switch {
+	case len(changes) == 0:
+		fmt.Println("Catalog is up to date.")
+	case dryRun:
+		fmt.Println("Dry run, no changes were applied.")
+	default:
+		fmt.Println("Catalog import successful!")
+	}
+	return nil
+}
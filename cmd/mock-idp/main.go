@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+
+	"merch-store-service/internal/infra/oidc/mockidp"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9000", "Address to listen on")
+	issuer := flag.String("issuer", "http://localhost:9000", "Issuer URL the provider is reachable at")
+	clientID := flag.String("client-id", "merch-store", "Client id of the merch store")
+	clientSecret := flag.String("client-secret", "", "Client secret of the merch store, if it should send one")
+	users := flag.String("users", "alice,bob", "Comma-separated logins; each signs in as <login>@example.com")
+	flag.Parse()
+
+	idp, err := mockidp.New(*clientID)
+	if err != nil {
+		log.Fatalf("Could not create provider: %v", err)
+	}
+	idp.Issuer = strings.TrimSuffix(*issuer, "/")
+	idp.ClientSecret = *clientSecret
+
+	for _, login := range strings.Split(*users, ",") {
+		login = strings.TrimSpace(login)
+		if login == "" {
+			continue
+		}
+		idp.AddUser(login, mockidp.User{
+			Subject:           "mock|" + login,
+			Email:             login + "@example.com",
+			EmailVerified:     true,
+			PreferredUsername: login,
+		})
+	}
+
+	log.Printf("Mock identity provider %s listening on %s; sign in with ?login_hint=<login>", idp.Issuer, *addr)
+	log.Fatal(http.ListenAndServe(*addr, idp))
+}
@@ -14,59 +14,2589 @@ import (
 
 // ServerInterface represents all merch-store handlers.
 type ServerInterface interface {
-	// Аутентификация и получение JWT-токена. При первой аутентификации пользователь создается автоматически.
+	// Открытые ключи для проверки JWT-токенов (JWKS). Содержит текущий ключ подписи и предыдущие ключи на время льготного периода.
+	// (GET /.well-known/jwks.json)
+	GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request)
+	// Начислить пользователю монеты (для роли hr-admin и ключей со scope coins:grant).
+	// (POST /api/admin/coins/grants)
+	PostApiAdminCoinsGrants(w http.ResponseWriter, r *http.Request)
+	// Имена пользователей и адреса с недавними неудачными попытками входа и время, до которого вход для них заблокирован (для ролей hr-admin и auditor).
+	// (GET /api/admin/login-failures)
+	GetApiAdminLoginFailures(w http.ResponseWriter, r *http.Request)
+	// Сбросить неудачные попытки входа и снять блокировку для имени пользователя или адреса (для роли hr-admin).
+	// (DELETE /api/admin/login-failures/{kind}/{key})
+	DeleteApiAdminLoginFailuresKindKey(w http.ResponseWriter, r *http.Request, kind string, key string)
+	// Список сервисных аккаунтов с их API-ключами (для ролей hr-admin и auditor).
+	// (GET /api/admin/service-accounts)
+	GetApiAdminServiceAccounts(w http.ResponseWriter, r *http.Request)
+	// Создать сервисный аккаунт (для роли hr-admin).
+	// (POST /api/admin/service-accounts)
+	PostApiAdminServiceAccounts(w http.ResponseWriter, r *http.Request)
+	// Выпустить API-ключ сервисного аккаунта (для роли hr-admin). Ключ целиком возвращается только в этом ответе.
+	// (POST /api/admin/service-accounts/{name}/keys)
+	PostApiAdminServiceAccountsNameKeys(w http.ResponseWriter, r *http.Request, name string)
+	// Отозвать API-ключ (для роли hr-admin).
+	// (DELETE /api/admin/service-accounts/{name}/keys/{keyId})
+	DeleteApiAdminServiceAccountsNameKeysKeyId(w http.ResponseWriter, r *http.Request, name string, keyId string)
+	// Роли, доступные только в сессиях, начатых с вторым фактором (для ролей hr-admin и auditor).
+	// (GET /api/admin/two-factor/roles)
+	GetApiAdminTwoFactorRoles(w http.ResponseWriter, r *http.Request)
+	// Перестать требовать двухфакторную аутентификацию для роли (для роли hr-admin).
+	// (DELETE /api/admin/two-factor/roles/{role})
+	DeleteApiAdminTwoFactorRolesRole(w http.ResponseWriter, r *http.Request, role string)
+	// Требовать двухфакторную аутентификацию для роли (для роли hr-admin). В сессиях без второго фактора роль пропадает из токена при следующем обновлении.
+	// (PUT /api/admin/two-factor/roles/{role})
+	PutApiAdminTwoFactorRolesRole(w http.ResponseWriter, r *http.Request, role string)
+	// Сбросить пароль пользователя (для роли hr-admin). Одноразовый токен сброса отправляется пользователю и в ответе не возвращается.
+	// (POST /api/admin/users/{username}/password-reset)
+	PostApiAdminUsersUsernamePasswordReset(w http.ResponseWriter, r *http.Request, username string)
+	// Атрибуты пользователя из каталога LDAP на момент его последнего входа через каталог (для ролей hr-admin и auditor).
+	// (GET /api/admin/users/{username}/profile)
+	GetApiAdminUsersUsernameProfile(w http.ResponseWriter, r *http.Request, username string)
+	// Роли пользователя (для ролей hr-admin и auditor).
+	// (GET /api/admin/users/{username}/roles)
+	GetApiAdminUsersUsernameRoles(w http.ResponseWriter, r *http.Request, username string)
+	// Отозвать у пользователя роль (для роли hr-admin). Выданные пользователю access-токены отзываются.
+	// (DELETE /api/admin/users/{username}/roles/{role})
+	DeleteApiAdminUsersUsernameRolesRole(w http.ResponseWriter, r *http.Request, username string, role string)
+	// Выдать пользователю роль (для роли hr-admin). Выданные пользователю access-токены отзываются, новая роль появится в токене после обновления.
+	// (PUT /api/admin/users/{username}/roles/{role})
+	PutApiAdminUsersUsernameRolesRole(w http.ResponseWriter, r *http.Request, username string, role string)
+	// Сбросить двухфакторную аутентификацию пользователя, например после потери телефона (для роли hr-admin). Коды восстановления удаляются, пользователь входит по паролю, пока не подключит ее снова.
+	// (DELETE /api/admin/users/{username}/two-factor)
+	DeleteApiAdminUsersUsernameTwoFactor(w http.ResponseWriter, r *http.Request, username string)
+	// Получить текущие и завершенные аукционы.
+	// (GET /api/auctions)
+	GetApiAuctions(w http.ResponseWriter, r *http.Request)
+	// Получить аукцион с историей ставок.
+	// (GET /api/auctions/{auctionId})
+	GetApiAuctionsAuctionId(w http.ResponseWriter, r *http.Request, auctionId int)
+	// Сделать ставку. Сумма ставки блокируется до тех пор, пока ставку не перебьют.
+	// (POST /api/auctions/{auctionId}/bids)
+	PostApiAuctionsAuctionIdBids(w http.ResponseWriter, r *http.Request, auctionId int)
+	// Аутентификация и получение JWT-токена. Устаревший эндпоинт, используйте /api/auth/login и /api/auth/register. Неизвестный пользователь создается автоматически, только если это включено в конфигурации.
 	// (POST /api/auth)
 	PostApiAuth(w http.ResponseWriter, r *http.Request)
+	// Вход по имени пользователя и паролю. Новые пользователи не создаются.
+	// (POST /api/auth/login)
+	PostApiAuthLogin(w http.ResponseWriter, r *http.Request)
+	// Выйти — отозвать текущую сессию, ее refresh-токены и уже выданные access-токены.
+	// (POST /api/auth/logout)
+	PostApiAuthLogout(w http.ResponseWriter, r *http.Request)
+	// Сменить пароль. Требует текущий пароль; все сессии пользователя, включая текущую, отзываются, и в ответе возвращается новая пара токенов.
+	// (POST /api/auth/password)
+	PostApiAuthPassword(w http.ResponseWriter, r *http.Request)
+	// Задать новый пароль по токену сброса. Токен действует один раз; все сессии пользователя отзываются.
+	// (POST /api/auth/password/reset)
+	PostApiAuthPasswordReset(w http.ResponseWriter, r *http.Request)
+	// Обменять refresh-токен на новую пару токенов. Каждый refresh-токен действует один раз; повторное использование отзывает всю сессию.
+	// (POST /api/auth/refresh)
+	PostApiAuthRefresh(w http.ResponseWriter, r *http.Request)
+	// Регистрация нового пользователя с проверкой имени, пароля и, в зависимости от режима регистрации, кода приглашения или списка разрешенных имен.
+	// (POST /api/auth/register)
+	PostApiAuthRegister(w http.ResponseWriter, r *http.Request)
+	// Завершение единого входа (SSO). Поставщик удостоверений перенаправляет сюда браузер с кодом авторизации; сервис обменивает код с проверкой PKCE, проверяет ID-токен и выдает собственные токены.
+	// (GET /api/auth/sso/callback)
+	GetApiAuthSsoCallback(w http.ResponseWriter, r *http.Request, params GetApiAuthSsoCallbackParams)
+	// Начать единый вход (SSO) через поставщика удостоверений OpenID Connect. Перенаправляет браузер на страницу входа поставщика (authorization code с PKCE).
+	// (GET /api/auth/sso/start)
+	GetApiAuthSsoStart(w http.ResponseWriter, r *http.Request)
+	// Состояние двухфакторной аутентификации текущего пользователя.
+	// (GET /api/auth/two-factor)
+	GetApiAuthTwoFactor(w http.ResponseWriter, r *http.Request)
+	// Подтвердить подключение двухфакторной аутентификации кодом из приложения. В ответе — одноразовые коды восстановления, они показываются только один раз.
+	// (POST /api/auth/two-factor/confirm)
+	PostApiAuthTwoFactorConfirm(w http.ResponseWriter, r *http.Request)
+	// Начать подключение двухфакторной аутентификации (TOTP). Возвращает секрет и URI otpauth для QR-кода; повторный вызов до подтверждения выпускает новый секрет.
+	// (POST /api/auth/two-factor/enroll)
+	PostApiAuthTwoFactorEnroll(w http.ResponseWriter, r *http.Request)
+	// Второй шаг входа. Обменивает токен проверки, полученный при входе, и код из приложения или код восстановления на пару токенов.
+	// (POST /api/auth/two-factor/verify)
+	PostApiAuthTwoFactorVerify(w http.ResponseWriter, r *http.Request)
+	// Получить наборы товаров с составом и доступностью.
+	// (GET /api/bundles)
+	GetApiBundles(w http.ResponseWriter, r *http.Request)
+	// Получить набор товаров.
+	// (GET /api/bundles/{bundle})
+	GetApiBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string)
+	// Купить набор. Цена набора списывается один раз, каждый товар из набора добавляется в инвентарь.
+	// (POST /api/bundles/{bundle}/buy)
+	PostApiBundlesBundleBuy(w http.ResponseWriter, r *http.Request, bundle string)
+	// Купить несколько единиц предмета за монеты.
+	// (POST /api/buy)
+	PostApiBuy(w http.ResponseWriter, r *http.Request)
 	// Купить предмет за монеты.
 	// (GET /api/buy/{item})
 	GetApiBuyItem(w http.ResponseWriter, r *http.Request, item string)
+	// Получить предстоящие и текущие дропы лимитированных товаров.
+	// (GET /api/drops)
+	GetApiDrops(w http.ResponseWriter, r *http.Request)
+	// Купить товар в подарок коллеге.
+	// (POST /api/gifts)
+	PostApiGifts(w http.ResponseWriter, r *http.Request)
 	// Получить информацию о монетах, инвентаре и истории транзакций.
 	// (GET /api/info)
 	GetApiInfo(w http.ResponseWriter, r *http.Request)
-	// Отправить монеты другому пользователю.
+	// Получить активные объявления внутреннего маркетплейса.
+	// (GET /api/market/listings)
+	GetApiMarketListings(w http.ResponseWriter, r *http.Request, params GetApiMarketListingsParams)
+	// Выставить предметы из своего инвентаря на продажу.
+	// (POST /api/market/listings)
+	PostApiMarketListings(w http.ResponseWriter, r *http.Request)
+	// Купить предметы по объявлению.
+	// (POST /api/market/listings/{listingId}/buy)
+	PostApiMarketListingsListingIdBuy(w http.ResponseWriter, r *http.Request, listingId int)
+	// Снять свое объявление и вернуть непроданные предметы в инвентарь.
+	// (POST /api/market/listings/{listingId}/cancel)
+	PostApiMarketListingsListingIdCancel(w http.ResponseWriter, r *http.Request, listingId int)
+	// Получить список заказов текущего пользователя.
+	// (GET /api/orders)
+	GetApiOrders(w http.ResponseWriter, r *http.Request)
+	// Отменить свой заказ и вернуть потраченные монеты.
+	// (POST /api/orders/{orderId}/cancel)
+	PostApiOrdersOrderIdCancel(w http.ResponseWriter, r *http.Request, orderId int)
+	// Получить текущие и разыгранные лотереи.
+	// (GET /api/raffles)
+	GetApiRaffles(w http.ResponseWriter, r *http.Request)
+	// Получить лотерею со списком билетов. После розыгрыша содержит раскрытый сид для проверки результата.
+	// (GET /api/raffles/{raffleId})
+	GetApiRafflesRaffleId(w http.ResponseWriter, r *http.Request, raffleId int)
+	// Купить билеты лотереи. Монеты списываются так же, как при покупке товара.
+	// (POST /api/raffles/{raffleId}/tickets)
+	PostApiRafflesRaffleIdTickets(w http.ResponseWriter, r *http.Request, raffleId int)
+	// Отправить монеты другому пользователю. Сервисный аккаунт со scope coins:send отправляет монеты от имени пользователя из заголовка X-On-Behalf-Of.
 	// (POST /api/sendCoin)
 	PostApiSendCoin(w http.ResponseWriter, r *http.Request)
+	// Создать аукцион (для сотрудников магазина).
+	// (POST /api/staff/auctions)
+	PostApiStaffAuctions(w http.ResponseWriter, r *http.Request)
+	// Создать набор товаров (для сотрудников магазина).
+	// (POST /api/staff/bundles)
+	PostApiStaffBundles(w http.ResponseWriter, r *http.Request)
+	// Удалить набор товаров (для сотрудников магазина).
+	// (DELETE /api/staff/bundles/{bundle})
+	DeleteApiStaffBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string)
+	// Изменить цену и состав набора (для сотрудников магазина).
+	// (PUT /api/staff/bundles/{bundle})
+	PutApiStaffBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string)
+	// Запланировать дроп лимитированного товара (для сотрудников магазина).
+	// (POST /api/staff/drops)
+	PostApiStaffDrops(w http.ResponseWriter, r *http.Request)
+	// Список кодов приглашений (для ролей hr-admin и auditor).
+	// (GET /api/staff/invites)
+	GetApiStaffInvites(w http.ResponseWriter, r *http.Request)
+	// Выпустить одноразовый код приглашения для регистрации (для роли hr-admin).
+	// (POST /api/staff/invites)
+	PostApiStaffInvites(w http.ResponseWriter, r *http.Request)
+	// Получить список всех заказов (для сотрудников магазина).
+	// (GET /api/staff/orders)
+	GetApiStaffOrders(w http.ResponseWriter, r *http.Request, params GetApiStaffOrdersParams)
+	// Отменить заказ пользователя с возвратом монет (для сотрудников магазина).
+	// (POST /api/staff/orders/{orderId}/cancel)
+	PostApiStaffOrdersOrderIdCancel(w http.ResponseWriter, r *http.Request, orderId int)
+	// Перевести заказ в следующий статус (для сотрудников магазина).
+	// (POST /api/staff/orders/{orderId}/status)
+	PostApiStaffOrdersOrderIdStatus(w http.ResponseWriter, r *http.Request, orderId int)
+	// Получить историю цен товара (для сотрудников магазина).
+	// (GET /api/staff/products/{item}/prices)
+	GetApiStaffProductsItemPrices(w http.ResponseWriter, r *http.Request, item string)
+	// Изменить цену товара сейчас или запланировать изменение на будущее (для сотрудников магазина).
+	// (POST /api/staff/products/{item}/prices)
+	PostApiStaffProductsItemPrices(w http.ResponseWriter, r *http.Request, item string)
+	// Пополнить остаток товара и уведомить лист ожидания (для сотрудников магазина).
+	// (POST /api/staff/products/{item}/stock)
+	PostApiStaffProductsItemStock(w http.ResponseWriter, r *http.Request, item string)
+	// Получить промокоды и статистику их использования (для сотрудников магазина).
+	// (GET /api/staff/promo-codes)
+	GetApiStaffPromoCodes(w http.ResponseWriter, r *http.Request)
+	// Создать промокод (для сотрудников магазина).
+	// (POST /api/staff/promo-codes)
+	PostApiStaffPromoCodes(w http.ResponseWriter, r *http.Request)
+	// Создать лотерею (для сотрудников магазина). Хеш сида публикуется сразу, сам сид — после розыгрыша.
+	// (POST /api/staff/raffles)
+	PostApiStaffRaffles(w http.ResponseWriter, r *http.Request)
+	// Отменить лотерею до розыгрыша и вернуть монеты за все билеты (для сотрудников магазина).
+	// (POST /api/staff/raffles/{raffleId}/cancel)
+	PostApiStaffRafflesRaffleIdCancel(w http.ResponseWriter, r *http.Request, raffleId int)
+	// Передать предметы из своего инвентаря другому пользователю.
+	// (POST /api/transferItem)
+	PostApiTransferItem(w http.ResponseWriter, r *http.Request)
+	// Получить открытый список желаний коллеги.
+	// (GET /api/users/{username}/wishlist)
+	GetApiUsersUsernameWishlist(w http.ResponseWriter, r *http.Request, username string)
+	// Получить листы ожидания, на которые подписан пользователь.
+	// (GET /api/waitlist)
+	GetApiWaitlist(w http.ResponseWriter, r *http.Request)
+	// Отписаться от листа ожидания товара.
+	// (DELETE /api/waitlist/{item})
+	DeleteApiWaitlistItem(w http.ResponseWriter, r *http.Request, item string)
+	// Подписаться на уведомление о поступлении распроданного товара.
+	// (POST /api/waitlist/{item})
+	PostApiWaitlistItem(w http.ResponseWriter, r *http.Request, item string)
+	// Получить свой список желаний.
+	// (GET /api/wishlist)
+	GetApiWishlist(w http.ResponseWriter, r *http.Request)
+	// Открыть или закрыть список желаний для коллег.
+	// (PATCH /api/wishlist)
+	PatchApiWishlist(w http.ResponseWriter, r *http.Request)
+	// Добавить товар в список желаний.
+	// (POST /api/wishlist)
+	PostApiWishlist(w http.ResponseWriter, r *http.Request)
+	// Удалить товар из списка желаний.
+	// (DELETE /api/wishlist/{item})
+	DeleteApiWishlistItem(w http.ResponseWriter, r *http.Request, item string)
+	// Изменить заметку к товару в списке желаний.
+	// (PUT /api/wishlist/{item})
+	PutApiWishlistItem(w http.ResponseWriter, r *http.Request, item string)
 }
 
 // Unimplemented merch-store implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
-// Аутентификация и получение JWT-токена. При первой аутентификации пользователь создается автоматически.
+// Открытые ключи для проверки JWT-токенов (JWKS). Содержит текущий ключ подписи и предыдущие ключи на время льготного периода.
+// (GET /.well-known/jwks.json)
+func (_ Unimplemented) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Начислить пользователю монеты (для роли hr-admin и ключей со scope coins:grant).
+// (POST /api/admin/coins/grants)
+func (_ Unimplemented) PostApiAdminCoinsGrants(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Имена пользователей и адреса с недавними неудачными попытками входа и время, до которого вход для них заблокирован (для ролей hr-admin и auditor).
+// (GET /api/admin/login-failures)
+func (_ Unimplemented) GetApiAdminLoginFailures(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Сбросить неудачные попытки входа и снять блокировку для имени пользователя или адреса (для роли hr-admin).
+// (DELETE /api/admin/login-failures/{kind}/{key})
+func (_ Unimplemented) DeleteApiAdminLoginFailuresKindKey(w http.ResponseWriter, r *http.Request, kind string, key string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список сервисных аккаунтов с их API-ключами (для ролей hr-admin и auditor).
+// (GET /api/admin/service-accounts)
+func (_ Unimplemented) GetApiAdminServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать сервисный аккаунт (для роли hr-admin).
+// (POST /api/admin/service-accounts)
+func (_ Unimplemented) PostApiAdminServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выпустить API-ключ сервисного аккаунта (для роли hr-admin). Ключ целиком возвращается только в этом ответе.
+// (POST /api/admin/service-accounts/{name}/keys)
+func (_ Unimplemented) PostApiAdminServiceAccountsNameKeys(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать API-ключ (для роли hr-admin).
+// (DELETE /api/admin/service-accounts/{name}/keys/{keyId})
+func (_ Unimplemented) DeleteApiAdminServiceAccountsNameKeysKeyId(w http.ResponseWriter, r *http.Request, name string, keyId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Роли, доступные только в сессиях, начатых с вторым фактором (для ролей hr-admin и auditor).
+// (GET /api/admin/two-factor/roles)
+func (_ Unimplemented) GetApiAdminTwoFactorRoles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Перестать требовать двухфакторную аутентификацию для роли (для роли hr-admin).
+// (DELETE /api/admin/two-factor/roles/{role})
+func (_ Unimplemented) DeleteApiAdminTwoFactorRolesRole(w http.ResponseWriter, r *http.Request, role string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Требовать двухфакторную аутентификацию для роли (для роли hr-admin). В сессиях без второго фактора роль пропадает из токена при следующем обновлении.
+// (PUT /api/admin/two-factor/roles/{role})
+func (_ Unimplemented) PutApiAdminTwoFactorRolesRole(w http.ResponseWriter, r *http.Request, role string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Сбросить пароль пользователя (для роли hr-admin). Одноразовый токен сброса отправляется пользователю и в ответе не возвращается.
+// (POST /api/admin/users/{username}/password-reset)
+func (_ Unimplemented) PostApiAdminUsersUsernamePasswordReset(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Атрибуты пользователя из каталога LDAP на момент его последнего входа через каталог (для ролей hr-admin и auditor).
+// (GET /api/admin/users/{username}/profile)
+func (_ Unimplemented) GetApiAdminUsersUsernameProfile(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Роли пользователя (для ролей hr-admin и auditor).
+// (GET /api/admin/users/{username}/roles)
+func (_ Unimplemented) GetApiAdminUsersUsernameRoles(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать у пользователя роль (для роли hr-admin). Выданные пользователю access-токены отзываются.
+// (DELETE /api/admin/users/{username}/roles/{role})
+func (_ Unimplemented) DeleteApiAdminUsersUsernameRolesRole(w http.ResponseWriter, r *http.Request, username string, role string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выдать пользователю роль (для роли hr-admin). Выданные пользователю access-токены отзываются, новая роль появится в токене после обновления.
+// (PUT /api/admin/users/{username}/roles/{role})
+func (_ Unimplemented) PutApiAdminUsersUsernameRolesRole(w http.ResponseWriter, r *http.Request, username string, role string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Сбросить двухфакторную аутентификацию пользователя, например после потери телефона (для роли hr-admin). Коды восстановления удаляются, пользователь входит по паролю, пока не подключит ее снова.
+// (DELETE /api/admin/users/{username}/two-factor)
+func (_ Unimplemented) DeleteApiAdminUsersUsernameTwoFactor(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить текущие и завершенные аукционы.
+// (GET /api/auctions)
+func (_ Unimplemented) GetApiAuctions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить аукцион с историей ставок.
+// (GET /api/auctions/{auctionId})
+func (_ Unimplemented) GetApiAuctionsAuctionId(w http.ResponseWriter, r *http.Request, auctionId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Сделать ставку. Сумма ставки блокируется до тех пор, пока ставку не перебьют.
+// (POST /api/auctions/{auctionId}/bids)
+func (_ Unimplemented) PostApiAuctionsAuctionIdBids(w http.ResponseWriter, r *http.Request, auctionId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Аутентификация и получение JWT-токена. Устаревший эндпоинт, используйте /api/auth/login и /api/auth/register. Неизвестный пользователь создается автоматически, только если это включено в конфигурации.
 // (POST /api/auth)
 func (_ Unimplemented) PostApiAuth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Вход по имени пользователя и паролю. Новые пользователи не создаются.
+// (POST /api/auth/login)
+func (_ Unimplemented) PostApiAuthLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выйти — отозвать текущую сессию, ее refresh-токены и уже выданные access-токены.
+// (POST /api/auth/logout)
+func (_ Unimplemented) PostApiAuthLogout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Сменить пароль. Требует текущий пароль; все сессии пользователя, включая текущую, отзываются, и в ответе возвращается новая пара токенов.
+// (POST /api/auth/password)
+func (_ Unimplemented) PostApiAuthPassword(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Задать новый пароль по токену сброса. Токен действует один раз; все сессии пользователя отзываются.
+// (POST /api/auth/password/reset)
+func (_ Unimplemented) PostApiAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Обменять refresh-токен на новую пару токенов. Каждый refresh-токен действует один раз; повторное использование отзывает всю сессию.
+// (POST /api/auth/refresh)
+func (_ Unimplemented) PostApiAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Регистрация нового пользователя с проверкой имени, пароля и, в зависимости от режима регистрации, кода приглашения или списка разрешенных имен.
+// (POST /api/auth/register)
+func (_ Unimplemented) PostApiAuthRegister(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Завершение единого входа (SSO). Поставщик удостоверений перенаправляет сюда браузер с кодом авторизации; сервис обменивает код с проверкой PKCE, проверяет ID-токен и выдает собственные токены.
+// (GET /api/auth/sso/callback)
+func (_ Unimplemented) GetApiAuthSsoCallback(w http.ResponseWriter, r *http.Request, params GetApiAuthSsoCallbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Начать единый вход (SSO) через поставщика удостоверений OpenID Connect. Перенаправляет браузер на страницу входа поставщика (authorization code с PKCE).
+// (GET /api/auth/sso/start)
+func (_ Unimplemented) GetApiAuthSsoStart(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Состояние двухфакторной аутентификации текущего пользователя.
+// (GET /api/auth/two-factor)
+func (_ Unimplemented) GetApiAuthTwoFactor(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Подтвердить подключение двухфакторной аутентификации кодом из приложения. В ответе — одноразовые коды восстановления, они показываются только один раз.
+// (POST /api/auth/two-factor/confirm)
+func (_ Unimplemented) PostApiAuthTwoFactorConfirm(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Начать подключение двухфакторной аутентификации (TOTP). Возвращает секрет и URI otpauth для QR-кода; повторный вызов до подтверждения выпускает новый секрет.
+// (POST /api/auth/two-factor/enroll)
+func (_ Unimplemented) PostApiAuthTwoFactorEnroll(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Второй шаг входа. Обменивает токен проверки, полученный при входе, и код из приложения или код восстановления на пару токенов.
+// (POST /api/auth/two-factor/verify)
+func (_ Unimplemented) PostApiAuthTwoFactorVerify(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить наборы товаров с составом и доступностью.
+// (GET /api/bundles)
+func (_ Unimplemented) GetApiBundles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить набор товаров.
+// (GET /api/bundles/{bundle})
+func (_ Unimplemented) GetApiBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Купить набор. Цена набора списывается один раз, каждый товар из набора добавляется в инвентарь.
+// (POST /api/bundles/{bundle}/buy)
+func (_ Unimplemented) PostApiBundlesBundleBuy(w http.ResponseWriter, r *http.Request, bundle string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Купить несколько единиц предмета за монеты.
+// (POST /api/buy)
+func (_ Unimplemented) PostApiBuy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Купить предмет за монеты.
 // (GET /api/buy/{item})
 func (_ Unimplemented) GetApiBuyItem(w http.ResponseWriter, r *http.Request, item string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Получить предстоящие и текущие дропы лимитированных товаров.
+// (GET /api/drops)
+func (_ Unimplemented) GetApiDrops(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Купить товар в подарок коллеге.
+// (POST /api/gifts)
+func (_ Unimplemented) PostApiGifts(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Получить информацию о монетах, инвентаре и истории транзакций.
 // (GET /api/info)
 func (_ Unimplemented) GetApiInfo(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Отправить монеты другому пользователю.
-// (POST /api/sendCoin)
-func (_ Unimplemented) PostApiSendCoin(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+// Получить активные объявления внутреннего маркетплейса.
+// (GET /api/market/listings)
+func (_ Unimplemented) GetApiMarketListings(w http.ResponseWriter, r *http.Request, params GetApiMarketListingsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выставить предметы из своего инвентаря на продажу.
+// (POST /api/market/listings)
+func (_ Unimplemented) PostApiMarketListings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Купить предметы по объявлению.
+// (POST /api/market/listings/{listingId}/buy)
+func (_ Unimplemented) PostApiMarketListingsListingIdBuy(w http.ResponseWriter, r *http.Request, listingId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Снять свое объявление и вернуть непроданные предметы в инвентарь.
+// (POST /api/market/listings/{listingId}/cancel)
+func (_ Unimplemented) PostApiMarketListingsListingIdCancel(w http.ResponseWriter, r *http.Request, listingId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить список заказов текущего пользователя.
+// (GET /api/orders)
+func (_ Unimplemented) GetApiOrders(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отменить свой заказ и вернуть потраченные монеты.
+// (POST /api/orders/{orderId}/cancel)
+func (_ Unimplemented) PostApiOrdersOrderIdCancel(w http.ResponseWriter, r *http.Request, orderId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить текущие и разыгранные лотереи.
+// (GET /api/raffles)
+func (_ Unimplemented) GetApiRaffles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить лотерею со списком билетов. После розыгрыша содержит раскрытый сид для проверки результата.
+// (GET /api/raffles/{raffleId})
+func (_ Unimplemented) GetApiRafflesRaffleId(w http.ResponseWriter, r *http.Request, raffleId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Купить билеты лотереи. Монеты списываются так же, как при покупке товара.
+// (POST /api/raffles/{raffleId}/tickets)
+func (_ Unimplemented) PostApiRafflesRaffleIdTickets(w http.ResponseWriter, r *http.Request, raffleId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отправить монеты другому пользователю. Сервисный аккаунт со scope coins:send отправляет монеты от имени пользователя из заголовка X-On-Behalf-Of.
+// (POST /api/sendCoin)
+func (_ Unimplemented) PostApiSendCoin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать аукцион (для сотрудников магазина).
+// (POST /api/staff/auctions)
+func (_ Unimplemented) PostApiStaffAuctions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать набор товаров (для сотрудников магазина).
+// (POST /api/staff/bundles)
+func (_ Unimplemented) PostApiStaffBundles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить набор товаров (для сотрудников магазина).
+// (DELETE /api/staff/bundles/{bundle})
+func (_ Unimplemented) DeleteApiStaffBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить цену и состав набора (для сотрудников магазина).
+// (PUT /api/staff/bundles/{bundle})
+func (_ Unimplemented) PutApiStaffBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Запланировать дроп лимитированного товара (для сотрудников магазина).
+// (POST /api/staff/drops)
+func (_ Unimplemented) PostApiStaffDrops(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список кодов приглашений (для ролей hr-admin и auditor).
+// (GET /api/staff/invites)
+func (_ Unimplemented) GetApiStaffInvites(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Выпустить одноразовый код приглашения для регистрации (для роли hr-admin).
+// (POST /api/staff/invites)
+func (_ Unimplemented) PostApiStaffInvites(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить список всех заказов (для сотрудников магазина).
+// (GET /api/staff/orders)
+func (_ Unimplemented) GetApiStaffOrders(w http.ResponseWriter, r *http.Request, params GetApiStaffOrdersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отменить заказ пользователя с возвратом монет (для сотрудников магазина).
+// (POST /api/staff/orders/{orderId}/cancel)
+func (_ Unimplemented) PostApiStaffOrdersOrderIdCancel(w http.ResponseWriter, r *http.Request, orderId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Перевести заказ в следующий статус (для сотрудников магазина).
+// (POST /api/staff/orders/{orderId}/status)
+func (_ Unimplemented) PostApiStaffOrdersOrderIdStatus(w http.ResponseWriter, r *http.Request, orderId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить историю цен товара (для сотрудников магазина).
+// (GET /api/staff/products/{item}/prices)
+func (_ Unimplemented) GetApiStaffProductsItemPrices(w http.ResponseWriter, r *http.Request, item string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить цену товара сейчас или запланировать изменение на будущее (для сотрудников магазина).
+// (POST /api/staff/products/{item}/prices)
+func (_ Unimplemented) PostApiStaffProductsItemPrices(w http.ResponseWriter, r *http.Request, item string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Пополнить остаток товара и уведомить лист ожидания (для сотрудников магазина).
+// (POST /api/staff/products/{item}/stock)
+func (_ Unimplemented) PostApiStaffProductsItemStock(w http.ResponseWriter, r *http.Request, item string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить промокоды и статистику их использования (для сотрудников магазина).
+// (GET /api/staff/promo-codes)
+func (_ Unimplemented) GetApiStaffPromoCodes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать промокод (для сотрудников магазина).
+// (POST /api/staff/promo-codes)
+func (_ Unimplemented) PostApiStaffPromoCodes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать лотерею (для сотрудников магазина). Хеш сида публикуется сразу, сам сид — после розыгрыша.
+// (POST /api/staff/raffles)
+func (_ Unimplemented) PostApiStaffRaffles(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отменить лотерею до розыгрыша и вернуть монеты за все билеты (для сотрудников магазина).
+// (POST /api/staff/raffles/{raffleId}/cancel)
+func (_ Unimplemented) PostApiStaffRafflesRaffleIdCancel(w http.ResponseWriter, r *http.Request, raffleId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Передать предметы из своего инвентаря другому пользователю.
+// (POST /api/transferItem)
+func (_ Unimplemented) PostApiTransferItem(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить открытый список желаний коллеги.
+// (GET /api/users/{username}/wishlist)
+func (_ Unimplemented) GetApiUsersUsernameWishlist(w http.ResponseWriter, r *http.Request, username string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить листы ожидания, на которые подписан пользователь.
+// (GET /api/waitlist)
+func (_ Unimplemented) GetApiWaitlist(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отписаться от листа ожидания товара.
+// (DELETE /api/waitlist/{item})
+func (_ Unimplemented) DeleteApiWaitlistItem(w http.ResponseWriter, r *http.Request, item string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Подписаться на уведомление о поступлении распроданного товара.
+// (POST /api/waitlist/{item})
+func (_ Unimplemented) PostApiWaitlistItem(w http.ResponseWriter, r *http.Request, item string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить свой список желаний.
+// (GET /api/wishlist)
+func (_ Unimplemented) GetApiWishlist(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Открыть или закрыть список желаний для коллег.
+// (PATCH /api/wishlist)
+func (_ Unimplemented) PatchApiWishlist(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Добавить товар в список желаний.
+// (POST /api/wishlist)
+func (_ Unimplemented) PostApiWishlist(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить товар из списка желаний.
+// (DELETE /api/wishlist/{item})
+func (_ Unimplemented) DeleteApiWishlistItem(w http.ResponseWriter, r *http.Request, item string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить заметку к товару в списке желаний.
+// (PUT /api/wishlist/{item})
+func (_ Unimplemented) PutApiWishlistItem(w http.ResponseWriter, r *http.Request, item string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetWellKnownJwksJson operation middleware
+func (siw *ServerInterfaceWrapper) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWellKnownJwksJson(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAdminCoinsGrants operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAdminCoinsGrants(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAdminCoinsGrants(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAdminLoginFailures operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminLoginFailures(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminLoginFailures(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiAdminLoginFailuresKindKey operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminLoginFailuresKindKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "kind" -------------
+	var kind string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "kind", chi.URLParam(r, "kind"), &kind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", chi.URLParam(r, "key"), &key, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminLoginFailuresKindKey(w, r, kind, key)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAdminServiceAccounts operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminServiceAccounts(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminServiceAccounts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAdminServiceAccounts operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAdminServiceAccounts(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAdminServiceAccounts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAdminServiceAccountsNameKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAdminServiceAccountsNameKeys(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAdminServiceAccountsNameKeys(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiAdminServiceAccountsNameKeysKeyId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminServiceAccountsNameKeysKeyId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "keyId", chi.URLParam(r, "keyId"), &keyId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminServiceAccountsNameKeysKeyId(w, r, name, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAdminTwoFactorRoles operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminTwoFactorRoles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminTwoFactorRoles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiAdminTwoFactorRolesRole operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminTwoFactorRolesRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", chi.URLParam(r, "role"), &role, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminTwoFactorRolesRole(w, r, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiAdminTwoFactorRolesRole operation middleware
+func (siw *ServerInterfaceWrapper) PutApiAdminTwoFactorRolesRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", chi.URLParam(r, "role"), &role, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiAdminTwoFactorRolesRole(w, r, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAdminUsersUsernamePasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAdminUsersUsernamePasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAdminUsersUsernamePasswordReset(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAdminUsersUsernameProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminUsersUsernameProfile(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminUsersUsernameProfile(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAdminUsersUsernameRoles operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAdminUsersUsernameRoles(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAdminUsersUsernameRoles(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiAdminUsersUsernameRolesRole operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminUsersUsernameRolesRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", chi.URLParam(r, "role"), &role, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminUsersUsernameRolesRole(w, r, username, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiAdminUsersUsernameRolesRole operation middleware
+func (siw *ServerInterfaceWrapper) PutApiAdminUsersUsernameRolesRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "role" -------------
+	var role string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", chi.URLParam(r, "role"), &role, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiAdminUsersUsernameRolesRole(w, r, username, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiAdminUsersUsernameTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiAdminUsersUsernameTwoFactor(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiAdminUsersUsernameTwoFactor(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAuctions operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAuctions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAuctions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAuctionsAuctionId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAuctionsAuctionId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "auctionId" -------------
+	var auctionId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "auctionId", chi.URLParam(r, "auctionId"), &auctionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "auctionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAuctionsAuctionId(w, r, auctionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuctionsAuctionIdBids operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuctionsAuctionIdBids(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "auctionId" -------------
+	var auctionId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "auctionId", chi.URLParam(r, "auctionId"), &auctionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "auctionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuctionsAuctionIdBids(w, r, auctionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuth operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuth(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthLogin(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthLogout(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthLogout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthPassword operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthPassword(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthPassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthRefresh(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthRefresh(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthRegister(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthRegister(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAuthSsoCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAuthSsoCallback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiAuthSsoCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", r.URL.Query(), &params.Error)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "error_description" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error_description", r.URL.Query(), &params.ErrorDescription)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "error_description", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAuthSsoCallback(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAuthSsoStart operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAuthSsoStart(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAuthSsoStart(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiAuthTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) GetApiAuthTwoFactor(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiAuthTwoFactor(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthTwoFactorConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthTwoFactorConfirm(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthTwoFactorConfirm(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthTwoFactorEnroll operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthTwoFactorEnroll(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthTwoFactorEnroll(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiAuthTwoFactorVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostApiAuthTwoFactorVerify(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiAuthTwoFactorVerify(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiBundles operation middleware
+func (siw *ServerInterfaceWrapper) GetApiBundles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiBundles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiBundlesBundle operation middleware
+func (siw *ServerInterfaceWrapper) GetApiBundlesBundle(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "bundle" -------------
+	var bundle string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "bundle", chi.URLParam(r, "bundle"), &bundle, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bundle", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiBundlesBundle(w, r, bundle)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiBundlesBundleBuy operation middleware
+func (siw *ServerInterfaceWrapper) PostApiBundlesBundleBuy(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "bundle" -------------
+	var bundle string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "bundle", chi.URLParam(r, "bundle"), &bundle, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bundle", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiBundlesBundleBuy(w, r, bundle)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiBuy operation middleware
+func (siw *ServerInterfaceWrapper) PostApiBuy(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiBuy(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiBuyItem operation middleware
+func (siw *ServerInterfaceWrapper) GetApiBuyItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "item" -------------
+	var item string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "item", chi.URLParam(r, "item"), &item, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "item", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiBuyItem(w, r, item)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiDrops operation middleware
+func (siw *ServerInterfaceWrapper) GetApiDrops(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiDrops(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiGifts operation middleware
+func (siw *ServerInterfaceWrapper) PostApiGifts(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiGifts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetApiInfo(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiMarketListings operation middleware
+func (siw *ServerInterfaceWrapper) GetApiMarketListings(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiMarketListingsParams
+
+	// ------------- Optional query parameter "item" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "item", r.URL.Query(), &params.Item)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "item", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiMarketListings(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiMarketListings operation middleware
+func (siw *ServerInterfaceWrapper) PostApiMarketListings(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiMarketListings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiMarketListingsListingIdBuy operation middleware
+func (siw *ServerInterfaceWrapper) PostApiMarketListingsListingIdBuy(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listingId" -------------
+	var listingId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listingId", chi.URLParam(r, "listingId"), &listingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listingId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiMarketListingsListingIdBuy(w, r, listingId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiMarketListingsListingIdCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostApiMarketListingsListingIdCancel(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listingId" -------------
+	var listingId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listingId", chi.URLParam(r, "listingId"), &listingId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listingId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiMarketListingsListingIdCancel(w, r, listingId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiOrders operation middleware
+func (siw *ServerInterfaceWrapper) GetApiOrders(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiOrders(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiOrdersOrderIdCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostApiOrdersOrderIdCancel(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", chi.URLParam(r, "orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiOrdersOrderIdCancel(w, r, orderId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiRaffles operation middleware
+func (siw *ServerInterfaceWrapper) GetApiRaffles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiRaffles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiRafflesRaffleId operation middleware
+func (siw *ServerInterfaceWrapper) GetApiRafflesRaffleId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "raffleId" -------------
+	var raffleId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "raffleId", chi.URLParam(r, "raffleId"), &raffleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "raffleId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiRafflesRaffleId(w, r, raffleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiRafflesRaffleIdTickets operation middleware
+func (siw *ServerInterfaceWrapper) PostApiRafflesRaffleIdTickets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "raffleId" -------------
+	var raffleId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "raffleId", chi.URLParam(r, "raffleId"), &raffleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "raffleId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiRafflesRaffleIdTickets(w, r, raffleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiSendCoin operation middleware
+func (siw *ServerInterfaceWrapper) PostApiSendCoin(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiSendCoin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffAuctions operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffAuctions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffAuctions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffBundles operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffBundles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffBundles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiStaffBundlesBundle operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiStaffBundlesBundle(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "bundle" -------------
+	var bundle string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "bundle", chi.URLParam(r, "bundle"), &bundle, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bundle", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiStaffBundlesBundle(w, r, bundle)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiStaffBundlesBundle operation middleware
+func (siw *ServerInterfaceWrapper) PutApiStaffBundlesBundle(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "bundle" -------------
+	var bundle string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "bundle", chi.URLParam(r, "bundle"), &bundle, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bundle", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiStaffBundlesBundle(w, r, bundle)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffDrops operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffDrops(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffDrops(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiStaffInvites operation middleware
+func (siw *ServerInterfaceWrapper) GetApiStaffInvites(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiStaffInvites(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffInvites operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffInvites(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffInvites(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiStaffOrders operation middleware
+func (siw *ServerInterfaceWrapper) GetApiStaffOrders(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiStaffOrdersParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiStaffOrders(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffOrdersOrderIdCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffOrdersOrderIdCancel(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", chi.URLParam(r, "orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffOrdersOrderIdCancel(w, r, orderId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffOrdersOrderIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffOrdersOrderIdStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", chi.URLParam(r, "orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffOrdersOrderIdStatus(w, r, orderId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiStaffProductsItemPrices operation middleware
+func (siw *ServerInterfaceWrapper) GetApiStaffProductsItemPrices(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "item" -------------
+	var item string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "item", chi.URLParam(r, "item"), &item, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "item", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiStaffProductsItemPrices(w, r, item)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffProductsItemPrices operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffProductsItemPrices(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "item" -------------
+	var item string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "item", chi.URLParam(r, "item"), &item, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "item", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffProductsItemPrices(w, r, item)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffProductsItemStock operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffProductsItemStock(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "item" -------------
+	var item string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "item", chi.URLParam(r, "item"), &item, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "item", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffProductsItemStock(w, r, item)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiStaffPromoCodes operation middleware
+func (siw *ServerInterfaceWrapper) GetApiStaffPromoCodes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiStaffPromoCodes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffPromoCodes operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffPromoCodes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffPromoCodes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffRaffles operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffRaffles(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffRaffles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiStaffRafflesRaffleIdCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostApiStaffRafflesRaffleIdCancel(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "raffleId" -------------
+	var raffleId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "raffleId", chi.URLParam(r, "raffleId"), &raffleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "raffleId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiStaffRafflesRaffleIdCancel(w, r, raffleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiTransferItem operation middleware
+func (siw *ServerInterfaceWrapper) PostApiTransferItem(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiTransferItem(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiUsersUsernameWishlist operation middleware
+func (siw *ServerInterfaceWrapper) GetApiUsersUsernameWishlist(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", chi.URLParam(r, "username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiUsersUsernameWishlist(w, r, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiWaitlist operation middleware
+func (siw *ServerInterfaceWrapper) GetApiWaitlist(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiWaitlist(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+// DeleteApiWaitlistItem operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiWaitlistItem(w http.ResponseWriter, r *http.Request) {
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	var err error
 
-// PostApiAuth operation middleware
-func (siw *ServerInterfaceWrapper) PostApiAuth(w http.ResponseWriter, r *http.Request) {
+	// ------------- Path parameter "item" -------------
+	var item string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "item", chi.URLParam(r, "item"), &item, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "item", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -75,7 +2605,7 @@ func (siw *ServerInterfaceWrapper) PostApiAuth(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostApiAuth(w, r)
+		siw.Handler.DeleteApiWaitlistItem(w, r, item)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -85,8 +2615,8 @@ func (siw *ServerInterfaceWrapper) PostApiAuth(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetApiBuyItem operation middleware
-func (siw *ServerInterfaceWrapper) GetApiBuyItem(w http.ResponseWriter, r *http.Request) {
+// PostApiWaitlistItem operation middleware
+func (siw *ServerInterfaceWrapper) PostApiWaitlistItem(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -106,7 +2636,7 @@ func (siw *ServerInterfaceWrapper) GetApiBuyItem(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetApiBuyItem(w, r, item)
+		siw.Handler.PostApiWaitlistItem(w, r, item)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -116,8 +2646,8 @@ func (siw *ServerInterfaceWrapper) GetApiBuyItem(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetApiInfo operation middleware
-func (siw *ServerInterfaceWrapper) GetApiInfo(w http.ResponseWriter, r *http.Request) {
+// GetApiWishlist operation middleware
+func (siw *ServerInterfaceWrapper) GetApiWishlist(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -126,7 +2656,7 @@ func (siw *ServerInterfaceWrapper) GetApiInfo(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetApiInfo(w, r)
+		siw.Handler.GetApiWishlist(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -136,8 +2666,8 @@ func (siw *ServerInterfaceWrapper) GetApiInfo(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// PostApiSendCoin operation middleware
-func (siw *ServerInterfaceWrapper) PostApiSendCoin(w http.ResponseWriter, r *http.Request) {
+// PatchApiWishlist operation middleware
+func (siw *ServerInterfaceWrapper) PatchApiWishlist(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -146,7 +2676,89 @@ func (siw *ServerInterfaceWrapper) PostApiSendCoin(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostApiSendCoin(w, r)
+		siw.Handler.PatchApiWishlist(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiWishlist operation middleware
+func (siw *ServerInterfaceWrapper) PostApiWishlist(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiWishlist(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiWishlistItem operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiWishlistItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "item" -------------
+	var item string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "item", chi.URLParam(r, "item"), &item, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "item", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiWishlistItem(w, r, item)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutApiWishlistItem operation middleware
+func (siw *ServerInterfaceWrapper) PutApiWishlistItem(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "item" -------------
+	var item string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "item", chi.URLParam(r, "item"), &item, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "item", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutApiWishlistItem(w, r, item)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -269,18 +2881,240 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/jwks.json", wrapper.GetWellKnownJwksJson)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/coins/grants", wrapper.PostApiAdminCoinsGrants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/login-failures", wrapper.GetApiAdminLoginFailures)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/login-failures/{kind}/{key}", wrapper.DeleteApiAdminLoginFailuresKindKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/service-accounts", wrapper.GetApiAdminServiceAccounts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/service-accounts", wrapper.PostApiAdminServiceAccounts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/service-accounts/{name}/keys", wrapper.PostApiAdminServiceAccountsNameKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/service-accounts/{name}/keys/{keyId}", wrapper.DeleteApiAdminServiceAccountsNameKeysKeyId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/two-factor/roles", wrapper.GetApiAdminTwoFactorRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/two-factor/roles/{role}", wrapper.DeleteApiAdminTwoFactorRolesRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/admin/two-factor/roles/{role}", wrapper.PutApiAdminTwoFactorRolesRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/admin/users/{username}/password-reset", wrapper.PostApiAdminUsersUsernamePasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/users/{username}/profile", wrapper.GetApiAdminUsersUsernameProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/admin/users/{username}/roles", wrapper.GetApiAdminUsersUsernameRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/users/{username}/roles/{role}", wrapper.DeleteApiAdminUsersUsernameRolesRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/admin/users/{username}/roles/{role}", wrapper.PutApiAdminUsersUsernameRolesRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/admin/users/{username}/two-factor", wrapper.DeleteApiAdminUsersUsernameTwoFactor)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/auctions", wrapper.GetApiAuctions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/auctions/{auctionId}", wrapper.GetApiAuctionsAuctionId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auctions/{auctionId}/bids", wrapper.PostApiAuctionsAuctionIdBids)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/auth", wrapper.PostApiAuth)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/login", wrapper.PostApiAuthLogin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/logout", wrapper.PostApiAuthLogout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/password", wrapper.PostApiAuthPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/password/reset", wrapper.PostApiAuthPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/refresh", wrapper.PostApiAuthRefresh)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/register", wrapper.PostApiAuthRegister)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/auth/sso/callback", wrapper.GetApiAuthSsoCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/auth/sso/start", wrapper.GetApiAuthSsoStart)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/auth/two-factor", wrapper.GetApiAuthTwoFactor)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/two-factor/confirm", wrapper.PostApiAuthTwoFactorConfirm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/two-factor/enroll", wrapper.PostApiAuthTwoFactorEnroll)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/auth/two-factor/verify", wrapper.PostApiAuthTwoFactorVerify)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/bundles", wrapper.GetApiBundles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/bundles/{bundle}", wrapper.GetApiBundlesBundle)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/bundles/{bundle}/buy", wrapper.PostApiBundlesBundleBuy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/buy", wrapper.PostApiBuy)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/buy/{item}", wrapper.GetApiBuyItem)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/drops", wrapper.GetApiDrops)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/gifts", wrapper.PostApiGifts)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/info", wrapper.GetApiInfo)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/market/listings", wrapper.GetApiMarketListings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/market/listings", wrapper.PostApiMarketListings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/market/listings/{listingId}/buy", wrapper.PostApiMarketListingsListingIdBuy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/market/listings/{listingId}/cancel", wrapper.PostApiMarketListingsListingIdCancel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/orders", wrapper.GetApiOrders)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/orders/{orderId}/cancel", wrapper.PostApiOrdersOrderIdCancel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/raffles", wrapper.GetApiRaffles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/raffles/{raffleId}", wrapper.GetApiRafflesRaffleId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/raffles/{raffleId}/tickets", wrapper.PostApiRafflesRaffleIdTickets)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/sendCoin", wrapper.PostApiSendCoin)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/auctions", wrapper.PostApiStaffAuctions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/bundles", wrapper.PostApiStaffBundles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/staff/bundles/{bundle}", wrapper.DeleteApiStaffBundlesBundle)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/staff/bundles/{bundle}", wrapper.PutApiStaffBundlesBundle)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/drops", wrapper.PostApiStaffDrops)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/staff/invites", wrapper.GetApiStaffInvites)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/invites", wrapper.PostApiStaffInvites)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/staff/orders", wrapper.GetApiStaffOrders)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/orders/{orderId}/cancel", wrapper.PostApiStaffOrdersOrderIdCancel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/orders/{orderId}/status", wrapper.PostApiStaffOrdersOrderIdStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/staff/products/{item}/prices", wrapper.GetApiStaffProductsItemPrices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/products/{item}/prices", wrapper.PostApiStaffProductsItemPrices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/products/{item}/stock", wrapper.PostApiStaffProductsItemStock)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/staff/promo-codes", wrapper.GetApiStaffPromoCodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/promo-codes", wrapper.PostApiStaffPromoCodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/raffles", wrapper.PostApiStaffRaffles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/staff/raffles/{raffleId}/cancel", wrapper.PostApiStaffRafflesRaffleIdCancel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/transferItem", wrapper.PostApiTransferItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/users/{username}/wishlist", wrapper.GetApiUsersUsernameWishlist)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/waitlist", wrapper.GetApiWaitlist)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/waitlist/{item}", wrapper.DeleteApiWaitlistItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/waitlist/{item}", wrapper.PostApiWaitlistItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/wishlist", wrapper.GetApiWishlist)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/wishlist", wrapper.PatchApiWishlist)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/wishlist", wrapper.PostApiWishlist)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/wishlist/{item}", wrapper.DeleteApiWishlistItem)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/wishlist/{item}", wrapper.PutApiWishlistItem)
+	})
 
 	return r
 }
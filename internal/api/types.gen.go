@@ -3,10 +3,183 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package api
 
+import (
+	"time"
+)
+
 const (
+	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for AuctionStatus.
+const (
+	AuctionStatusActive   AuctionStatus = "active"
+	AuctionStatusEnded    AuctionStatus = "ended"
+	AuctionStatusSold     AuctionStatus = "sold"
+	AuctionStatusUnsold   AuctionStatus = "unsold"
+	AuctionStatusUpcoming AuctionStatus = "upcoming"
+)
+
+// Defines values for DiscountType.
+const (
+	Fixed   DiscountType = "fixed"
+	Percent DiscountType = "percent"
+)
+
+// Defines values for DropStatus.
+const (
+	DropStatusActive   DropStatus = "active"
+	DropStatusEnded    DropStatus = "ended"
+	DropStatusSoldOut  DropStatus = "sold_out"
+	DropStatusUpcoming DropStatus = "upcoming"
+)
+
+// Defines values for ListingStatus.
+const (
+	ListingStatusActive    ListingStatus = "active"
+	ListingStatusCancelled ListingStatus = "cancelled"
+	ListingStatusSold      ListingStatus = "sold"
+)
+
+// Defines values for OrderStatus.
+const (
+	OrderStatusCancelled      OrderStatus = "cancelled"
+	OrderStatusDelivered      OrderStatus = "delivered"
+	OrderStatusPlaced         OrderStatus = "placed"
+	OrderStatusReadyForPickup OrderStatus = "ready_for_pickup"
+)
+
+// Defines values for RaffleStatus.
+const (
+	RaffleStatusCancelled RaffleStatus = "cancelled"
+	RaffleStatusClosed    RaffleStatus = "closed"
+	RaffleStatusDrawn     RaffleStatus = "drawn"
+	RaffleStatusOpen      RaffleStatus = "open"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	// CreatedAt Время выпуска.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ExpiresAt Срок действия ключа.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Id Идентификатор ключа, входит в сам ключ.
+	Id string `json:"id"`
+
+	// Key Ключ целиком. Возвращается только при выпуске.
+	Key *string `json:"key,omitempty"`
+
+	// LastUsedAt Время последнего использования, с точностью до минуты.
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	// RevokedAt Время отзыва.
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+
+	// Scopes Разрешения ключа.
+	Scopes []string `json:"scopes"`
+}
+
+// APIKeyRequest defines model for APIKeyRequest.
+type APIKeyRequest struct {
+	// ExpiresAt Срок действия ключа. Без него ключ действует до отзыва.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Scopes Разрешения ключа — coins:grant, coins:send или catalog:write.
+	Scopes []string `json:"scopes"`
+}
+
+// Auction defines model for Auction.
+type Auction struct {
+	// ClosedAt Время подведения итогов.
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
+
+	// CurrentBid Текущая максимальная ставка.
+	CurrentBid *int `json:"currentBid,omitempty"`
+
+	// EndsAt Окончание аукциона с учетом продлений.
+	EndsAt time.Time `json:"endsAt"`
+
+	// ExtensionSeconds Ставка, сделанная ближе этого времени к концу, продлевает аукцион на это время.
+	ExtensionSeconds int `json:"extensionSeconds"`
+
+	// Id Идентификатор аукциона.
+	Id int `json:"id"`
+
+	// Leader Лидер аукциона, после завершения — победитель.
+	Leader *string `json:"leader,omitempty"`
+
+	// MinIncrement Минимальный шаг ставки.
+	MinIncrement int `json:"minIncrement"`
+
+	// MinimumBid Минимальная сумма следующей ставки.
+	MinimumBid int `json:"minimumBid"`
+
+	// Product Товар, который разыгрывается.
+	Product string `json:"product"`
+
+	// Quantity Количество единиц в лоте.
+	Quantity int `json:"quantity"`
+
+	// ReserveMet Достигнута ли резервная цена.
+	ReserveMet bool `json:"reserveMet"`
+
+	// StartsAt Начало приема ставок.
+	StartsAt time.Time `json:"startsAt"`
+
+	// Status Состояние аукциона.
+	Status AuctionStatus `json:"status"`
+}
+
+// AuctionBid defines model for AuctionBid.
+type AuctionBid struct {
+	// Amount Сумма ставки.
+	Amount int `json:"amount"`
+
+	// Bidder Участник, сделавший ставку.
+	Bidder string `json:"bidder"`
+
+	// CreatedAt Время ставки.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AuctionDetails defines model for AuctionDetails.
+type AuctionDetails struct {
+	Auction Auction `json:"auction"`
+
+	// Bids Ставки от большей к меньшей.
+	Bids []AuctionBid `json:"bids"`
+}
+
+// AuctionRequest defines model for AuctionRequest.
+type AuctionRequest struct {
+	// EndsAt Окончание аукциона.
+	EndsAt time.Time `json:"endsAt"`
+
+	// ExtensionSeconds Окно защиты от ставок в последний момент в секундах (по умолчанию 120, 0 — отключено).
+	ExtensionSeconds *int `json:"extensionSeconds,omitempty"`
+
+	// MinIncrement Минимальный шаг ставки (по умолчанию 1).
+	MinIncrement *int `json:"minIncrement,omitempty"`
+
+	// Product Товар, который разыгрывается.
+	Product string `json:"product"`
+
+	// Quantity Количество единиц в лоте (по умолчанию 1).
+	Quantity *int `json:"quantity,omitempty"`
+
+	// ReservePrice Резервная цена. Если максимальная ставка ниже, лот не продается (по умолчанию 0).
+	ReservePrice *int `json:"reservePrice,omitempty"`
+
+	// StartsAt Начало приема ставок.
+	StartsAt time.Time `json:"startsAt"`
+}
+
+// AuctionStatus Состояние аукциона.
+type AuctionStatus string
+
 // AuthRequest defines model for AuthRequest.
 type AuthRequest struct {
 	// Password Пароль для аутентификации.
@@ -18,16 +191,172 @@ type AuthRequest struct {
 
 // AuthResponse defines model for AuthResponse.
 type AuthResponse struct {
+	// ExpiresAt Когда истекает JWT-токен.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// RefreshToken Одноразовый токен для получения новой пары токенов через /api/auth/refresh.
+	RefreshToken *string `json:"refreshToken,omitempty"`
+
 	// Token JWT-токен для доступа к защищенным ресурсам.
 	Token *string `json:"token,omitempty"`
 }
 
+// BidRequest defines model for BidRequest.
+type BidRequest struct {
+	// Amount Сумма ставки в монетах.
+	Amount int `json:"amount"`
+}
+
+// Bundle defines model for Bundle.
+type Bundle struct {
+	// Available Можно ли сейчас купить каждый товар из набора.
+	Available bool `json:"available"`
+
+	// Items Состав набора.
+	Items []BundleItem `json:"items"`
+
+	// Name Название набора.
+	Name string `json:"name"`
+
+	// Price Цена набора.
+	Price int `json:"price"`
+}
+
+// BundleBuyRequest defines model for BundleBuyRequest.
+type BundleBuyRequest struct {
+	// Quantity Количество наборов (по умолчанию 1).
+	Quantity *int `json:"quantity,omitempty"`
+}
+
+// BundleItem defines model for BundleItem.
+type BundleItem struct {
+	// Product Товар из набора.
+	Product string `json:"product"`
+
+	// Quantity Количество единиц товара в одном наборе.
+	Quantity int `json:"quantity"`
+}
+
+// BundleRequest defines model for BundleRequest.
+type BundleRequest struct {
+	// Items Состав набора.
+	Items []BundleItem `json:"items"`
+
+	// Name Название набора.
+	Name string `json:"name"`
+
+	// Price Цена набора.
+	Price int `json:"price"`
+}
+
+// BundleUpdateRequest defines model for BundleUpdateRequest.
+type BundleUpdateRequest struct {
+	// Items Состав набора.
+	Items []BundleItem `json:"items"`
+
+	// Price Цена набора.
+	Price int `json:"price"`
+}
+
+// BuyRequest defines model for BuyRequest.
+type BuyRequest struct {
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// PromoCode Промокод на скидку.
+	PromoCode *string `json:"promoCode,omitempty"`
+
+	// Quantity Количество покупаемых единиц.
+	Quantity int `json:"quantity"`
+}
+
+// CoinGrantRequest defines model for CoinGrantRequest.
+type CoinGrantRequest struct {
+	// Amount Количество монет, больше нуля.
+	Amount int `json:"amount"`
+
+	// ToUser Пользователь, которому начисляются монеты.
+	ToUser string `json:"toUser"`
+}
+
+// DiscountType Тип скидки — процент или фиксированное количество монет.
+type DiscountType string
+
+// Drop defines model for Drop.
+type Drop struct {
+	// EndsAt Окончание дропа. Если не указано — дроп длится до конца остатка.
+	EndsAt *time.Time `json:"endsAt,omitempty"`
+
+	// Id Идентификатор дропа.
+	Id int `json:"id"`
+
+	// PerUserLimit Сколько единиц может купить один пользователь.
+	PerUserLimit int `json:"perUserLimit"`
+
+	// Product Товар, который продается в дропе.
+	Product string `json:"product"`
+
+	// Remaining Сколько единиц еще доступно.
+	Remaining int `json:"remaining"`
+
+	// StartsAt Момент, с которого товар можно купить.
+	StartsAt time.Time `json:"startsAt"`
+
+	// Status Состояние дропа.
+	Status DropStatus `json:"status"`
+
+	// Stock Количество единиц в дропе.
+	Stock int `json:"stock"`
+}
+
+// DropRequest defines model for DropRequest.
+type DropRequest struct {
+	// EndsAt Окончание дропа. Если не указано — дроп длится до конца остатка.
+	EndsAt *time.Time `json:"endsAt,omitempty"`
+
+	// PerUserLimit Сколько единиц может купить один пользователь (по умолчанию 1).
+	PerUserLimit *int `json:"perUserLimit,omitempty"`
+
+	// Product Товар, который продается в дропе.
+	Product string `json:"product"`
+
+	// StartsAt Момент, с которого товар можно купить.
+	StartsAt time.Time `json:"startsAt"`
+
+	// Stock Количество единиц в дропе.
+	Stock int `json:"stock"`
+}
+
+// DropStatus Состояние дропа.
+type DropStatus string
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	// Errors Сообщение об ошибке, описывающее проблему.
 	Errors *string `json:"errors,omitempty"`
 }
 
+// GiftRequest defines model for GiftRequest.
+type GiftRequest struct {
+	// Anonymous Скрыть имя отправителя от получателя.
+	Anonymous *bool `json:"anonymous,omitempty"`
+
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// Message Поздравление для получателя.
+	Message *string `json:"message,omitempty"`
+
+	// PromoCode Промокод на скидку.
+	PromoCode *string `json:"promoCode,omitempty"`
+
+	// Quantity Количество единиц (по умолчанию 1).
+	Quantity *int `json:"quantity,omitempty"`
+
+	// ToUser Имя пользователя, который получит подарок.
+	ToUser string `json:"toUser"`
+}
+
 // InfoResponse defines model for InfoResponse.
 type InfoResponse struct {
 	CoinHistory *struct {
@@ -48,7 +377,13 @@ type InfoResponse struct {
 	} `json:"coinHistory,omitempty"`
 
 	// Coins Количество доступных монет.
-	Coins     *int `json:"coins,omitempty"`
+	Coins *int `json:"coins,omitempty"`
+
+	// Gifts Подарки, полученные и отправленные пользователем.
+	Gifts *struct {
+		Received *[]ReceivedGift `json:"received,omitempty"`
+		Sent     *[]SentGift     `json:"sent,omitempty"`
+	} `json:"gifts,omitempty"`
 	Inventory *[]struct {
 		// Quantity Количество предметов.
 		Quantity *int `json:"quantity,omitempty"`
@@ -56,6 +391,487 @@ type InfoResponse struct {
 		// Type Тип предмета.
 		Type *string `json:"type,omitempty"`
 	} `json:"inventory,omitempty"`
+
+	// ItemHistory История передачи предметов между пользователями.
+	ItemHistory *struct {
+		Received *[]ReceivedItem `json:"received,omitempty"`
+		Sent     *[]SentItem     `json:"sent,omitempty"`
+	} `json:"itemHistory,omitempty"`
+}
+
+// Invite defines model for Invite.
+type Invite struct {
+	// Code Код приглашения.
+	Code string `json:"code"`
+
+	// CreatedAt Время создания кода.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CreatedBy Сотрудник, выпустивший код.
+	CreatedBy string `json:"createdBy"`
+
+	// ExpiresAt Срок действия кода.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// UsedAt Время использования кода.
+	UsedAt *time.Time `json:"usedAt,omitempty"`
+
+	// UsedBy Пользователь, зарегистрировавшийся по коду.
+	UsedBy *string `json:"usedBy,omitempty"`
+}
+
+// InviteRequest defines model for InviteRequest.
+type InviteRequest struct {
+	// ExpiresAt Срок действия кода. Если не указан, код действует бессрочно.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// JSONWebKey defines model for JSONWebKey.
+type JSONWebKey struct {
+	// Alg Алгоритм подписи — RS256 или EdDSA.
+	Alg string `json:"alg"`
+
+	// Crv Кривая OKP-ключа.
+	Crv *string `json:"crv,omitempty"`
+
+	// E Открытая экспонента RSA-ключа (base64url).
+	E *string `json:"e,omitempty"`
+
+	// Kid Идентификатор ключа, совпадает с заголовком kid токена.
+	Kid string `json:"kid"`
+
+	// Kty Тип ключа — RSA или OKP (Ed25519).
+	Kty string `json:"kty"`
+
+	// N Модуль RSA-ключа (base64url).
+	N *string `json:"n,omitempty"`
+
+	// Use Назначение ключа, всегда sig.
+	Use string `json:"use"`
+
+	// X Открытый OKP-ключ (base64url).
+	X *string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet defines model for JSONWebKeySet.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// Listing defines model for Listing.
+type Listing struct {
+	// CreatedAt Время создания объявления.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Id Идентификатор объявления.
+	Id int `json:"id"`
+
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// Price Цена за единицу в монетах.
+	Price int `json:"price"`
+
+	// Quantity Сколько единиц выставлено.
+	Quantity int `json:"quantity"`
+
+	// Remaining Сколько единиц еще можно купить.
+	Remaining int `json:"remaining"`
+
+	// Seller Имя продавца.
+	Seller string `json:"seller"`
+
+	// Status Состояние объявления.
+	Status ListingStatus `json:"status"`
+}
+
+// ListingRequest defines model for ListingRequest.
+type ListingRequest struct {
+	// Item Название предмета из инвентаря.
+	Item string `json:"item"`
+
+	// Price Цена за единицу в монетах.
+	Price int `json:"price"`
+
+	// Quantity Сколько единиц выставить.
+	Quantity int `json:"quantity"`
+}
+
+// ListingStatus Состояние объявления.
+type ListingStatus string
+
+// LoginFailures defines model for LoginFailures.
+type LoginFailures struct {
+	// BlockedUntil До какого времени вход запрещен, с учетом задержки между попытками и блокировки. Отсутствует, если вход разрешен.
+	BlockedUntil *time.Time `json:"blockedUntil,omitempty"`
+
+	// Failures Количество неудачных попыток подряд.
+	Failures int `json:"failures"`
+
+	// Key Имя пользователя или адрес клиента.
+	Key string `json:"key"`
+
+	// Kind По чему считаются попытки — username или ip.
+	Kind string `json:"kind"`
+
+	// LastFailureAt Время последней неудачной попытки.
+	LastFailureAt time.Time `json:"lastFailureAt"`
+
+	// LockedUntil Блокировка после превышения лимита попыток.
+	LockedUntil *time.Time `json:"lockedUntil,omitempty"`
+}
+
+// MarketBuyRequest defines model for MarketBuyRequest.
+type MarketBuyRequest struct {
+	// Quantity Количество покупаемых единиц (по умолчанию 1).
+	Quantity *int `json:"quantity,omitempty"`
+}
+
+// Order defines model for Order.
+type Order struct {
+	// CreatedAt Время оформления заказа.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DeliveredAt Время выдачи заказа.
+	DeliveredAt *time.Time `json:"deliveredAt,omitempty"`
+
+	// Discount Скидка по промокоду.
+	Discount int `json:"discount"`
+
+	// Gift Сведения о подарке, если заказ оформлен для другого пользователя.
+	Gift *OrderGift `json:"gift,omitempty"`
+
+	// Id Идентификатор заказа.
+	Id    int         `json:"id"`
+	Items []OrderItem `json:"items"`
+
+	// Status Статус заказа.
+	Status OrderStatus `json:"status"`
+
+	// Total Сумма, списанная за заказ.
+	Total int `json:"total"`
+
+	// UpdatedAt Время последнего изменения статуса.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// OrderGift Сведения о подарке, если заказ оформлен для другого пользователя.
+type OrderGift struct {
+	// Anonymous Имя отправителя скрыто от получателя.
+	Anonymous bool `json:"anonymous"`
+
+	// Message Поздравление для получателя.
+	Message *string `json:"message,omitempty"`
+
+	// ToUser Получатель подарка.
+	ToUser string `json:"toUser"`
+}
+
+// OrderItem defines model for OrderItem.
+type OrderItem struct {
+	// Bundle Набор, в составе которого куплен предмет. Цена набора входит в сумму заказа, а цена за единицу равна 0.
+	Bundle *string `json:"bundle,omitempty"`
+
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// Quantity Количество единиц.
+	Quantity int `json:"quantity"`
+
+	// UnitPrice Цена за единицу на момент покупки.
+	UnitPrice int `json:"unitPrice"`
+}
+
+// OrderStatus Статус заказа.
+type OrderStatus string
+
+// OrderStatusUpdateRequest defines model for OrderStatusUpdateRequest.
+type OrderStatusUpdateRequest struct {
+	// Status Статус заказа.
+	Status OrderStatus `json:"status"`
+}
+
+// PasswordChangeRequest defines model for PasswordChangeRequest.
+type PasswordChangeRequest struct {
+	// CurrentPassword Текущий пароль.
+	CurrentPassword string `json:"currentPassword"`
+
+	// NewPassword Новый пароль.
+	NewPassword string `json:"newPassword"`
+}
+
+// PasswordReset defines model for PasswordReset.
+type PasswordReset struct {
+	// ExpiresAt Срок действия токена.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Username Пользователь, которому отправлен токен сброса.
+	Username string `json:"username"`
+}
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	// NewPassword Новый пароль.
+	NewPassword string `json:"newPassword"`
+
+	// Token Токен сброса, полученный пользователем.
+	Token string `json:"token"`
+}
+
+// PricePoint defines model for PricePoint.
+type PricePoint struct {
+	// CreatedAt Время добавления цены.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Current Действует ли эта цена сейчас.
+	Current bool `json:"current"`
+
+	// EffectiveFrom Момент, с которого действует цена.
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+
+	// Price Цена в монетах.
+	Price int `json:"price"`
+}
+
+// PriceScheduleRequest defines model for PriceScheduleRequest.
+type PriceScheduleRequest struct {
+	// EffectiveFrom Момент, с которого действует цена. Если не указан — цена меняется сразу.
+	EffectiveFrom *time.Time `json:"effectiveFrom,omitempty"`
+
+	// Price Новая цена в монетах.
+	Price int `json:"price"`
+}
+
+// PromoCode defines model for PromoCode.
+type PromoCode struct {
+	// Code Промокод.
+	Code string `json:"code"`
+
+	// CreatedAt Время создания промокода.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DiscountType Тип скидки — процент или фиксированное количество монет.
+	DiscountType DiscountType `json:"discountType"`
+
+	// DiscountValue Размер скидки в процентах или монетах.
+	DiscountValue int `json:"discountValue"`
+
+	// Id Идентификатор промокода.
+	Id int `json:"id"`
+
+	// MaxUses Общий лимит использований.
+	MaxUses *int `json:"maxUses,omitempty"`
+
+	// MaxUsesPerUser Лимит использований одним пользователем.
+	MaxUsesPerUser *int `json:"maxUsesPerUser,omitempty"`
+
+	// Product Товар, на который действует промокод. Если не указан — действует на весь каталог.
+	Product *string `json:"product,omitempty"`
+
+	// ValidFrom Начало действия промокода.
+	ValidFrom time.Time `json:"validFrom"`
+
+	// ValidUntil Окончание действия промокода.
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+}
+
+// PromoCodeRequest defines model for PromoCodeRequest.
+type PromoCodeRequest struct {
+	// Code Промокод.
+	Code string `json:"code"`
+
+	// DiscountType Тип скидки — процент или фиксированное количество монет.
+	DiscountType DiscountType `json:"discountType"`
+
+	// DiscountValue Размер скидки в процентах или монетах.
+	DiscountValue int `json:"discountValue"`
+
+	// MaxUses Общий лимит использований.
+	MaxUses *int `json:"maxUses,omitempty"`
+
+	// MaxUsesPerUser Лимит использований одним пользователем.
+	MaxUsesPerUser *int `json:"maxUsesPerUser,omitempty"`
+
+	// Product Товар, на который действует промокод. Если не указан — действует на весь каталог.
+	Product *string `json:"product,omitempty"`
+
+	// ValidFrom Начало действия промокода.
+	ValidFrom *time.Time `json:"validFrom,omitempty"`
+
+	// ValidUntil Окончание действия промокода.
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+}
+
+// PromoCodeStats defines model for PromoCodeStats.
+type PromoCodeStats struct {
+	PromoCode PromoCode `json:"promoCode"`
+
+	// Redemptions Количество использований.
+	Redemptions int `json:"redemptions"`
+
+	// TotalDiscount Суммарная скидка в монетах.
+	TotalDiscount int `json:"totalDiscount"`
+
+	// UniqueUsers Количество разных пользователей, применивших промокод.
+	UniqueUsers int `json:"uniqueUsers"`
+}
+
+// Raffle defines model for Raffle.
+type Raffle struct {
+	// DrawAt Время окончания продажи билетов и розыгрыша.
+	DrawAt time.Time `json:"drawAt"`
+
+	// DrawnAt Время розыгрыша.
+	DrawnAt *time.Time `json:"drawnAt,omitempty"`
+
+	// Id Идентификатор лотереи.
+	Id int `json:"id"`
+
+	// MaxTicketsPerUser Максимальное количество билетов у одного пользователя.
+	MaxTicketsPerUser *int `json:"maxTicketsPerUser,omitempty"`
+
+	// Prizes Количество выигрышных билетов, каждый получает одну единицу товара.
+	Prizes int `json:"prizes"`
+
+	// Product Товар, который разыгрывается.
+	Product string `json:"product"`
+
+	// Seed Сид розыгрыша в hex, раскрывается после розыгрыша или отмены.
+	Seed *string `json:"seed,omitempty"`
+
+	// SeedCommitment SHA-256 от сида розыгрыша в hex, опубликованный при создании лотереи.
+	SeedCommitment string `json:"seedCommitment"`
+
+	// Status Состояние лотереи.
+	Status RaffleStatus `json:"status"`
+
+	// TicketPrice Цена одного билета.
+	TicketPrice int `json:"ticketPrice"`
+
+	// TicketsSold Количество проданных билетов.
+	TicketsSold int `json:"ticketsSold"`
+}
+
+// RaffleDetails defines model for RaffleDetails.
+type RaffleDetails struct {
+	Raffle Raffle `json:"raffle"`
+
+	// Tickets Все билеты в порядке номеров.
+	Tickets []RaffleTicket `json:"tickets"`
+}
+
+// RaffleRequest defines model for RaffleRequest.
+type RaffleRequest struct {
+	// DrawAt Время окончания продажи билетов и розыгрыша.
+	DrawAt time.Time `json:"drawAt"`
+
+	// MaxTicketsPerUser Максимальное количество билетов у одного пользователя (по умолчанию без ограничения).
+	MaxTicketsPerUser *int `json:"maxTicketsPerUser,omitempty"`
+
+	// Prizes Количество выигрышных билетов (по умолчанию 1).
+	Prizes *int `json:"prizes,omitempty"`
+
+	// Product Товар, который разыгрывается.
+	Product string `json:"product"`
+
+	// TicketPrice Цена одного билета.
+	TicketPrice int `json:"ticketPrice"`
+}
+
+// RaffleStatus Состояние лотереи.
+type RaffleStatus string
+
+// RaffleTicket defines model for RaffleTicket.
+type RaffleTicket struct {
+	// Holder Владелец билета.
+	Holder string `json:"holder"`
+
+	// Number Номер билета, начиная с 1.
+	Number int `json:"number"`
+
+	// PrizeRank Порядковый номер выигрыша, если билет выиграл.
+	PrizeRank *int `json:"prizeRank,omitempty"`
+}
+
+// RaffleTicketsRequest defines model for RaffleTicketsRequest.
+type RaffleTicketsRequest struct {
+	// Count Количество билетов (по умолчанию 1).
+	Count *int `json:"count,omitempty"`
+}
+
+// RaffleTicketsResponse defines model for RaffleTicketsResponse.
+type RaffleTicketsResponse struct {
+	// Numbers Номера купленных билетов.
+	Numbers []int `json:"numbers"`
+
+	// Total Списанная сумма.
+	Total int `json:"total"`
+}
+
+// ReceivedGift defines model for ReceivedGift.
+type ReceivedGift struct {
+	// FromUser Отправитель подарка. Не указывается, если подарок анонимный.
+	FromUser *string `json:"fromUser,omitempty"`
+
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// Message Поздравление.
+	Message *string `json:"message,omitempty"`
+
+	// Quantity Количество единиц.
+	Quantity int `json:"quantity"`
+
+	// ReceivedAt Время получения подарка.
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// ReceivedItem defines model for ReceivedItem.
+type ReceivedItem struct {
+	// FromUser Имя пользователя, который передал предметы.
+	FromUser string `json:"fromUser"`
+
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// Quantity Количество единиц.
+	Quantity int `json:"quantity"`
+
+	// ReceivedAt Время передачи.
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// RefreshRequest defines model for RefreshRequest.
+type RefreshRequest struct {
+	// RefreshToken Refresh-токен, полученный при входе или предыдущем обновлении.
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RegisterRequest defines model for RegisterRequest.
+type RegisterRequest struct {
+	// InviteCode Код приглашения, обязателен в режиме регистрации по приглашениям.
+	InviteCode *string `json:"inviteCode,omitempty"`
+
+	// Password Пароль не короче минимальной длины из конфигурации.
+	Password string `json:"password"`
+
+	// Username Имя пользователя, от 3 до 32 латинских букв, цифр и символов '.', '-', '_'.
+	Username string `json:"username"`
+}
+
+// RestockRequest defines model for RestockRequest.
+type RestockRequest struct {
+	// Quantity Сколько единиц поступило на склад.
+	Quantity int `json:"quantity"`
+}
+
+// RestockResponse defines model for RestockResponse.
+type RestockResponse struct {
+	// Notified Сколько подписчиков уведомлено.
+	Notified int `json:"notified"`
+
+	// Reserved Сколько единиц временно зарезервировано за первыми подписчиками.
+	Reserved int `json:"reserved"`
 }
 
 // SendCoinRequest defines model for SendCoinRequest.
@@ -67,8 +883,382 @@ type SendCoinRequest struct {
 	ToUser string `json:"toUser"`
 }
 
+// SentGift defines model for SentGift.
+type SentGift struct {
+	// Anonymous Имя отправителя скрыто от получателя.
+	Anonymous bool `json:"anonymous"`
+
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// Message Поздравление.
+	Message *string `json:"message,omitempty"`
+
+	// Quantity Количество единиц.
+	Quantity int `json:"quantity"`
+
+	// SentAt Время отправки подарка.
+	SentAt time.Time `json:"sentAt"`
+
+	// ToUser Получатель подарка.
+	ToUser string `json:"toUser"`
+}
+
+// SentItem defines model for SentItem.
+type SentItem struct {
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// Quantity Количество единиц.
+	Quantity int `json:"quantity"`
+
+	// SentAt Время передачи.
+	SentAt time.Time `json:"sentAt"`
+
+	// ToUser Имя пользователя, которому переданы предметы.
+	ToUser string `json:"toUser"`
+}
+
+// ServiceAccount defines model for ServiceAccount.
+type ServiceAccount struct {
+	// CreatedAt Время создания.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CreatedBy Администратор, создавший аккаунт.
+	CreatedBy string `json:"createdBy"`
+
+	// Description Назначение аккаунта.
+	Description string `json:"description"`
+
+	// Keys API-ключи аккаунта, новые первыми. Сами ключи не возвращаются.
+	Keys []APIKey `json:"keys"`
+
+	// Name Имя сервисного аккаунта.
+	Name string `json:"name"`
+}
+
+// ServiceAccountRequest defines model for ServiceAccountRequest.
+type ServiceAccountRequest struct {
+	// Description Назначение аккаунта.
+	Description *string `json:"description,omitempty"`
+
+	// Name Имя сервисного аккаунта — от 3 до 32 строчных латинских букв, цифр и символов -.
+	Name string `json:"name"`
+}
+
+// Trade defines model for Trade.
+type Trade struct {
+	// CreatedAt Время сделки.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Fee Комиссия маркетплейса, которая сжигается.
+	Fee int `json:"fee"`
+
+	// Id Идентификатор сделки.
+	Id int `json:"id"`
+
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// ListingId Идентификатор объявления.
+	ListingId int `json:"listingId"`
+
+	// Quantity Количество купленных единиц.
+	Quantity int `json:"quantity"`
+
+	// Seller Имя продавца.
+	Seller string `json:"seller"`
+
+	// Total Сколько монет списано у покупателя.
+	Total int `json:"total"`
+}
+
+// TransferItemRequest defines model for TransferItemRequest.
+type TransferItemRequest struct {
+	// Item Название предмета.
+	Item string `json:"item"`
+
+	// Quantity Количество передаваемых единиц.
+	Quantity int `json:"quantity"`
+
+	// ToUser Имя пользователя, которому нужно передать предметы.
+	ToUser string `json:"toUser"`
+}
+
+// TwoFactorChallenge defines model for TwoFactorChallenge.
+type TwoFactorChallenge struct {
+	// ChallengeToken Токен проверки для /api/auth/two-factor/verify.
+	ChallengeToken string `json:"challengeToken"`
+
+	// ExpiresAt До какого времени нужно ввести код.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// TwoFactorCodeRequest defines model for TwoFactorCodeRequest.
+type TwoFactorCodeRequest struct {
+	// Code Шестизначный код из приложения-аутентификатора.
+	Code string `json:"code"`
+}
+
+// TwoFactorEnrollment defines model for TwoFactorEnrollment.
+type TwoFactorEnrollment struct {
+	// ProvisioningUri URI otpauth://totp/... для QR-кода.
+	ProvisioningUri string `json:"provisioningUri"`
+
+	// Secret Секрет TOTP в base32 для ручного ввода в приложение.
+	Secret string `json:"secret"`
+}
+
+// TwoFactorRecoveryCodes defines model for TwoFactorRecoveryCodes.
+type TwoFactorRecoveryCodes struct {
+	// RecoveryCodes Одноразовые коды восстановления на случай потери приложения.
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// TwoFactorRoles defines model for TwoFactorRoles.
+type TwoFactorRoles struct {
+	// Roles Роли, доступные только в сессиях, начатых с вторым фактором.
+	Roles []string `json:"roles"`
+}
+
+// TwoFactorStatus defines model for TwoFactorStatus.
+type TwoFactorStatus struct {
+	// ConfirmedAt Когда она была включена.
+	ConfirmedAt *time.Time `json:"confirmedAt,omitempty"`
+
+	// Enabled Включена ли двухфакторная аутентификация.
+	Enabled bool `json:"enabled"`
+
+	// RecoveryCodesLeft Сколько кодов восстановления еще не использовано.
+	RecoveryCodesLeft int `json:"recoveryCodesLeft"`
+
+	// Required Требуется ли второй фактор для одной из ролей пользователя.
+	Required bool `json:"required"`
+}
+
+// TwoFactorVerifyRequest defines model for TwoFactorVerifyRequest.
+type TwoFactorVerifyRequest struct {
+	// ChallengeToken Токен проверки из ответа на вход.
+	ChallengeToken string `json:"challengeToken"`
+
+	// Code Шестизначный код из приложения или код восстановления вида abcd-efgh.
+	Code string `json:"code"`
+}
+
+// UserProfile defines model for UserProfile.
+type UserProfile struct {
+	// Department Подразделение.
+	Department string `json:"department"`
+
+	// DisplayName Отображаемое имя.
+	DisplayName string `json:"displayName"`
+
+	// Dn Отличительное имя (DN) записи пользователя в каталоге.
+	Dn string `json:"dn"`
+
+	// Email Электронная почта.
+	Email string `json:"email"`
+
+	// Source Каталог, из которого синхронизирован профиль (ldap).
+	Source string `json:"source"`
+
+	// SyncedAt Время последней синхронизации атрибутов.
+	SyncedAt time.Time `json:"syncedAt"`
+
+	// Username Имя пользователя.
+	Username string `json:"username"`
+}
+
+// UserRoles defines model for UserRoles.
+type UserRoles struct {
+	// Roles Роли пользователя — employee, shop-manager, hr-admin или auditor.
+	Roles []string `json:"roles"`
+
+	// Username Имя пользователя.
+	Username string `json:"username"`
+}
+
+// WaitlistEntry defines model for WaitlistEntry.
+type WaitlistEntry struct {
+	// CreatedAt Время подписки.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Position Место в очереди (начиная с 1).
+	Position int `json:"position"`
+
+	// Product Товар, которого ждет пользователь.
+	Product string `json:"product"`
+}
+
+// Wishlist defines model for Wishlist.
+type Wishlist struct {
+	Items []WishlistItem `json:"items"`
+
+	// Public Виден ли список коллегам.
+	Public bool `json:"public"`
+}
+
+// WishlistAddRequest defines model for WishlistAddRequest.
+type WishlistAddRequest struct {
+	// Item Название товара.
+	Item string `json:"item"`
+
+	// Note Заметка, например размер или цвет.
+	Note *string `json:"note,omitempty"`
+}
+
+// WishlistItem defines model for WishlistItem.
+type WishlistItem struct {
+	// AddedAt Время добавления в список.
+	AddedAt time.Time `json:"addedAt"`
+
+	// Available Можно ли купить товар прямо сейчас.
+	Available bool `json:"available"`
+
+	// Item Название товара.
+	Item string `json:"item"`
+
+	// Note Заметка, например размер или цвет.
+	Note *string `json:"note,omitempty"`
+
+	// Price Текущая цена в монетах.
+	Price int `json:"price"`
+}
+
+// WishlistItemUpdateRequest defines model for WishlistItemUpdateRequest.
+type WishlistItemUpdateRequest struct {
+	// Note Новая заметка. Если не указана — заметка удаляется.
+	Note *string `json:"note,omitempty"`
+}
+
+// WishlistSettingsRequest defines model for WishlistSettingsRequest.
+type WishlistSettingsRequest struct {
+	// Public Открыть список желаний коллегам.
+	Public bool `json:"public"`
+}
+
+// GetApiAuthSsoCallbackParams defines parameters for GetApiAuthSsoCallback.
+type GetApiAuthSsoCallbackParams struct {
+	// Code Код авторизации от поставщика удостоверений.
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+
+	// State Значение state из запроса авторизации.
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+
+	// Error Код ошибки, если поставщик удостоверений отказал во входе.
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+
+	// ErrorDescription Описание ошибки от поставщика удостоверений.
+	ErrorDescription *string `form:"error_description,omitempty" json:"error_description,omitempty"`
+}
+
+// GetApiMarketListingsParams defines parameters for GetApiMarketListings.
+type GetApiMarketListingsParams struct {
+	// Item Показать объявления только для этого предмета.
+	Item *string `form:"item,omitempty" json:"item,omitempty"`
+}
+
+// GetApiStaffOrdersParams defines parameters for GetApiStaffOrders.
+type GetApiStaffOrdersParams struct {
+	// Status Фильтр по статусу заказа.
+	Status *OrderStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// PostApiAdminCoinsGrantsJSONRequestBody defines body for PostApiAdminCoinsGrants for application/json ContentType.
+type PostApiAdminCoinsGrantsJSONRequestBody = CoinGrantRequest
+
+// PostApiAdminServiceAccountsJSONRequestBody defines body for PostApiAdminServiceAccounts for application/json ContentType.
+type PostApiAdminServiceAccountsJSONRequestBody = ServiceAccountRequest
+
+// PostApiAdminServiceAccountsNameKeysJSONRequestBody defines body for PostApiAdminServiceAccountsNameKeys for application/json ContentType.
+type PostApiAdminServiceAccountsNameKeysJSONRequestBody = APIKeyRequest
+
+// PostApiAuctionsAuctionIdBidsJSONRequestBody defines body for PostApiAuctionsAuctionIdBids for application/json ContentType.
+type PostApiAuctionsAuctionIdBidsJSONRequestBody = BidRequest
+
 // PostApiAuthJSONRequestBody defines body for PostApiAuth for application/json ContentType.
 type PostApiAuthJSONRequestBody = AuthRequest
 
+// PostApiAuthLoginJSONRequestBody defines body for PostApiAuthLogin for application/json ContentType.
+type PostApiAuthLoginJSONRequestBody = AuthRequest
+
+// PostApiAuthPasswordJSONRequestBody defines body for PostApiAuthPassword for application/json ContentType.
+type PostApiAuthPasswordJSONRequestBody = PasswordChangeRequest
+
+// PostApiAuthPasswordResetJSONRequestBody defines body for PostApiAuthPasswordReset for application/json ContentType.
+type PostApiAuthPasswordResetJSONRequestBody = PasswordResetRequest
+
+// PostApiAuthRefreshJSONRequestBody defines body for PostApiAuthRefresh for application/json ContentType.
+type PostApiAuthRefreshJSONRequestBody = RefreshRequest
+
+// PostApiAuthRegisterJSONRequestBody defines body for PostApiAuthRegister for application/json ContentType.
+type PostApiAuthRegisterJSONRequestBody = RegisterRequest
+
+// PostApiAuthTwoFactorConfirmJSONRequestBody defines body for PostApiAuthTwoFactorConfirm for application/json ContentType.
+type PostApiAuthTwoFactorConfirmJSONRequestBody = TwoFactorCodeRequest
+
+// PostApiAuthTwoFactorVerifyJSONRequestBody defines body for PostApiAuthTwoFactorVerify for application/json ContentType.
+type PostApiAuthTwoFactorVerifyJSONRequestBody = TwoFactorVerifyRequest
+
+// PostApiBundlesBundleBuyJSONRequestBody defines body for PostApiBundlesBundleBuy for application/json ContentType.
+type PostApiBundlesBundleBuyJSONRequestBody = BundleBuyRequest
+
+// PostApiBuyJSONRequestBody defines body for PostApiBuy for application/json ContentType.
+type PostApiBuyJSONRequestBody = BuyRequest
+
+// PostApiGiftsJSONRequestBody defines body for PostApiGifts for application/json ContentType.
+type PostApiGiftsJSONRequestBody = GiftRequest
+
+// PostApiMarketListingsJSONRequestBody defines body for PostApiMarketListings for application/json ContentType.
+type PostApiMarketListingsJSONRequestBody = ListingRequest
+
+// PostApiMarketListingsListingIdBuyJSONRequestBody defines body for PostApiMarketListingsListingIdBuy for application/json ContentType.
+type PostApiMarketListingsListingIdBuyJSONRequestBody = MarketBuyRequest
+
+// PostApiRafflesRaffleIdTicketsJSONRequestBody defines body for PostApiRafflesRaffleIdTickets for application/json ContentType.
+type PostApiRafflesRaffleIdTicketsJSONRequestBody = RaffleTicketsRequest
+
 // PostApiSendCoinJSONRequestBody defines body for PostApiSendCoin for application/json ContentType.
 type PostApiSendCoinJSONRequestBody = SendCoinRequest
+
+// PostApiStaffAuctionsJSONRequestBody defines body for PostApiStaffAuctions for application/json ContentType.
+type PostApiStaffAuctionsJSONRequestBody = AuctionRequest
+
+// PostApiStaffBundlesJSONRequestBody defines body for PostApiStaffBundles for application/json ContentType.
+type PostApiStaffBundlesJSONRequestBody = BundleRequest
+
+// PutApiStaffBundlesBundleJSONRequestBody defines body for PutApiStaffBundlesBundle for application/json ContentType.
+type PutApiStaffBundlesBundleJSONRequestBody = BundleUpdateRequest
+
+// PostApiStaffDropsJSONRequestBody defines body for PostApiStaffDrops for application/json ContentType.
+type PostApiStaffDropsJSONRequestBody = DropRequest
+
+// PostApiStaffInvitesJSONRequestBody defines body for PostApiStaffInvites for application/json ContentType.
+type PostApiStaffInvitesJSONRequestBody = InviteRequest
+
+// PostApiStaffOrdersOrderIdStatusJSONRequestBody defines body for PostApiStaffOrdersOrderIdStatus for application/json ContentType.
+type PostApiStaffOrdersOrderIdStatusJSONRequestBody = OrderStatusUpdateRequest
+
+// PostApiStaffProductsItemPricesJSONRequestBody defines body for PostApiStaffProductsItemPrices for application/json ContentType.
+type PostApiStaffProductsItemPricesJSONRequestBody = PriceScheduleRequest
+
+// PostApiStaffProductsItemStockJSONRequestBody defines body for PostApiStaffProductsItemStock for application/json ContentType.
+type PostApiStaffProductsItemStockJSONRequestBody = RestockRequest
+
+// PostApiStaffPromoCodesJSONRequestBody defines body for PostApiStaffPromoCodes for application/json ContentType.
+type PostApiStaffPromoCodesJSONRequestBody = PromoCodeRequest
+
+// PostApiStaffRafflesJSONRequestBody defines body for PostApiStaffRaffles for application/json ContentType.
+type PostApiStaffRafflesJSONRequestBody = RaffleRequest
+
+// PostApiTransferItemJSONRequestBody defines body for PostApiTransferItem for application/json ContentType.
+type PostApiTransferItemJSONRequestBody = TransferItemRequest
+
+// PatchApiWishlistJSONRequestBody defines body for PatchApiWishlist for application/json ContentType.
+type PatchApiWishlistJSONRequestBody = WishlistSettingsRequest
+
+// PostApiWishlistJSONRequestBody defines body for PostApiWishlist for application/json ContentType.
+type PostApiWishlistJSONRequestBody = WishlistAddRequest
+
+// PutApiWishlistItemJSONRequestBody defines body for PutApiWishlistItem for application/json ContentType.
+type PutApiWishlistItemJSONRequestBody = WishlistItemUpdateRequest
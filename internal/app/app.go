@@ -6,12 +6,27 @@ import (
 	"fmt"
 	"log"
 	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/access"
+	auctionServices "merch-store-service/internal/domain/auctions/service"
+	catalogServices "merch-store-service/internal/domain/catalog/service"
 	coinServices "merch-store-service/internal/domain/coins/service"
+	dropServices "merch-store-service/internal/domain/drops/service"
+	marketplaceServices "merch-store-service/internal/domain/marketplace/service"
+	"merch-store-service/internal/domain/models"
+	orderServices "merch-store-service/internal/domain/orders/service"
+	promoServices "merch-store-service/internal/domain/promos/service"
+	raffleServices "merch-store-service/internal/domain/raffles/service"
 	"merch-store-service/internal/domain/repository"
+	serviceAccountServices "merch-store-service/internal/domain/serviceaccounts/service"
 	userServices "merch-store-service/internal/domain/users/service"
+	waitlistServices "merch-store-service/internal/domain/waitlist/service"
+	wishlistServices "merch-store-service/internal/domain/wishlists/service"
 	"merch-store-service/internal/infra/config"
 	middleware "merch-store-service/internal/infra/http/middlewares"
 	"merch-store-service/internal/infra/jwtutils"
+	"merch-store-service/internal/infra/ldapauth"
+	"merch-store-service/internal/infra/notifier"
+	"merch-store-service/internal/infra/oidc"
 	"net/http"
 	"time"
 
@@ -21,6 +36,10 @@ import (
 type App struct {
 	Server *http.Server
 	port   int
+
+	jobs     []job
+	jobsCtx  context.Context
+	stopJobs context.CancelFunc
 }
 
 func New(cfg *config.Config) *App {
@@ -29,30 +48,205 @@ func New(cfg *config.Config) *App {
 		log.Fatalf("failed to init storage %v", err)
 	}
 
-	jwtManager := jwtutils.NewJWTManager(cfg)
+	if cfg.JWTKeyGracePeriod < cfg.AccessTokenTTL {
+		log.Fatalf("jwt_key_grace_period %s must not be shorter than access_token_ttl %s", cfg.JWTKeyGracePeriod, cfg.AccessTokenTTL)
+	}
+
+	jwtManager := jwtutils.NewJWTManager(cfg, storage)
+	if err := jwtManager.Rotate(context.Background()); err != nil {
+		log.Fatalf("failed to load signing keys %v", err)
+	}
+
+	notify, err := notifier.New(cfg)
+	if err != nil {
+		log.Fatalf("failed to init notifier %v", err)
+	}
+
+	registrationPolicy := models.RegistrationPolicy{
+		Mode:              models.RegistrationMode(cfg.RegistrationMode),
+		Allowlist:         cfg.RegistrationAllowlist,
+		PasswordMinLength: cfg.PasswordMinLength,
+	}
+	if err := registrationPolicy.Validate(); err != nil {
+		log.Fatalf("invalid registration policy %v", err)
+	}
+
+	ssoPolicy := models.SSOPolicy{
+		UsernameClaim: cfg.OIDCUsernameClaim,
+		EmailDomain:   cfg.OIDCEmailDomain,
+		LinkExisting:  cfg.OIDCLinkExisting,
+		Provision:     cfg.OIDCProvision,
+	}
+	var sso *oidc.Provider
+	if cfg.OIDCIssuer != "" {
+		if err := ssoPolicy.Validate(); err != nil {
+			log.Fatalf("invalid single sign-on policy %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		sso, err = oidc.Discover(ctx, oidc.Config{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       cfg.OIDCScopes,
+		}, &http.Client{Timeout: 10 * time.Second})
+		cancel()
+		if err != nil {
+			log.Fatalf("failed to discover identity provider %v", err)
+		}
+	}
+	if !cfg.PasswordLogin && sso == nil {
+		log.Fatalf("password_login is disabled and oidc_issuer is not set, nobody could sign in")
+	}
+
+	authenticatorNames, err := models.ParseAuthenticators(cfg.Authenticators)
+	if err != nil {
+		log.Fatalf("invalid authenticators %v", err)
+	}
+	authenticators := make([]userServices.Authenticator, 0, len(authenticatorNames))
+	for _, name := range authenticatorNames {
+		switch name {
+		case models.AuthenticatorLocal:
+			authenticators = append(authenticators, userServices.NewLocalAuthenticator(storage))
+		case models.AuthenticatorLDAP:
+			directory, err := ldapauth.New(ldapauth.Config{
+				URL:                  cfg.LDAPURL,
+				StartTLS:             cfg.LDAPStartTLS,
+				CAFile:               cfg.LDAPCAFile,
+				BindDN:               cfg.LDAPBindDN,
+				BindPassword:         cfg.LDAPBindPassword,
+				UserDNs:              cfg.LDAPUserDNs,
+				BaseDNs:              cfg.LDAPBaseDNs,
+				UserFilter:           cfg.LDAPUserFilter,
+				Timeout:              cfg.LDAPTimeout,
+				UsernameAttribute:    cfg.LDAPUsernameAttribute,
+				DisplayNameAttribute: cfg.LDAPDisplayNameAttribute,
+				EmailAttribute:       cfg.LDAPEmailAttribute,
+				DepartmentAttribute:  cfg.LDAPDepartmentAttribute,
+			})
+			if err != nil {
+				log.Fatalf("failed to init ldap %v", err)
+			}
+			authenticators = append(authenticators, userServices.NewDirectoryAuthenticator(directory, storage, cfg.LDAPLinkExisting, cfg.LDAPProvision))
+		}
+	}
+
+	denylist := access.NewDenylist(storage)
+	if err := denylist.Sync(context.Background()); err != nil {
+		log.Fatalf("failed to load token denylist %v", err)
+	}
+
+	seeds := map[models.Role][]string{
+		models.RoleShopManager: cfg.ShopStaff,
+		models.RoleHRAdmin:     cfg.HRAdmins,
+	}
+	for role, usernames := range seeds {
+		if err := storage.SeedRoles(context.Background(), usernames, role); err != nil {
+			log.Fatalf("failed to seed %s role %v", role, err)
+		}
+	}
+
+	userService := userServices.NewUserService(storage, userServices.UserServiceConfig{
+		JWTManager:   jwtManager,
+		Denylist:     denylist,
+		Registration: registrationPolicy,
+		Sessions: models.SessionPolicy{
+			AccessTTL:  cfg.AccessTokenTTL,
+			RefreshTTL: cfg.RefreshTokenTTL,
+		},
+		AutoProvision: cfg.AuthAutoProvision,
+		Sender:        notify,
+		ResetTTL:      cfg.PasswordResetTTL,
+		Throttle: models.LoginThrottlePolicy{
+			FreeAttempts:     cfg.LoginFreeAttempts,
+			BackoffBase:      cfg.LoginBackoffBase,
+			BackoffMax:       cfg.LoginBackoffMax,
+			MaxFailures:      cfg.LoginMaxFailures,
+			MaxFailuresPerIP: cfg.LoginMaxFailuresPerIP,
+			Lockout:          cfg.LoginLockout,
+		},
+		SSO:            sso,
+		SSOPolicy:      ssoPolicy,
+		PasswordLogin:  cfg.PasswordLogin,
+		Authenticators: authenticators,
+		TwoFactor: models.TwoFactorPolicy{
+			Issuer:       cfg.TwoFactorIssuer,
+			ChallengeTTL: cfg.TwoFactorChallengeTTL,
+			MaxAttempts:  cfg.TwoFactorMaxAttempts,
+		},
+	})
+	coinService := coinServices.NewCoinService(storage, cfg.MaxOrderQuantity)
+	orderService := orderServices.NewOrderService(storage, cfg.ReturnWindow)
+	promoService := promoServices.NewPromoService(storage)
+	catalogService := catalogServices.NewCatalogService(storage, notify, models.ReservePolicy{
+		Count: cfg.WaitlistReserveCount,
+		TTL:   cfg.WaitlistReserveTTL,
+	})
+	dropService := dropServices.NewDropService(storage)
+	waitlistService := waitlistServices.NewWaitlistService(storage)
+	wishlistService := wishlistServices.NewWishlistService(storage)
+	marketplaceService := marketplaceServices.NewMarketplaceService(storage, cfg.MarketplaceFeePercent)
+	auctionService := auctionServices.NewAuctionService(storage)
+	raffleService := raffleServices.NewRaffleService(storage)
+	serviceAccountService := serviceAccountServices.NewServiceAccountService(storage)
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("invalid trusted_proxies %v", err)
+	}
 
-	userService := userServices.NewUserService(storage, jwtManager)
-	coinService := coinServices.NewCoinService(storage)
 	router := chi.NewRouter()
 
-	authMiddleware := middleware.NewAuthMiddleware(jwtManager)
+	authMiddleware := middleware.NewAuthMiddleware(jwtManager, denylist, serviceAccountService)
 	router.Use(authMiddleware.Middleware())
 
 	server := &Server{
-		UserService: userService,
-		CoinService: coinService,
+		JWTManager:         jwtManager,
+		UserService:        userService,
+		CoinService:        coinService,
+		OrderService:       orderService,
+		PromoService:       promoService,
+		CatalogService:     catalogService,
+		DropService:        dropService,
+		WaitlistService:    waitlistService,
+		WishlistService:    wishlistService,
+		MarketplaceService: marketplaceService,
+		AuctionService:     auctionService,
+		RaffleService:      raffleService,
+
+		ServiceAccountService: serviceAccountService,
+		TrustForwardedFor:     cfg.TrustForwardedFor,
+		TrustedProxies:        trustedProxies,
 	}
 
-	apiHandler := api.HandlerFromMux(server, router)
+	apiHandler := api.HandlerWithOptions(server, api.ChiServerOptions{
+		BaseRouter:  router,
+		Middlewares: []api.MiddlewareFunc{middleware.Authorize(permissions)},
+	})
+	if err := permissions.Check(router); err != nil {
+		log.Fatalf("invalid access rules %v", err)
+	}
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Port),
 		Handler: apiHandler,
 	}
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+
 	return &App{
 		Server: srv,
 		port:   cfg.Port,
+		jobs: []job{
+			{name: "close auctions", interval: cfg.AuctionCloseInterval, run: auctionService.CloseDueAuctions},
+			{name: "draw raffles", interval: cfg.RaffleDrawInterval, run: raffleService.DrawDueRaffles},
+			{name: "rotate signing keys", interval: cfg.JWTKeyCheckInterval, run: jwtManager.Rotate},
+			{name: "sync token denylist", interval: cfg.TokenDenylistSyncInterval, run: denylist.Sync},
+			{name: "purge expired sessions", interval: cfg.SessionPurgeInterval, run: userService.PurgeExpiredSessions},
+			{name: "purge login failures", interval: cfg.SessionPurgeInterval, run: userService.PurgeLoginFailures},
+		},
+		jobsCtx:  jobsCtx,
+		stopJobs: stopJobs,
 	}
 
 }
@@ -66,6 +260,8 @@ func (a *App) MustRun() {
 func (a *App) Run() error {
 	const op = "merch-store.Run"
 
+	startJobs(a.jobsCtx, a.jobs)
+
 	if err := a.Server.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) {
 
 		return fmt.Errorf("%s: %w", op, err)
@@ -77,6 +273,8 @@ func (a *App) Run() error {
 func (a *App) Stop() {
 	const op = "merch-store.Stop"
 
+	a.stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
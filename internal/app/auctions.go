@@ -0,0 +1,157 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"merch-store-service/internal/api"
+	auctionService "merch-store-service/internal/domain/auctions/service"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+	"time"
+)
+
+// defaultAuctionExtension is the anti-sniping window used when the request does not set one.
+const defaultAuctionExtension = 120
+
+// GetApiAuctions Получить текущие и завершенные аукционы.
+// (GET /api/auctions)
+func (s *Server) GetApiAuctions(w http.ResponseWriter, r *http.Request) {
+	auctions, err := s.AuctionService.ListAuctions(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now().UTC()
+	resp := make([]api.Auction, 0, len(auctions))
+	for i := range auctions {
+		resp = append(resp, toAPIAuction(&auctions[i], now))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetApiAuctionsAuctionId Получить аукцион с историей ставок.
+// (GET /api/auctions/{auctionId})
+func (s *Server) GetApiAuctionsAuctionId(w http.ResponseWriter, r *http.Request, auctionId int) {
+	auction, bids, err := s.AuctionService.GetAuction(r.Context(), auctionId)
+	if err != nil {
+		http.Error(w, err.Error(), auctionErrorStatus(err))
+		return
+	}
+
+	resp := api.AuctionDetails{
+		Auction: toAPIAuction(auction, time.Now().UTC()),
+		Bids:    make([]api.AuctionBid, 0, len(bids)),
+	}
+	for _, bid := range bids {
+		resp.Bids = append(resp.Bids, api.AuctionBid{
+			Bidder:    bid.Bidder,
+			Amount:    bid.Amount,
+			CreatedAt: bid.CreatedAt,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PostApiAuctionsAuctionIdBids Сделать ставку. Сумма ставки блокируется до тех пор, пока ставку не перебьют.
+// (POST /api/auctions/{auctionId}/bids)
+func (s *Server) PostApiAuctionsAuctionIdBids(w http.ResponseWriter, r *http.Request, auctionId int) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.BidRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	auction, err := s.AuctionService.PlaceBid(r.Context(), userID, auctionId, req.Amount)
+	if err != nil {
+		http.Error(w, err.Error(), auctionErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIAuction(auction, time.Now().UTC()))
+}
+
+// PostApiStaffAuctions Создать аукцион (для сотрудников магазина).
+// (POST /api/staff/auctions)
+func (s *Server) PostApiStaffAuctions(w http.ResponseWriter, r *http.Request) {
+	var req api.AuctionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	auction := models.Auction{
+		Product:      req.Product,
+		Quantity:     1,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+		MinIncrement: 1,
+		Extension:    defaultAuctionExtension * time.Second,
+	}
+	if req.Quantity != nil {
+		auction.Quantity = *req.Quantity
+	}
+	if req.ReservePrice != nil {
+		auction.ReservePrice = *req.ReservePrice
+	}
+	if req.MinIncrement != nil {
+		auction.MinIncrement = *req.MinIncrement
+	}
+	if req.ExtensionSeconds != nil {
+		auction.Extension = time.Duration(*req.ExtensionSeconds) * time.Second
+	}
+
+	created, err := s.AuctionService.CreateAuction(r.Context(), auction)
+	if err != nil {
+		http.Error(w, err.Error(), auctionErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIAuction(created, time.Now().UTC()))
+}
+
+func auctionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrAuctionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrAuctionNotStarted),
+		errors.Is(err, models.ErrAuctionEnded),
+		errors.Is(err, repository.ErrOutOfStock):
+		return http.StatusConflict
+	case errors.Is(err, auctionService.ErrInvalidAuction),
+		errors.Is(err, repository.ErrItemNotFound),
+		errors.Is(err, models.ErrBidTooLow),
+		errors.Is(err, repository.ErrInsufficientFunds):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIAuction(auction *models.Auction, now time.Time) api.Auction {
+	return api.Auction{
+		Id:               auction.ID,
+		Product:          auction.Product,
+		Quantity:         auction.Quantity,
+		StartsAt:         auction.StartsAt,
+		EndsAt:           auction.EndsAt,
+		MinIncrement:     auction.MinIncrement,
+		MinimumBid:       auction.MinimumBid(),
+		ExtensionSeconds: int(auction.Extension / time.Second),
+		CurrentBid:       auction.CurrentBid,
+		Leader:           auction.Leader,
+		ReserveMet:       auction.ReserveMet(),
+		Status:           api.AuctionStatus(auction.Status(now)),
+		ClosedAt:         auction.ClosedAt,
+	}
+}
@@ -0,0 +1,327 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	userService "merch-store-service/internal/domain/users/service"
+	"merch-store-service/internal/infra/oidc"
+	"merch-store-service/pkg/ctxkeys"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PostApiAuthLogin Вход по имени пользователя и паролю. Новые пользователи не создаются.
+// (POST /api/auth/login)
+func (s *Server) PostApiAuthLogin(w http.ResponseWriter, r *http.Request) {
+	var req api.AuthRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Username == "" || req.Password == "" {
+		http.Error(w, "Username and password are required", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := s.UserService.Login(r.Context(), req.Username, req.Password, s.clientIP(r))
+	if err != nil {
+		loginError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIAuthResponse(tokens))
+}
+
+// PostApiAuthLogout Выйти — отозвать текущую сессию, ее refresh-токены и уже выданные access-токены.
+// (POST /api/auth/logout)
+func (s *Server) PostApiAuthLogout(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.Context().Value(ctxkeys.UserIDKey).(int); !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, _ := r.Context().Value(ctxkeys.SessionIDKey).(string)
+	if err := s.UserService.Logout(r.Context(), sessionID); err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PostApiAuthRefresh Обменять refresh-токен на новую пару токенов. Каждый refresh-токен действует один раз; повторное использование отзывает всю сессию.
+// (POST /api/auth/refresh)
+func (s *Server) PostApiAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	var req api.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.RefreshToken == "" {
+		http.Error(w, "Refresh token is required", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := s.UserService.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIAuthResponse(tokens))
+}
+
+// PostApiAuthRegister Регистрация нового пользователя с проверкой имени, пароля и, в зависимости от режима регистрации, кода приглашения или списка разрешенных имен.
+// (POST /api/auth/register)
+func (s *Server) PostApiAuthRegister(w http.ResponseWriter, r *http.Request) {
+	var req api.RegisterRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	var inviteCode string
+	if req.InviteCode != nil {
+		inviteCode = *req.InviteCode
+	}
+
+	tokens, err := s.UserService.Register(r.Context(), req.Username, req.Password, inviteCode)
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIAuthResponse(tokens))
+}
+
+// GetApiStaffInvites Список кодов приглашений (для сотрудников магазина).
+// (GET /api/staff/invites)
+func (s *Server) GetApiStaffInvites(w http.ResponseWriter, r *http.Request) {
+	invites, err := s.UserService.ListInvites(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	resp := make([]api.Invite, 0, len(invites))
+	for i := range invites {
+		resp = append(resp, toAPIInvite(&invites[i]))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PostApiStaffInvites Выпустить одноразовый код приглашения для регистрации (для сотрудников магазина).
+// (POST /api/staff/invites)
+func (s *Server) PostApiStaffInvites(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.InviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	invite, err := s.UserService.CreateInvite(r.Context(), userID, req.ExpiresAt)
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIInvite(invite))
+}
+
+// GetWellKnownJwksJson Открытые ключи для проверки JWT-токенов (JWKS). Содержит текущий ключ подписи и предыдущие ключи на время льготного периода.
+// (GET /.well-known/jwks.json)
+func (s *Server) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+	keys := s.JWTManager.JWKS()
+
+	resp := api.JSONWebKeySet{Keys: make([]api.JSONWebKey, 0, len(keys))}
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, api.JSONWebKey{
+			Kty: key.KeyType,
+			Kid: key.KeyID,
+			Use: key.Use,
+			Alg: key.Algorithm,
+			N:   optionalString(key.N),
+			E:   optionalString(key.E),
+			Crv: optionalString(key.Curve),
+			X:   optionalString(key.X),
+		})
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func authErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrUnauthorized),
+		errors.Is(err, models.ErrRefreshTokenReused),
+		errors.Is(err, repository.ErrSessionNotFound),
+		errors.Is(err, repository.ErrResetTokenInvalid),
+		errors.Is(err, repository.ErrSSOStateInvalid),
+		errors.Is(err, repository.ErrTwoFactorChallengeInvalid),
+		errors.Is(err, oidc.ErrTokenExchange),
+		errors.Is(err, oidc.ErrInvalidIDToken):
+		return http.StatusUnauthorized
+	case errors.Is(err, userService.ErrWrongPassword),
+		errors.Is(err, models.ErrNotAllowlisted),
+		errors.Is(err, userService.ErrInviteRequired),
+		errors.Is(err, repository.ErrInviteInvalid),
+		errors.Is(err, models.ErrPasswordLoginDisabled),
+		errors.Is(err, models.ErrSSOIdentityRejected),
+		errors.Is(err, repository.ErrIdentityNotLinked),
+		errors.Is(err, models.ErrInvalidTwoFactorCode):
+		return http.StatusForbidden
+	case errors.Is(err, models.ErrTooManyLoginAttempts):
+		return http.StatusTooManyRequests
+	case errors.Is(err, repository.ErrUserNotFound),
+		errors.Is(err, repository.ErrLoginFailuresNotFound),
+		errors.Is(err, models.ErrSSODisabled),
+		errors.Is(err, repository.ErrTwoFactorNotEnrolled):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrUserExists),
+		errors.Is(err, repository.ErrTwoFactorEnabled),
+		errors.Is(err, repository.ErrPasswordManagedExternally):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrInvalidUsername),
+		errors.Is(err, models.ErrWeakPassword),
+		errors.Is(err, models.ErrUnknownThrottleKind),
+		errors.Is(err, userService.ErrNoSession),
+		errors.Is(err, userService.ErrInvalidInvite):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// loginError writes a failed login. A throttled login tells the client when
+// to try again; a login waiting for a second factor gets its challenge.
+func loginError(w http.ResponseWriter, err error) {
+	if twoFactorChallenge(w, err) {
+		return
+	}
+
+	var throttled *models.LoginThrottledError
+	if errors.As(err, &throttled) {
+		seconds := int(math.Ceil(time.Until(throttled.RetryAt).Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+	}
+
+	http.Error(w, err.Error(), authErrorStatus(err))
+}
+
+// clientIP returns the address of the client, used to throttle failed logins.
+// Behind a reverse proxy it is the rightmost X-Forwarded-For entry that is
+// not one of the trusted proxies: entries to the left of it were sent by the
+// client and can be anything.
+func (s *Server) clientIP(r *http.Request) string {
+	if s.TrustForwardedFor {
+		entries := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+		client := ""
+		for i := len(entries) - 1; i >= 0; i-- {
+			entry := strings.TrimSpace(entries[i])
+			if entry == "" {
+				continue
+			}
+			client = entry
+			if !s.trustedProxy(entry) {
+				break
+			}
+		}
+		if client != "" {
+			return client
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (s *Server) trustedProxy(address string) bool {
+	ip, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+
+	for _, proxy := range s.TrustedProxies {
+		if proxy.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses addresses and networks in CIDR notation.
+func parseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, err
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+
+	return proxies, nil
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func toAPIAuthResponse(tokens *models.AuthTokens) api.AuthResponse {
+	return api.AuthResponse{
+		Token:        &tokens.AccessToken,
+		RefreshToken: &tokens.RefreshToken,
+		ExpiresAt:    &tokens.ExpiresAt,
+	}
+}
+
+func toAPIInvite(invite *models.Invite) api.Invite {
+	return api.Invite{
+		Code:      invite.Code,
+		CreatedBy: invite.CreatedBy,
+		CreatedAt: invite.CreatedAt,
+		ExpiresAt: invite.ExpiresAt,
+		UsedBy:    invite.UsedBy,
+		UsedAt:    invite.UsedAt,
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"encoding/json"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+	req.RemoteAddr = "198.51.100.7:54321"
+	req.Header.Set("X-Forwarded-For", "192.0.2.1, 10.0.0.1")
+
+	assert.Equal(t, "198.51.100.7", (&Server{}).clientIP(req), "X-Forwarded-For should be ignored by default")
+	assert.Equal(t, "10.0.0.1", (&Server{TrustForwardedFor: true}).clientIP(req), "The entry added by the proxy should be used")
+
+	proxies, err := parseTrustedProxies([]string{"10.0.0.0/8", "203.0.113.5"})
+	assert.NoError(t, err)
+	server := &Server{TrustForwardedFor: true, TrustedProxies: proxies}
+	assert.Equal(t, "192.0.2.1", server.clientIP(req), "Trusted proxies should be skipped")
+
+	req.Header.Set("X-Forwarded-For", "1.2.3.4, 192.0.2.1")
+	req.Header.Add("X-Forwarded-For", "203.0.113.5")
+	assert.Equal(t, "192.0.2.1", server.clientIP(req), "Entries sent by the client should be ignored")
+
+	req.Header.Set("X-Forwarded-For", "10.0.0.2, 10.0.0.1")
+	assert.Equal(t, "10.0.0.2", server.clientIP(req), "If every entry is a trusted proxy, the leftmost should be used")
+
+	_, err = parseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestLoginErrorRetryAfter(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	loginError(recorder, &models.LoginThrottledError{RetryAt: time.Now().Add(90 * time.Second)})
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Contains(t, []string{"89", "90"}, recorder.Header().Get("Retry-After"))
+}
+
+func TestLoginErrorTwoFactorChallenge(t *testing.T) {
+	expiresAt := time.Date(2025, 3, 1, 18, 15, 0, 0, time.UTC)
+	recorder := httptest.NewRecorder()
+	loginError(recorder, &models.TwoFactorRequiredError{ChallengeToken: "challenge", ExpiresAt: expiresAt})
+
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	assert.Equal(t, "no-store", recorder.Header().Get("Cache-Control"))
+
+	var challenge api.TwoFactorChallenge
+	assert.NoError(t, json.NewDecoder(recorder.Body).Decode(&challenge))
+	assert.Equal(t, api.TwoFactorChallenge{ChallengeToken: "challenge", ExpiresAt: expiresAt}, challenge)
+}
@@ -0,0 +1,161 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"merch-store-service/internal/api"
+	catalogService "merch-store-service/internal/domain/catalog/service"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// GetApiBundles Получить наборы товаров с составом и доступностью.
+// (GET /api/bundles)
+func (s *Server) GetApiBundles(w http.ResponseWriter, r *http.Request) {
+	bundles, err := s.CatalogService.ListBundles(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]api.Bundle, 0, len(bundles))
+	for i := range bundles {
+		resp = append(resp, toAPIBundle(&bundles[i]))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetApiBundlesBundle Получить набор товаров.
+// (GET /api/bundles/{bundle})
+func (s *Server) GetApiBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string) {
+	result, err := s.CatalogService.GetBundle(r.Context(), bundle)
+	if err != nil {
+		http.Error(w, err.Error(), bundleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIBundle(result))
+}
+
+// PostApiBundlesBundleBuy Купить набор. Цена набора списывается один раз, каждый товар из набора добавляется в инвентарь.
+// (POST /api/bundles/{bundle}/buy)
+func (s *Server) PostApiBundlesBundleBuy(w http.ResponseWriter, r *http.Request, bundle string) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.BundleBuyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	quantity := 1
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+
+	order, err := s.CoinService.BuyBundle(r.Context(), userID, bundle, quantity)
+	if err != nil {
+		http.Error(w, err.Error(), bundleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIOrder(order))
+}
+
+// PostApiStaffBundles Создать набор товаров (для сотрудников магазина).
+// (POST /api/staff/bundles)
+func (s *Server) PostApiStaffBundles(w http.ResponseWriter, r *http.Request) {
+	var req api.BundleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	created, err := s.CatalogService.CreateBundle(r.Context(), models.Bundle{
+		Name:  req.Name,
+		Price: req.Price,
+		Items: toBundleItems(req.Items),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), bundleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIBundle(created))
+}
+
+// DeleteApiStaffBundlesBundle Удалить набор товаров (для сотрудников магазина).
+// (DELETE /api/staff/bundles/{bundle})
+func (s *Server) DeleteApiStaffBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string) {
+	if err := s.CatalogService.DeleteBundle(r.Context(), bundle); err != nil {
+		http.Error(w, err.Error(), bundleErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PutApiStaffBundlesBundle Изменить цену и состав набора (для сотрудников магазина).
+// (PUT /api/staff/bundles/{bundle})
+func (s *Server) PutApiStaffBundlesBundle(w http.ResponseWriter, r *http.Request, bundle string) {
+	var req api.BundleUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	updated, err := s.CatalogService.UpdateBundle(r.Context(), models.Bundle{
+		Name:  bundle,
+		Price: req.Price,
+		Items: toBundleItems(req.Items),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), bundleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIBundle(updated))
+}
+
+func bundleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrBundleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrBundleExists):
+		return http.StatusConflict
+	case errors.Is(err, catalogService.ErrInvalidBundle):
+		return http.StatusBadRequest
+	default:
+		return purchaseErrorStatus(err)
+	}
+}
+
+func toBundleItems(items []api.BundleItem) []models.BundleItem {
+	result := make([]models.BundleItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, models.BundleItem{Product: item.Product, Quantity: item.Quantity})
+	}
+	return result
+}
+
+func toAPIBundle(bundle *models.Bundle) api.Bundle {
+	items := make([]api.BundleItem, 0, len(bundle.Items))
+	for _, item := range bundle.Items {
+		items = append(items, api.BundleItem{Product: item.Product, Quantity: item.Quantity})
+	}
+
+	return api.Bundle{
+		Name:      bundle.Name,
+		Price:     bundle.Price,
+		Items:     items,
+		Available: bundle.Available,
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"merch-store-service/internal/api"
+	catalogService "merch-store-service/internal/domain/catalog/service"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"net/http"
+	"time"
+)
+
+// GetApiStaffProductsItemPrices Получить историю цен товара (для сотрудников магазина).
+// (GET /api/staff/products/{item}/prices)
+func (s *Server) GetApiStaffProductsItemPrices(w http.ResponseWriter, r *http.Request, item string) {
+	timeline, err := s.CatalogService.PriceTimeline(r.Context(), item)
+	if err != nil {
+		http.Error(w, err.Error(), catalogErrorStatus(err))
+		return
+	}
+
+	current := models.CurrentPrice(timeline, time.Now().UTC())
+	resp := make([]api.PricePoint, 0, len(timeline))
+	for i, point := range timeline {
+		resp = append(resp, api.PricePoint{
+			Price:         point.Price,
+			EffectiveFrom: point.EffectiveFrom,
+			CreatedAt:     point.CreatedAt,
+			Current:       i == current,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PostApiStaffProductsItemPrices Изменить цену товара сейчас или запланировать изменение на будущее (для сотрудников магазина).
+// (POST /api/staff/products/{item}/prices)
+func (s *Server) PostApiStaffProductsItemPrices(w http.ResponseWriter, r *http.Request, item string) {
+	var req api.PriceScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	point, err := s.CatalogService.SchedulePrice(r.Context(), item, req.Price, req.EffectiveFrom)
+	if err != nil {
+		http.Error(w, err.Error(), catalogErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, api.PricePoint{
+		Price:         point.Price,
+		EffectiveFrom: point.EffectiveFrom,
+		CreatedAt:     point.CreatedAt,
+		Current:       !point.EffectiveFrom.After(time.Now().UTC()),
+	})
+}
+
+// PostApiStaffProductsItemStock Пополнить остаток товара и уведомить лист ожидания (для сотрудников магазина).
+// (POST /api/staff/products/{item}/stock)
+func (s *Server) PostApiStaffProductsItemStock(w http.ResponseWriter, r *http.Request, item string) {
+	var req api.RestockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	notices, err := s.CatalogService.Restock(r.Context(), item, req.Quantity)
+	if err != nil {
+		http.Error(w, err.Error(), catalogErrorStatus(err))
+		return
+	}
+
+	resp := api.RestockResponse{Notified: len(notices)}
+	for _, notice := range notices {
+		if notice.ReservedUntil != nil {
+			resp.Reserved++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func catalogErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrPriceExists),
+		errors.Is(err, repository.ErrStockNotTracked):
+		return http.StatusConflict
+	case errors.Is(err, catalogService.ErrInvalidPrice),
+		errors.Is(err, repository.ErrInvalidRestock):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"merch-store-service/internal/api"
+	dropService "merch-store-service/internal/domain/drops/service"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"net/http"
+	"time"
+)
+
+// GetApiDrops Получить предстоящие и текущие дропы лимитированных товаров.
+// (GET /api/drops)
+func (s *Server) GetApiDrops(w http.ResponseWriter, r *http.Request) {
+	drops, err := s.DropService.ListDrops(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now().UTC()
+	resp := make([]api.Drop, 0, len(drops))
+	for i := range drops {
+		resp = append(resp, toAPIDrop(&drops[i], now))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PostApiStaffDrops Запланировать дроп лимитированного товара (для сотрудников магазина).
+// (POST /api/staff/drops)
+func (s *Server) PostApiStaffDrops(w http.ResponseWriter, r *http.Request) {
+	var req api.DropRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	drop := models.Drop{
+		Product:      req.Product,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+		Stock:        req.Stock,
+		PerUserLimit: 1,
+	}
+	if req.PerUserLimit != nil {
+		drop.PerUserLimit = *req.PerUserLimit
+	}
+
+	created, err := s.DropService.CreateDrop(r.Context(), drop)
+	if err != nil {
+		http.Error(w, err.Error(), dropErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIDrop(created, time.Now().UTC()))
+}
+
+func dropErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, dropService.ErrInvalidDrop),
+		errors.Is(err, repository.ErrItemNotFound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIDrop(drop *models.Drop, now time.Time) api.Drop {
+	return api.Drop{
+		Id:           drop.ID,
+		Product:      drop.Product,
+		StartsAt:     drop.StartsAt,
+		EndsAt:       drop.EndsAt,
+		Stock:        drop.Stock,
+		Remaining:    drop.Remaining,
+		PerUserLimit: drop.PerUserLimit,
+		Status:       api.DropStatus(drop.Status(now)),
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// PostApiGifts Купить товар в подарок коллеге.
+// (POST /api/gifts)
+func (s *Server) PostApiGifts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.GiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Item == "" || req.ToUser == "" {
+		http.Error(w, "Item and recipient are required", http.StatusBadRequest)
+		return
+	}
+
+	purchase := models.Purchase{
+		UserID:   userID,
+		Item:     req.Item,
+		Quantity: 1,
+		Gift: &models.Gift{
+			Recipient: req.ToUser,
+			Message:   req.Message,
+		},
+	}
+	if req.Quantity != nil {
+		purchase.Quantity = *req.Quantity
+	}
+	if req.PromoCode != nil {
+		purchase.PromoCode = *req.PromoCode
+	}
+	if req.Anonymous != nil {
+		purchase.Gift.Anonymous = *req.Anonymous
+	}
+
+	order, err := s.CoinService.BuyGift(r.Context(), purchase)
+	if err != nil {
+		http.Error(w, err.Error(), giftErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIOrder(order))
+}
+
+func giftErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound),
+		errors.Is(err, models.ErrGiftToSelf),
+		errors.Is(err, models.ErrGiftMessageTooLong):
+		return http.StatusBadRequest
+	default:
+		return purchaseErrorStatus(err)
+	}
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// job is a task the application runs periodically in the background.
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// startJobs runs every job on its interval until ctx is cancelled.
+func startJobs(ctx context.Context, jobs []job) {
+	for _, j := range jobs {
+		if j.interval <= 0 {
+			log.Printf("job %s is disabled", j.name)
+			continue
+		}
+
+		go func(j job) {
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := j.run(ctx); err != nil {
+						log.Printf("job %s failed: %v", j.name, err)
+					}
+				}
+			}
+		}(j)
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"merch-store-service/internal/api"
+	"net/http"
+)
+
+// GetApiAdminLoginFailures Имена пользователей и адреса с недавними неудачными попытками входа и время, до которого вход для них заблокирован (для ролей hr-admin и auditor).
+// (GET /api/admin/login-failures)
+func (s *Server) GetApiAdminLoginFailures(w http.ResponseWriter, r *http.Request) {
+	failures, err := s.UserService.ListLoginFailures(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	resp := make([]api.LoginFailures, 0, len(failures))
+	for _, f := range failures {
+		resp = append(resp, api.LoginFailures{
+			Kind:          string(f.Kind),
+			Key:           f.Key,
+			Failures:      f.Failures,
+			LastFailureAt: f.LastFailureAt,
+			LockedUntil:   f.LockedUntil,
+			BlockedUntil:  f.BlockedUntil,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// DeleteApiAdminLoginFailuresKindKey Сбросить неудачные попытки входа и снять блокировку для имени пользователя или адреса (для роли hr-admin).
+// (DELETE /api/admin/login-failures/{kind}/{key})
+func (s *Server) DeleteApiAdminLoginFailuresKindKey(w http.ResponseWriter, r *http.Request, kind string, key string) {
+	if err := s.UserService.ClearLoginFailures(r.Context(), kind, key); err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
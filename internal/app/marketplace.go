@@ -0,0 +1,145 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// GetApiMarketListings Получить активные объявления внутреннего маркетплейса.
+// (GET /api/market/listings)
+func (s *Server) GetApiMarketListings(w http.ResponseWriter, r *http.Request, params api.GetApiMarketListingsParams) {
+	listings, err := s.MarketplaceService.ListListings(r.Context(), params.Item)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]api.Listing, 0, len(listings))
+	for i := range listings {
+		resp = append(resp, toAPIListing(&listings[i]))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PostApiMarketListings Выставить предметы из своего инвентаря на продажу.
+// (POST /api/market/listings)
+func (s *Server) PostApiMarketListings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.ListingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Item == "" {
+		http.Error(w, "Item is required", http.StatusBadRequest)
+		return
+	}
+
+	listing, err := s.MarketplaceService.CreateListing(r.Context(), userID, req.Item, req.Quantity, req.Price)
+	if err != nil {
+		http.Error(w, err.Error(), marketErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIListing(listing))
+}
+
+// PostApiMarketListingsListingIdBuy Купить предметы по объявлению.
+// (POST /api/market/listings/{listingId}/buy)
+func (s *Server) PostApiMarketListingsListingIdBuy(w http.ResponseWriter, r *http.Request, listingId int) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.MarketBuyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	quantity := 1
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+
+	trade, err := s.MarketplaceService.Buy(r.Context(), userID, listingId, quantity)
+	if err != nil {
+		http.Error(w, err.Error(), marketErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.Trade{
+		Id:        trade.ID,
+		ListingId: trade.ListingID,
+		Seller:    trade.Seller,
+		Item:      trade.Item,
+		Quantity:  trade.Quantity,
+		Total:     trade.Total,
+		Fee:       trade.Fee,
+		CreatedAt: trade.CreatedAt,
+	})
+}
+
+// PostApiMarketListingsListingIdCancel Снять свое объявление и вернуть непроданные предметы в инвентарь.
+// (POST /api/market/listings/{listingId}/cancel)
+func (s *Server) PostApiMarketListingsListingIdCancel(w http.ResponseWriter, r *http.Request, listingId int) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	listing, err := s.MarketplaceService.CancelListing(r.Context(), userID, listingId)
+	if err != nil {
+		http.Error(w, err.Error(), marketErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIListing(listing))
+}
+
+func marketErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrListingNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrListingNotActive):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrInvalidListing),
+		errors.Is(err, models.ErrOwnListing),
+		errors.Is(err, repository.ErrInvalidQuantity),
+		errors.Is(err, repository.ErrItemNotOwned),
+		errors.Is(err, repository.ErrOutOfStock),
+		errors.Is(err, repository.ErrInsufficientFunds):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIListing(listing *models.Listing) api.Listing {
+	return api.Listing{
+		Id:        listing.ID,
+		Seller:    listing.Seller,
+		Item:      listing.Item,
+		Quantity:  listing.Quantity,
+		Remaining: listing.Remaining,
+		Price:     listing.Price,
+		Status:    api.ListingStatus(listing.Status),
+		CreatedAt: listing.CreatedAt,
+	}
+}
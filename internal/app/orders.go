@@ -0,0 +1,158 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// GetApiOrders Получить список заказов текущего пользователя.
+// (GET /api/orders)
+func (s *Server) GetApiOrders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	orders, err := s.OrderService.ListUserOrders(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIOrders(orders))
+}
+
+// GetApiStaffOrders Получить список всех заказов (для сотрудников магазина).
+// (GET /api/staff/orders)
+func (s *Server) GetApiStaffOrders(w http.ResponseWriter, r *http.Request, params api.GetApiStaffOrdersParams) {
+	var status *models.OrderStatus
+	if params.Status != nil {
+		st := models.OrderStatus(*params.Status)
+		status = &st
+	}
+
+	orders, err := s.OrderService.ListOrders(r.Context(), status)
+	if err != nil {
+		http.Error(w, err.Error(), orderErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIOrders(orders))
+}
+
+// PostApiStaffOrdersOrderIdStatus Перевести заказ в следующий статус (для сотрудников магазина).
+// (POST /api/staff/orders/{orderId}/status)
+func (s *Server) PostApiStaffOrdersOrderIdStatus(w http.ResponseWriter, r *http.Request, orderId int) {
+	var req api.OrderStatusUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	order, err := s.OrderService.AdvanceStatus(r.Context(), orderId, models.OrderStatus(req.Status))
+	if err != nil {
+		http.Error(w, err.Error(), orderErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIOrder(order))
+}
+
+// PostApiOrdersOrderIdCancel Отменить свой заказ и вернуть потраченные монеты.
+// (POST /api/orders/{orderId}/cancel)
+func (s *Server) PostApiOrdersOrderIdCancel(w http.ResponseWriter, r *http.Request, orderId int) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	order, err := s.OrderService.CancelOrder(r.Context(), userID, orderId)
+	if err != nil {
+		http.Error(w, err.Error(), orderErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIOrder(order))
+}
+
+// PostApiStaffOrdersOrderIdCancel Отменить заказ пользователя с возвратом монет (для сотрудников магазина).
+// (POST /api/staff/orders/{orderId}/cancel)
+func (s *Server) PostApiStaffOrdersOrderIdCancel(w http.ResponseWriter, r *http.Request, orderId int) {
+	order, err := s.OrderService.StaffCancelOrder(r.Context(), orderId)
+	if err != nil {
+		http.Error(w, err.Error(), orderErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIOrder(order))
+}
+
+func orderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrInvalidStatusTransition),
+		errors.Is(err, repository.ErrOrderNotCancellable),
+		errors.Is(err, repository.ErrItemNotOwned):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIOrder(order *models.Order) api.Order {
+	items := make([]api.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, api.OrderItem{
+			Item:      item.ItemName,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Bundle:    item.Bundle,
+		})
+	}
+
+	var gift *api.OrderGift
+	if order.Gift != nil {
+		gift = &api.OrderGift{
+			ToUser:    order.Gift.Recipient,
+			Message:   order.Gift.Message,
+			Anonymous: order.Gift.Anonymous,
+		}
+	}
+
+	return api.Order{
+		Id:          order.ID,
+		Status:      api.OrderStatus(order.Status),
+		Items:       items,
+		Total:       order.Total,
+		Discount:    order.Discount,
+		Gift:        gift,
+		CreatedAt:   order.CreatedAt,
+		UpdatedAt:   order.UpdatedAt,
+		DeliveredAt: order.DeliveredAt,
+	}
+}
+
+func toAPIOrders(orders []models.Order) []api.Order {
+	resp := make([]api.Order, 0, len(orders))
+	for i := range orders {
+		resp = append(resp, toAPIOrder(&orders[i]))
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
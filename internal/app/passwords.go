@@ -0,0 +1,81 @@
+package app
+
+import (
+	"encoding/json"
+	"merch-store-service/internal/api"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// PostApiAuthPassword Сменить пароль. Требует текущий пароль; все сессии пользователя, включая текущую, отзываются, и в ответе возвращается новая пара токенов.
+// (POST /api/auth/password)
+func (s *Server) PostApiAuthPassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.PasswordChangeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := s.UserService.ChangePassword(r.Context(), userID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIAuthResponse(tokens))
+}
+
+// PostApiAuthPasswordReset Задать новый пароль по токену сброса. Токен действует один раз; все сессии пользователя отзываются.
+// (POST /api/auth/password/reset)
+func (s *Server) PostApiAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req api.PasswordResetRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Token == "" {
+		http.Error(w, "Reset token is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.UserService.ResetPassword(r.Context(), req.Token, req.NewPassword); err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PostApiAdminUsersUsernamePasswordReset Сбросить пароль пользователя (для роли hr-admin). Одноразовый токен сброса отправляется пользователю и в ответе не возвращается.
+// (POST /api/admin/users/{username}/password-reset)
+func (s *Server) PostApiAdminUsersUsernamePasswordReset(w http.ResponseWriter, r *http.Request, username string) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	reset, err := s.UserService.CreatePasswordReset(r.Context(), userID, username)
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, api.PasswordReset{
+		Username:  reset.Username,
+		ExpiresAt: reset.ExpiresAt,
+	})
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"merch-store-service/internal/domain/models"
+	middleware "merch-store-service/internal/infra/http/middlewares"
+)
+
+var (
+	public        = middleware.Rule{Public: true}
+	authenticated = middleware.Rule{}
+	employee      = allow(models.RoleEmployee)
+	shopManager   = allow(models.RoleShopManager)
+	shopAuditor   = allow(models.RoleShopManager, models.RoleAuditor)
+	hrAdmin       = allow(models.RoleHRAdmin)
+	hrAuditor     = allow(models.RoleHRAdmin, models.RoleAuditor)
+)
+
+// permissions declares who may call each operation of the API. It is the only
+// place where access is checked: a route without a rule is forbidden, and the
+// application refuses to start if the rules and the routes disagree.
+var permissions = middleware.Permissions{
+	"POST /api/auth":                    public,
+	"POST /api/auth/login":              public,
+	"POST /api/auth/register":           public,
+	"POST /api/auth/refresh":            public,
+	"POST /api/auth/logout":             authenticated,
+	"POST /api/auth/password":           authenticated,
+	"POST /api/auth/password/reset":     public,
+	"GET /api/auth/sso/start":           public,
+	"GET /api/auth/sso/callback":        public,
+	"GET /api/auth/two-factor":          authenticated,
+	"POST /api/auth/two-factor/enroll":  authenticated,
+	"POST /api/auth/two-factor/confirm": authenticated,
+	"POST /api/auth/two-factor/verify":  public,
+	"GET /.well-known/jwks.json":        public,
+
+	"GET /api/info":                                employee,
+	"POST /api/sendCoin":                           scoped(employee, models.ScopeCoinsSend),
+	"POST /api/transferItem":                       employee,
+	"POST /api/buy":                                employee,
+	"GET /api/buy/{item}":                          employee,
+	"GET /api/auctions":                            employee,
+	"GET /api/auctions/{auctionId}":                employee,
+	"POST /api/auctions/{auctionId}/bids":          employee,
+	"GET /api/bundles":                             employee,
+	"GET /api/bundles/{bundle}":                    employee,
+	"POST /api/bundles/{bundle}/buy":               employee,
+	"GET /api/drops":                               employee,
+	"POST /api/gifts":                              employee,
+	"GET /api/market/listings":                     employee,
+	"POST /api/market/listings":                    employee,
+	"POST /api/market/listings/{listingId}/buy":    employee,
+	"POST /api/market/listings/{listingId}/cancel": employee,
+	"GET /api/orders":                              employee,
+	"POST /api/orders/{orderId}/cancel":            employee,
+	"GET /api/raffles":                             employee,
+	"GET /api/raffles/{raffleId}":                  employee,
+	"POST /api/raffles/{raffleId}/tickets":         employee,
+	"GET /api/waitlist":                            employee,
+	"POST /api/waitlist/{item}":                    employee,
+	"DELETE /api/waitlist/{item}":                  employee,
+	"GET /api/wishlist":                            employee,
+	"POST /api/wishlist":                           employee,
+	"PATCH /api/wishlist":                          employee,
+	"PUT /api/wishlist/{item}":                     employee,
+	"DELETE /api/wishlist/{item}":                  employee,
+	"GET /api/users/{username}/wishlist":           employee,
+
+	"POST /api/staff/auctions":                  shopManager,
+	"POST /api/staff/bundles":                   scoped(shopManager, models.ScopeCatalogWrite),
+	"PUT /api/staff/bundles/{bundle}":           scoped(shopManager, models.ScopeCatalogWrite),
+	"DELETE /api/staff/bundles/{bundle}":        scoped(shopManager, models.ScopeCatalogWrite),
+	"POST /api/staff/drops":                     shopManager,
+	"GET /api/staff/orders":                     shopAuditor,
+	"POST /api/staff/orders/{orderId}/cancel":   shopManager,
+	"POST /api/staff/orders/{orderId}/status":   shopManager,
+	"GET /api/staff/products/{item}/prices":     shopAuditor,
+	"POST /api/staff/products/{item}/prices":    scoped(shopManager, models.ScopeCatalogWrite),
+	"POST /api/staff/products/{item}/stock":     scoped(shopManager, models.ScopeCatalogWrite),
+	"GET /api/staff/promo-codes":                shopAuditor,
+	"POST /api/staff/promo-codes":               shopManager,
+	"POST /api/staff/raffles":                   shopManager,
+	"POST /api/staff/raffles/{raffleId}/cancel": shopManager,
+
+	"GET /api/staff/invites":                                 hrAuditor,
+	"POST /api/staff/invites":                                hrAdmin,
+	"GET /api/admin/users/{username}/profile":                hrAuditor,
+	"GET /api/admin/users/{username}/roles":                  hrAuditor,
+	"PUT /api/admin/users/{username}/roles/{role}":           hrAdmin,
+	"DELETE /api/admin/users/{username}/roles/{role}":        hrAdmin,
+	"GET /api/admin/login-failures":                          hrAuditor,
+	"DELETE /api/admin/login-failures/{kind}/{key}":          hrAdmin,
+	"POST /api/admin/users/{username}/password-reset":        hrAdmin,
+	"DELETE /api/admin/users/{username}/two-factor":          hrAdmin,
+	"GET /api/admin/two-factor/roles":                        hrAuditor,
+	"PUT /api/admin/two-factor/roles/{role}":                 hrAdmin,
+	"DELETE /api/admin/two-factor/roles/{role}":              hrAdmin,
+	"POST /api/admin/coins/grants":                           scoped(hrAdmin, models.ScopeCoinsGrant),
+	"GET /api/admin/service-accounts":                        hrAuditor,
+	"POST /api/admin/service-accounts":                       hrAdmin,
+	"POST /api/admin/service-accounts/{name}/keys":           hrAdmin,
+	"DELETE /api/admin/service-accounts/{name}/keys/{keyId}": hrAdmin,
+}
+
+func allow(roles ...models.Role) middleware.Rule {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+
+	return middleware.Rule{Roles: names}
+}
+
+// scoped additionally lets service accounts call the operation with an API
+// key holding one of the scopes.
+func scoped(rule middleware.Rule, scopes ...models.Scope) middleware.Rule {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+
+	rule.Scopes = names
+	return rule
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"merch-store-service/internal/api"
+	middleware "merch-store-service/internal/infra/http/middlewares"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionsCoverAllRoutes(t *testing.T) {
+	router := chi.NewRouter()
+	api.HandlerFromMux(&Server{}, router)
+
+	assert.NoError(t, permissions.Check(router))
+}
+
+func TestPermissionsDenyBeforeHandler(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Test-User") != "" {
+				ctx := context.WithValue(r.Context(), ctxkeys.UserIDKey, 1)
+				ctx = context.WithValue(ctx, ctxkeys.RolesKey, []string{"employee"})
+				r = r.WithContext(ctx)
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	// The server has no services: any request that reaches a handler panics.
+	api.HandlerWithOptions(&Server{}, api.ChiServerOptions{
+		BaseRouter:  router,
+		Middlewares: []api.MiddlewareFunc{middleware.Authorize(permissions)},
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		authenticated  bool
+		expectedStatus int
+	}{
+		{"Employee operation without a token", http.MethodGet, "/api/info", false, http.StatusUnauthorized},
+		{"Staff operation for an employee", http.MethodGet, "/api/staff/orders", true, http.StatusForbidden},
+		{"Role admin operation for an employee", http.MethodPut, "/api/admin/users/alice/roles/hr-admin", true, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authenticated {
+				req.Header.Set("X-Test-User", "1")
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
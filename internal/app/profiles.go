@@ -0,0 +1,42 @@
+package app
+
+import (
+	"errors"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"net/http"
+)
+
+// GetApiAdminUsersUsernameProfile Атрибуты пользователя из каталога LDAP на момент его последнего входа через каталог (для ролей hr-admin и auditor).
+// (GET /api/admin/users/{username}/profile)
+func (s *Server) GetApiAdminUsersUsernameProfile(w http.ResponseWriter, r *http.Request, username string) {
+	profile, err := s.UserService.GetProfile(r.Context(), username)
+	if err != nil {
+		http.Error(w, err.Error(), profileErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIUserProfile(profile))
+}
+
+func profileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound), errors.Is(err, repository.ErrProfileNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIUserProfile(profile *models.UserProfile) api.UserProfile {
+	return api.UserProfile{
+		Username:    profile.Username,
+		Source:      profile.Source,
+		Dn:          profile.DN,
+		DisplayName: profile.DisplayName,
+		Email:       profile.Email,
+		Department:  profile.Department,
+		SyncedAt:    profile.SyncedAt,
+	}
+}
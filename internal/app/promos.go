@@ -0,0 +1,91 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	promoService "merch-store-service/internal/domain/promos/service"
+	"merch-store-service/internal/domain/repository"
+	"net/http"
+)
+
+// GetApiStaffPromoCodes Получить промокоды и статистику их использования (для сотрудников магазина).
+// (GET /api/staff/promo-codes)
+func (s *Server) GetApiStaffPromoCodes(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.PromoService.ListPromoCodes(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), promoErrorStatus(err))
+		return
+	}
+
+	resp := make([]api.PromoCodeStats, 0, len(stats))
+	for i := range stats {
+		resp = append(resp, api.PromoCodeStats{
+			PromoCode:     toAPIPromoCode(&stats[i].PromoCode),
+			Redemptions:   stats[i].Redemptions,
+			UniqueUsers:   stats[i].UniqueUsers,
+			TotalDiscount: stats[i].TotalDiscount,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PostApiStaffPromoCodes Создать промокод (для сотрудников магазина).
+// (POST /api/staff/promo-codes)
+func (s *Server) PostApiStaffPromoCodes(w http.ResponseWriter, r *http.Request) {
+	var req api.PromoCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	promo := models.PromoCode{
+		Code:           req.Code,
+		DiscountType:   models.DiscountType(req.DiscountType),
+		DiscountValue:  req.DiscountValue,
+		Product:        req.Product,
+		ValidUntil:     req.ValidUntil,
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+	}
+	if req.ValidFrom != nil {
+		promo.ValidFrom = *req.ValidFrom
+	}
+
+	created, err := s.PromoService.CreatePromoCode(r.Context(), promo)
+	if err != nil {
+		http.Error(w, err.Error(), promoErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIPromoCode(created))
+}
+
+func promoErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrPromoCodeExists):
+		return http.StatusConflict
+	case errors.Is(err, promoService.ErrInvalidPromoCode),
+		errors.Is(err, repository.ErrItemNotFound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIPromoCode(promo *models.PromoCode) api.PromoCode {
+	return api.PromoCode{
+		Id:             promo.ID,
+		Code:           promo.Code,
+		DiscountType:   api.DiscountType(promo.DiscountType),
+		DiscountValue:  promo.DiscountValue,
+		Product:        promo.Product,
+		ValidFrom:      promo.ValidFrom,
+		ValidUntil:     promo.ValidUntil,
+		MaxUses:        promo.MaxUses,
+		MaxUsesPerUser: promo.MaxUsesPerUser,
+		CreatedAt:      promo.CreatedAt,
+	}
+}
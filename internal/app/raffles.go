@@ -0,0 +1,164 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	raffleService "merch-store-service/internal/domain/raffles/service"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+	"time"
+)
+
+// GetApiRaffles Получить текущие и разыгранные лотереи.
+// (GET /api/raffles)
+func (s *Server) GetApiRaffles(w http.ResponseWriter, r *http.Request) {
+	raffles, err := s.RaffleService.ListRaffles(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now().UTC()
+	resp := make([]api.Raffle, 0, len(raffles))
+	for i := range raffles {
+		resp = append(resp, toAPIRaffle(&raffles[i], now))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetApiRafflesRaffleId Получить лотерею со списком билетов. После розыгрыша содержит раскрытый сид для проверки результата.
+// (GET /api/raffles/{raffleId})
+func (s *Server) GetApiRafflesRaffleId(w http.ResponseWriter, r *http.Request, raffleId int) {
+	raffle, tickets, err := s.RaffleService.GetRaffle(r.Context(), raffleId)
+	if err != nil {
+		http.Error(w, err.Error(), raffleErrorStatus(err))
+		return
+	}
+
+	resp := api.RaffleDetails{
+		Raffle:  toAPIRaffle(raffle, time.Now().UTC()),
+		Tickets: make([]api.RaffleTicket, 0, len(tickets)),
+	}
+	for _, ticket := range tickets {
+		resp.Tickets = append(resp.Tickets, api.RaffleTicket{
+			Number:    ticket.Number,
+			Holder:    ticket.Holder,
+			PrizeRank: ticket.PrizeRank,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PostApiRafflesRaffleIdTickets Купить билеты лотереи. Монеты списываются так же, как при покупке товара.
+// (POST /api/raffles/{raffleId}/tickets)
+func (s *Server) PostApiRafflesRaffleIdTickets(w http.ResponseWriter, r *http.Request, raffleId int) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.RaffleTicketsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	count := 1
+	if req.Count != nil {
+		count = *req.Count
+	}
+
+	numbers, total, err := s.RaffleService.BuyTickets(r.Context(), userID, raffleId, count)
+	if err != nil {
+		http.Error(w, err.Error(), raffleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.RaffleTicketsResponse{
+		Numbers: numbers,
+		Total:   total,
+	})
+}
+
+// PostApiStaffRaffles Создать лотерею (для сотрудников магазина). Хеш сида публикуется сразу, сам сид — после розыгрыша.
+// (POST /api/staff/raffles)
+func (s *Server) PostApiStaffRaffles(w http.ResponseWriter, r *http.Request) {
+	var req api.RaffleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	raffle := models.Raffle{
+		Product:           req.Product,
+		Prizes:            1,
+		TicketPrice:       req.TicketPrice,
+		MaxTicketsPerUser: req.MaxTicketsPerUser,
+		DrawAt:            req.DrawAt,
+	}
+	if req.Prizes != nil {
+		raffle.Prizes = *req.Prizes
+	}
+
+	created, err := s.RaffleService.CreateRaffle(r.Context(), raffle)
+	if err != nil {
+		http.Error(w, err.Error(), raffleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIRaffle(created, time.Now().UTC()))
+}
+
+// PostApiStaffRafflesRaffleIdCancel Отменить лотерею до розыгрыша и вернуть монеты за все билеты (для сотрудников магазина).
+// (POST /api/staff/raffles/{raffleId}/cancel)
+func (s *Server) PostApiStaffRafflesRaffleIdCancel(w http.ResponseWriter, r *http.Request, raffleId int) {
+	raffle, err := s.RaffleService.CancelRaffle(r.Context(), raffleId)
+	if err != nil {
+		http.Error(w, err.Error(), raffleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIRaffle(raffle, time.Now().UTC()))
+}
+
+func raffleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrRaffleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, models.ErrRaffleClosed),
+		errors.Is(err, repository.ErrRaffleNotOpen),
+		errors.Is(err, repository.ErrOutOfStock):
+		return http.StatusConflict
+	case errors.Is(err, raffleService.ErrInvalidRaffle),
+		errors.Is(err, repository.ErrItemNotFound),
+		errors.Is(err, repository.ErrInvalidQuantity),
+		errors.Is(err, models.ErrTicketLimitReached),
+		errors.Is(err, repository.ErrInsufficientFunds):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIRaffle(raffle *models.Raffle, now time.Time) api.Raffle {
+	return api.Raffle{
+		Id:                raffle.ID,
+		Product:           raffle.Product,
+		Prizes:            raffle.Prizes,
+		TicketPrice:       raffle.TicketPrice,
+		MaxTicketsPerUser: raffle.MaxTicketsPerUser,
+		DrawAt:            raffle.DrawAt,
+		TicketsSold:       raffle.TicketsSold,
+		SeedCommitment:    raffle.Commitment,
+		Seed:              raffle.Seed,
+		Status:            api.RaffleStatus(raffle.Status(now)),
+		DrawnAt:           raffle.DrawnAt,
+	}
+}
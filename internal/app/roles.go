@@ -0,0 +1,75 @@
+package app
+
+import (
+	"errors"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// GetApiAdminUsersUsernameRoles Роли пользователя (для ролей hr-admin и auditor).
+// (GET /api/admin/users/{username}/roles)
+func (s *Server) GetApiAdminUsersUsernameRoles(w http.ResponseWriter, r *http.Request, username string) {
+	roles, err := s.UserService.ListRoles(r.Context(), username)
+	if err != nil {
+		http.Error(w, err.Error(), roleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIUserRoles(roles))
+}
+
+// PutApiAdminUsersUsernameRolesRole Выдать пользователю роль (для роли hr-admin).
+// (PUT /api/admin/users/{username}/roles/{role})
+func (s *Server) PutApiAdminUsersUsernameRolesRole(w http.ResponseWriter, r *http.Request, username string, role string) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	roles, err := s.UserService.GrantRole(r.Context(), userID, username, role)
+	if err != nil {
+		http.Error(w, err.Error(), roleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIUserRoles(roles))
+}
+
+// DeleteApiAdminUsersUsernameRolesRole Отозвать у пользователя роль (для роли hr-admin).
+// (DELETE /api/admin/users/{username}/roles/{role})
+func (s *Server) DeleteApiAdminUsersUsernameRolesRole(w http.ResponseWriter, r *http.Request, username string, role string) {
+	roles, err := s.UserService.RevokeRole(r.Context(), username, role)
+	if err != nil {
+		http.Error(w, err.Error(), roleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIUserRoles(roles))
+}
+
+func roleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrUnknownRole):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIUserRoles(roles *models.UserRoles) api.UserRoles {
+	names := make([]string, len(roles.Roles))
+	for i, role := range roles.Roles {
+		names[i] = string(role)
+	}
+
+	return api.UserRoles{
+		Username: roles.Username,
+		Roles:    names,
+	}
+}
@@ -2,21 +2,54 @@ package app
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"merch-store-service/internal/api"
+	auctionService "merch-store-service/internal/domain/auctions/service"
+	catalogService "merch-store-service/internal/domain/catalog/service"
 	coinService "merch-store-service/internal/domain/coins/service"
+	dropService "merch-store-service/internal/domain/drops/service"
+	marketplaceService "merch-store-service/internal/domain/marketplace/service"
+	"merch-store-service/internal/domain/models"
+	orderService "merch-store-service/internal/domain/orders/service"
+	promoService "merch-store-service/internal/domain/promos/service"
+	raffleService "merch-store-service/internal/domain/raffles/service"
+	"merch-store-service/internal/domain/repository"
+	serviceAccountService "merch-store-service/internal/domain/serviceaccounts/service"
 	userService "merch-store-service/internal/domain/users/service"
+	waitlistService "merch-store-service/internal/domain/waitlist/service"
+	wishlistService "merch-store-service/internal/domain/wishlists/service"
+	"merch-store-service/internal/infra/jwtutils"
 	"merch-store-service/pkg/ctxkeys"
 	"net/http"
+	"net/netip"
 )
 
 type Server struct {
-	UserService *userService.UserService
-	CoinService *coinService.CoinService
+	JWTManager         *jwtutils.JWTManager
+	UserService        *userService.UserService
+	CoinService        *coinService.CoinService
+	OrderService       *orderService.OrderService
+	PromoService       *promoService.PromoService
+	CatalogService     *catalogService.CatalogService
+	DropService        *dropService.DropService
+	WaitlistService    *waitlistService.WaitlistService
+	WishlistService    *wishlistService.WishlistService
+	MarketplaceService *marketplaceService.MarketplaceService
+	AuctionService     *auctionService.AuctionService
+	RaffleService      *raffleService.RaffleService
+
+	ServiceAccountService *serviceAccountService.ServiceAccountService
+
+	// TrustForwardedFor takes the client address from X-Forwarded-For, for
+	// when the service runs behind a reverse proxy. Entries added by
+	// TrustedProxies are skipped.
+	TrustForwardedFor bool
+	TrustedProxies    []netip.Prefix
 }
 
-// PostApiAuth Аутентификация и получение JWT-токена. При первой аутентификации пользователь создается автоматически.
+// PostApiAuth Аутентификация и получение JWT-токена. Устаревший эндпоинт, используйте /api/auth/login и /api/auth/register. Неизвестный пользователь создается автоматически, только если это включено в конфигурации.
 // (POST /api/auth)
 func (s *Server) PostApiAuth(w http.ResponseWriter, r *http.Request) {
 	if r.Body == nil {
@@ -38,13 +71,13 @@ func (s *Server) PostApiAuth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := s.UserService.PostApiAuth(r.Context(), req.Username, req.Password)
+	tokens, err := s.UserService.PostApiAuth(r.Context(), req.Username, req.Password, s.clientIP(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		loginError(w, err)
 		return
 	}
 
-	resp := api.AuthResponse{Token: &token}
+	resp := toAPIAuthResponse(tokens)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -71,6 +104,44 @@ func (s *Server) GetApiBuyItem(w http.ResponseWriter, r *http.Request, item stri
 	w.WriteHeader(http.StatusOK)
 }
 
+// PostApiBuy Купить несколько единиц предмета за монеты.
+// (POST /api/buy)
+func (s *Server) PostApiBuy(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.BuyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Item == "" {
+		http.Error(w, "Item is required", http.StatusBadRequest)
+		return
+	}
+
+	purchase := models.Purchase{
+		UserID:   userID,
+		Item:     req.Item,
+		Quantity: req.Quantity,
+	}
+	if req.PromoCode != nil {
+		purchase.PromoCode = *req.PromoCode
+	}
+
+	order, err := s.CoinService.BuyItems(r.Context(), purchase)
+	if err != nil {
+		http.Error(w, err.Error(), purchaseErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIOrder(order))
+}
+
 // GetApiInfo Получить информацию о монетах, инвентаре и истории транзакций.
 // (GET /api/info)
 func (s *Server) GetApiInfo(w http.ResponseWriter, r *http.Request) {
@@ -116,3 +187,63 @@ func (s *Server) PostApiSendCoin(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// PostApiTransferItem Передать предметы из своего инвентаря другому пользователю.
+// (POST /api/transferItem)
+func (s *Server) PostApiTransferItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.TransferItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Item == "" || req.ToUser == "" {
+		http.Error(w, "Item and recipient are required", http.StatusBadRequest)
+		return
+	}
+
+	err := s.CoinService.TransferItem(r.Context(), userID, req.ToUser, req.Item, req.Quantity)
+	if err != nil {
+		http.Error(w, err.Error(), transferErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound),
+		errors.Is(err, repository.ErrInvalidQuantity),
+		errors.Is(err, repository.ErrItemNotOwned),
+		errors.Is(err, models.ErrTransferToSelf):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func purchaseErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrInvalidQuantity),
+		errors.Is(err, repository.ErrItemNotFound),
+		errors.Is(err, repository.ErrInsufficientFunds),
+		errors.Is(err, repository.ErrOutOfStock),
+		errors.Is(err, repository.ErrPromoCodeNotFound),
+		errors.Is(err, models.ErrPromoNotActive),
+		errors.Is(err, models.ErrPromoNotApplicable),
+		errors.Is(err, models.ErrPromoUsageExhausted),
+		errors.Is(err, models.ErrDropNotStarted),
+		errors.Is(err, models.ErrDropEnded),
+		errors.Is(err, models.ErrDropLimitReached):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	serviceAccountService "merch-store-service/internal/domain/serviceaccounts/service"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// PostApiAdminCoinsGrants Начислить монеты пользователю (для роли hr-admin и ключей со scope coins:grant).
+// (POST /api/admin/coins/grants)
+func (s *Server) PostApiAdminCoinsGrants(w http.ResponseWriter, r *http.Request) {
+	var req api.CoinGrantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.CoinService.GrantCoins(r.Context(), req.ToUser, req.Amount); err != nil {
+		http.Error(w, err.Error(), grantErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetApiAdminServiceAccounts Сервисные аккаунты и их API-ключи (для ролей hr-admin и auditor).
+// (GET /api/admin/service-accounts)
+func (s *Server) GetApiAdminServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := s.ServiceAccountService.ListServiceAccounts(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), serviceAccountErrorStatus(err))
+		return
+	}
+
+	resp := make([]api.ServiceAccount, 0, len(accounts))
+	for i := range accounts {
+		resp = append(resp, toAPIServiceAccount(&accounts[i]))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PostApiAdminServiceAccounts Создать сервисный аккаунт (для роли hr-admin).
+// (POST /api/admin/service-accounts)
+func (s *Server) PostApiAdminServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.ServiceAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	description := ""
+	if req.Description != nil {
+		description = *req.Description
+	}
+
+	account, err := s.ServiceAccountService.CreateServiceAccount(r.Context(), userID, req.Name, description)
+	if err != nil {
+		http.Error(w, err.Error(), serviceAccountErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIServiceAccount(account))
+}
+
+// PostApiAdminServiceAccountsNameKeys Выпустить API-ключ сервисного аккаунта (для роли hr-admin). Ключ целиком возвращается только в этом ответе.
+// (POST /api/admin/service-accounts/{name}/keys)
+func (s *Server) PostApiAdminServiceAccountsNameKeys(w http.ResponseWriter, r *http.Request, name string) {
+	var req api.APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	key, plain, err := s.ServiceAccountService.CreateAPIKey(r.Context(), name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		http.Error(w, err.Error(), serviceAccountErrorStatus(err))
+		return
+	}
+
+	resp := toAPIKey(key)
+	resp.Key = &plain
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// DeleteApiAdminServiceAccountsNameKeysKeyId Отозвать API-ключ сервисного аккаунта (для роли hr-admin).
+// (DELETE /api/admin/service-accounts/{name}/keys/{keyId})
+func (s *Server) DeleteApiAdminServiceAccountsNameKeysKeyId(w http.ResponseWriter, r *http.Request, name string, keyId string) {
+	if err := s.ServiceAccountService.RevokeAPIKey(r.Context(), name, keyId); err != nil {
+		http.Error(w, err.Error(), serviceAccountErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func grantErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrInvalidQuantity):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func serviceAccountErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrInvalidServiceAccountName),
+		errors.Is(err, models.ErrUnknownScope),
+		errors.Is(err, serviceAccountService.ErrInvalidAPIKeyRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrServiceAccountNotFound),
+		errors.Is(err, repository.ErrAPIKeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrServiceAccountExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIServiceAccount(account *models.ServiceAccount) api.ServiceAccount {
+	keys := make([]api.APIKey, 0, len(account.Keys))
+	for i := range account.Keys {
+		keys = append(keys, toAPIKey(&account.Keys[i]))
+	}
+
+	return api.ServiceAccount{
+		Name:        account.Name,
+		Description: account.Description,
+		CreatedBy:   account.CreatedBy,
+		CreatedAt:   account.CreatedAt,
+		Keys:        keys,
+	}
+}
+
+func toAPIKey(key *models.APIKey) api.APIKey {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return api.APIKey{
+		Id:         key.ID,
+		Scopes:     scopes,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
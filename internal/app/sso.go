@@ -0,0 +1,47 @@
+package app
+
+import (
+	"fmt"
+	"merch-store-service/internal/api"
+	"net/http"
+)
+
+// GetApiAuthSsoStart Начать единый вход (SSO) через поставщика удостоверений OpenID Connect. Перенаправляет браузер на страницу входа поставщика (authorization code с PKCE).
+// (GET /api/auth/sso/start)
+func (s *Server) GetApiAuthSsoStart(w http.ResponseWriter, r *http.Request) {
+	authURL, err := s.UserService.StartSSO(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// GetApiAuthSsoCallback Завершение единого входа (SSO). Поставщик удостоверений перенаправляет сюда браузер с кодом авторизации; сервис обменивает код с проверкой PKCE, проверяет ID-токен и выдает собственные токены.
+// (GET /api/auth/sso/callback)
+func (s *Server) GetApiAuthSsoCallback(w http.ResponseWriter, r *http.Request, params api.GetApiAuthSsoCallbackParams) {
+	if params.Error != nil {
+		msg := "identity provider refused the sign-in: " + *params.Error
+		if params.ErrorDescription != nil {
+			msg = fmt.Sprintf("%s (%s)", msg, *params.ErrorDescription)
+		}
+		http.Error(w, msg, http.StatusUnauthorized)
+		return
+	}
+
+	if params.Code == nil || params.State == nil {
+		http.Error(w, "Code and state are required", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := s.UserService.FinishSSO(r.Context(), *params.State, *params.Code)
+	if err != nil {
+		loginError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, toAPIAuthResponse(tokens))
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// GetApiAuthTwoFactor Состояние двухфакторной аутентификации текущего пользователя.
+// (GET /api/auth/two-factor)
+func (s *Server) GetApiAuthTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	status, err := s.UserService.GetTwoFactorStatus(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.TwoFactorStatus{
+		Enabled:           status.Enabled,
+		ConfirmedAt:       status.ConfirmedAt,
+		RecoveryCodesLeft: status.RecoveryCodesLeft,
+		Required:          status.Required,
+	})
+}
+
+// PostApiAuthTwoFactorEnroll Начать подключение двухфакторной аутентификации (TOTP). Возвращает секрет и URI otpauth для QR-кода.
+// (POST /api/auth/two-factor/enroll)
+func (s *Server) PostApiAuthTwoFactorEnroll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	enrollment, err := s.UserService.EnrollTwoFactor(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, api.TwoFactorEnrollment{
+		Secret:          enrollment.Secret,
+		ProvisioningUri: enrollment.ProvisioningURI,
+	})
+}
+
+// PostApiAuthTwoFactorConfirm Подтвердить подключение двухфакторной аутентификации кодом из приложения. В ответе — одноразовые коды восстановления.
+// (POST /api/auth/two-factor/confirm)
+func (s *Server) PostApiAuthTwoFactorConfirm(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.TwoFactorCodeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.Code == "" {
+		http.Error(w, "Code is required", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := s.UserService.ConfirmTwoFactor(r.Context(), userID, req.Code)
+	if err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, api.TwoFactorRecoveryCodes{RecoveryCodes: codes})
+}
+
+// PostApiAuthTwoFactorVerify Второй шаг входа. Обменивает токен проверки и код из приложения или код восстановления на пару токенов.
+// (POST /api/auth/two-factor/verify)
+func (s *Server) PostApiAuthTwoFactorVerify(w http.ResponseWriter, r *http.Request) {
+	var req api.TwoFactorVerifyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	if req.ChallengeToken == "" || req.Code == "" {
+		http.Error(w, "Challenge token and code are required", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := s.UserService.VerifyTwoFactor(r.Context(), req.ChallengeToken, req.Code, s.clientIP(r))
+	if err != nil {
+		loginError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIAuthResponse(tokens))
+}
+
+// DeleteApiAdminUsersUsernameTwoFactor Сбросить двухфакторную аутентификацию пользователя (для роли hr-admin).
+// (DELETE /api/admin/users/{username}/two-factor)
+func (s *Server) DeleteApiAdminUsersUsernameTwoFactor(w http.ResponseWriter, r *http.Request, username string) {
+	if err := s.UserService.ResetTwoFactor(r.Context(), username); err != nil {
+		http.Error(w, err.Error(), authErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetApiAdminTwoFactorRoles Роли, доступные только в сессиях, начатых с вторым фактором (для ролей hr-admin и auditor).
+// (GET /api/admin/two-factor/roles)
+func (s *Server) GetApiAdminTwoFactorRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := s.UserService.ListTwoFactorRoles(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), roleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPITwoFactorRoles(roles))
+}
+
+// PutApiAdminTwoFactorRolesRole Требовать двухфакторную аутентификацию для роли (для роли hr-admin).
+// (PUT /api/admin/two-factor/roles/{role})
+func (s *Server) PutApiAdminTwoFactorRolesRole(w http.ResponseWriter, r *http.Request, role string) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	roles, err := s.UserService.RequireTwoFactor(r.Context(), userID, role)
+	if err != nil {
+		http.Error(w, err.Error(), roleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPITwoFactorRoles(roles))
+}
+
+// DeleteApiAdminTwoFactorRolesRole Перестать требовать двухфакторную аутентификацию для роли (для роли hr-admin).
+// (DELETE /api/admin/two-factor/roles/{role})
+func (s *Server) DeleteApiAdminTwoFactorRolesRole(w http.ResponseWriter, r *http.Request, role string) {
+	roles, err := s.UserService.UnrequireTwoFactor(r.Context(), role)
+	if err != nil {
+		http.Error(w, err.Error(), roleErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPITwoFactorRoles(roles))
+}
+
+// twoFactorChallenge writes the second login step if the login was stopped
+// for one, and reports whether it did.
+func twoFactorChallenge(w http.ResponseWriter, err error) bool {
+	var required *models.TwoFactorRequiredError
+	if !errors.As(err, &required) {
+		return false
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusAccepted, api.TwoFactorChallenge{
+		ChallengeToken: required.ChallengeToken,
+		ExpiresAt:      required.ExpiresAt,
+	})
+	return true
+}
+
+func toAPITwoFactorRoles(roles []models.Role) api.TwoFactorRoles {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+
+	return api.TwoFactorRoles{Roles: names}
+}
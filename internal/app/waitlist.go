@@ -0,0 +1,88 @@
+package app
+
+import (
+	"errors"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// GetApiWaitlist Получить листы ожидания, на которые подписан пользователь.
+// (GET /api/waitlist)
+func (s *Server) GetApiWaitlist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	entries, err := s.WaitlistService.List(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]api.WaitlistEntry, 0, len(entries))
+	for i := range entries {
+		resp = append(resp, toAPIWaitlistEntry(&entries[i]))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// DeleteApiWaitlistItem Отписаться от листа ожидания товара.
+// (DELETE /api/waitlist/{item})
+func (s *Server) DeleteApiWaitlistItem(w http.ResponseWriter, r *http.Request, item string) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.WaitlistService.Leave(r.Context(), userID, item); err != nil {
+		http.Error(w, err.Error(), waitlistErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PostApiWaitlistItem Подписаться на уведомление о поступлении распроданного товара.
+// (POST /api/waitlist/{item})
+func (s *Server) PostApiWaitlistItem(w http.ResponseWriter, r *http.Request, item string) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	entry, err := s.WaitlistService.Join(r.Context(), userID, item)
+	if err != nil {
+		http.Error(w, err.Error(), waitlistErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIWaitlistEntry(entry))
+}
+
+func waitlistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrItemNotFound),
+		errors.Is(err, repository.ErrNotOnWaitlist):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrItemInStock):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIWaitlistEntry(entry *models.WaitlistEntry) api.WaitlistEntry {
+	return api.WaitlistEntry{
+		Product:   entry.Product,
+		Position:  entry.Position,
+		CreatedAt: entry.CreatedAt,
+	}
+}
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+)
+
+// GetApiWishlist Получить свой список желаний.
+// (GET /api/wishlist)
+func (s *Server) GetApiWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	wishlist, err := s.WishlistService.GetWishlist(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), wishlistErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIWishlist(wishlist))
+}
+
+// PatchApiWishlist Открыть или закрыть список желаний для коллег.
+// (PATCH /api/wishlist)
+func (s *Server) PatchApiWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.WishlistSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	wishlist, err := s.WishlistService.SetPublic(r.Context(), userID, req.Public)
+	if err != nil {
+		http.Error(w, err.Error(), wishlistErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIWishlist(wishlist))
+}
+
+// PostApiWishlist Добавить товар в список желаний.
+// (POST /api/wishlist)
+func (s *Server) PostApiWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.WishlistAddRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	wishlist, err := s.WishlistService.AddItem(r.Context(), userID, req.Item, req.Note)
+	if err != nil {
+		http.Error(w, err.Error(), wishlistErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAPIWishlist(wishlist))
+}
+
+// DeleteApiWishlistItem Удалить товар из списка желаний.
+// (DELETE /api/wishlist/{item})
+func (s *Server) DeleteApiWishlistItem(w http.ResponseWriter, r *http.Request, item string) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	if err := s.WishlistService.RemoveItem(r.Context(), userID, item); err != nil {
+		http.Error(w, err.Error(), wishlistErrorStatus(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PutApiWishlistItem Изменить заметку к товару в списке желаний.
+// (PUT /api/wishlist/{item})
+func (s *Server) PutApiWishlistItem(w http.ResponseWriter, r *http.Request, item string) {
+	userID, ok := r.Context().Value(ctxkeys.UserIDKey).(int)
+	if !ok {
+		http.Error(w, "invalid user ID", http.StatusUnauthorized)
+		return
+	}
+
+	var req api.WishlistItemUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+
+	wishlist, err := s.WishlistService.UpdateItem(r.Context(), userID, item, req.Note)
+	if err != nil {
+		http.Error(w, err.Error(), wishlistErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIWishlist(wishlist))
+}
+
+// GetApiUsersUsernameWishlist Получить открытый список желаний коллеги.
+// (GET /api/users/{username}/wishlist)
+func (s *Server) GetApiUsersUsernameWishlist(w http.ResponseWriter, r *http.Request, username string) {
+	wishlist, err := s.WishlistService.GetSharedWishlist(r.Context(), username)
+	if err != nil {
+		// A private wishlist is reported like a missing one so that it does not leak.
+		if errors.Is(err, repository.ErrWishlistPrivate) {
+			http.Error(w, repository.ErrUserNotFound.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), wishlistErrorStatus(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAPIWishlist(wishlist))
+}
+
+func wishlistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, models.ErrWishlistNoteTooLong):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrItemNotFound),
+		errors.Is(err, repository.ErrUserNotFound),
+		errors.Is(err, repository.ErrWishlistItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrWishlistItemExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toAPIWishlist(wishlist *models.Wishlist) api.Wishlist {
+	items := make([]api.WishlistItem, 0, len(wishlist.Items))
+	for _, item := range wishlist.Items {
+		items = append(items, api.WishlistItem{
+			Item:      item.Product,
+			Note:      item.Note,
+			Price:     item.Price,
+			Available: item.Available,
+			AddedAt:   item.AddedAt,
+		})
+	}
+
+	return api.Wishlist{
+		Public: wishlist.Public,
+		Items:  items,
+	}
+}
@@ -0,0 +1,54 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"merch-store-service/internal/domain/repository"
+	"sync"
+)
+
+// Denylist is an in-memory cache of revoked access token ids, so that
+// checking a token on every request does not hit the database. The revoked
+// tokens are stored in the database; Sync reloads them, which also picks up
+// revocations made by other instances.
+type Denylist struct {
+	storage *repository.Storage
+
+	mu      sync.RWMutex
+	revoked map[string]struct{}
+}
+
+func NewDenylist(storage *repository.Storage) *Denylist {
+	return &Denylist{
+		storage: storage,
+		revoked: make(map[string]struct{}),
+	}
+}
+
+// IsRevoked reports whether the access token with the given id is revoked.
+func (d *Denylist) IsRevoked(tokenID string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	_, ok := d.revoked[tokenID]
+	return ok
+}
+
+// Sync replaces the cache with the revoked tokens that have not expired yet.
+func (d *Denylist) Sync(ctx context.Context) error {
+	tokens, err := d.storage.ListRevokedTokens(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load revoked tokens: %w", err)
+	}
+
+	revoked := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		revoked[token.ID] = struct{}{}
+	}
+
+	d.mu.Lock()
+	d.revoked = revoked
+	d.mu.Unlock()
+
+	return nil
+}
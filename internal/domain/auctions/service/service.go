@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/models"
+)
+
+var ErrInvalidAuction = errors.New("invalid auction")
+
+type AuctionServiceInterface interface {
+	CreateAuction(ctx context.Context, auction models.Auction) (*models.Auction, error)
+	ListAuctions(ctx context.Context) ([]models.Auction, error)
+	GetAuction(ctx context.Context, auctionID int) (*models.Auction, []models.Bid, error)
+	PlaceBid(ctx context.Context, userID, auctionID, amount int) (*models.Auction, error)
+	CloseDueAuctions(ctx context.Context) error
+}
+
+// AuctionStorage is the part of the repository the auction service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=AuctionStorage
+type AuctionStorage interface {
+	CreateAuction(ctx context.Context, auction *models.Auction) (*models.Auction, error)
+	ListAuctions(ctx context.Context) ([]models.Auction, error)
+	GetAuction(ctx context.Context, auctionID int) (*models.Auction, []models.Bid, error)
+	PlaceBid(ctx context.Context, auctionID, userID, amount int) (*models.Auction, error)
+	CloseDueAuctions(ctx context.Context) ([]models.Auction, error)
+}
+
+type AuctionService struct {
+	storage AuctionStorage
+}
+
+func NewAuctionService(storage AuctionStorage) *AuctionService {
+	return &AuctionService{
+		storage: storage,
+	}
+}
+
+func (s *AuctionService) CreateAuction(ctx context.Context, auction models.Auction) (*models.Auction, error) {
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	auction.StartsAt = auction.StartsAt.UTC()
+	auction.EndsAt = auction.EndsAt.UTC()
+
+	if err := auction.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAuction, err)
+	}
+
+	return s.storage.CreateAuction(ctx, &auction)
+}
+
+func (s *AuctionService) ListAuctions(ctx context.Context) ([]models.Auction, error) {
+	return s.storage.ListAuctions(ctx)
+}
+
+func (s *AuctionService) GetAuction(ctx context.Context, auctionID int) (*models.Auction, []models.Bid, error) {
+	return s.storage.GetAuction(ctx, auctionID)
+}
+
+func (s *AuctionService) PlaceBid(ctx context.Context, userID, auctionID, amount int) (*models.Auction, error) {
+	return s.storage.PlaceBid(ctx, auctionID, userID, amount)
+}
+
+// CloseDueAuctions settles the auctions that have ended. It is run
+// periodically by the application.
+func (s *AuctionService) CloseDueAuctions(ctx context.Context) error {
+	closed, err := s.storage.CloseDueAuctions(ctx)
+	for _, auction := range closed {
+		if auction.State == models.AuctionSold {
+			log.Printf("auction %d: %s sold to %s for %d coins", auction.ID, auction.Product, *auction.Leader, *auction.CurrentBid)
+		} else {
+			log.Printf("auction %d: %s closed unsold", auction.ID, auction.Product)
+		}
+	}
+
+	return err
+}
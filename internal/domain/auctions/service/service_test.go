@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/auctions/service/mocks"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+)
+
+func TestCreateAuction(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewAuctionStorage(t)
+	service := NewAuctionService(storage)
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2025, 3, 1, 13, 0, 0, 0, moscow)
+
+	// Timestamps are stored in UTC.
+	storage.On("CreateAuction", ctx, mock.MatchedBy(func(a *models.Auction) bool {
+		return a.StartsAt.Location() == time.UTC && a.EndsAt.Location() == time.UTC && a.StartsAt.Equal(start)
+	})).Return(&models.Auction{ID: 1, Product: "book", State: models.AuctionOpen}, nil).Once()
+
+	auction, err := service.CreateAuction(ctx, models.Auction{
+		Product: "book", Quantity: 1, StartsAt: start, EndsAt: start.Add(24 * time.Hour),
+		ReservePrice: 500, MinIncrement: 10, Extension: 2 * time.Minute,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, auction.ID)
+
+	_, err = service.CreateAuction(ctx, models.Auction{Product: "book", Quantity: 1, StartsAt: start, EndsAt: start, MinIncrement: 10})
+	assert.ErrorIs(t, err, ErrInvalidAuction, "An auction should not end before it starts")
+
+	_, err = service.CreateAuction(ctx, models.Auction{Product: "book", Quantity: 1, StartsAt: start, EndsAt: start.Add(time.Hour)})
+	assert.ErrorIs(t, err, ErrInvalidAuction, "The minimum increment should be positive")
+
+	storage.On("CreateAuction", ctx, mock.Anything).Return(nil, repository.ErrOutOfStock).Once()
+
+	_, err = service.CreateAuction(ctx, models.Auction{Product: "book", Quantity: 5, StartsAt: start, EndsAt: start.Add(time.Hour), MinIncrement: 10})
+	assert.ErrorIs(t, err, repository.ErrOutOfStock)
+}
+
+func TestPlaceBid(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewAuctionStorage(t)
+	service := NewAuctionService(storage)
+
+	leader, bid := "alice", 150
+	storage.On("PlaceBid", ctx, 1, 2, 150).Return(&models.Auction{ID: 1, Leader: &leader, CurrentBid: &bid}, nil).Once()
+
+	auction, err := service.PlaceBid(ctx, 2, 1, 150)
+	assert.NoError(t, err)
+	assert.Equal(t, 150, *auction.CurrentBid)
+
+	storage.On("PlaceBid", ctx, 1, 3, 155).Return(nil, models.ErrBidTooLow).Once()
+
+	_, err = service.PlaceBid(ctx, 3, 1, 155)
+	assert.ErrorIs(t, err, models.ErrBidTooLow)
+
+	storage.On("PlaceBid", ctx, 1, 3, 2000).Return(nil, repository.ErrInsufficientFunds).Once()
+
+	_, err = service.PlaceBid(ctx, 3, 1, 2000)
+	assert.ErrorIs(t, err, repository.ErrInsufficientFunds)
+
+	storage.On("PlaceBid", ctx, 2, 3, 200).Return(nil, models.ErrAuctionEnded).Once()
+
+	_, err = service.PlaceBid(ctx, 3, 2, 200)
+	assert.ErrorIs(t, err, models.ErrAuctionEnded)
+}
+
+func TestCloseDueAuctions(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewAuctionStorage(t)
+	service := NewAuctionService(storage)
+
+	leader, bid := "alice", 250
+	storage.On("CloseDueAuctions", ctx).Return([]models.Auction{
+		{ID: 1, Product: "book", State: models.AuctionSold, Leader: &leader, CurrentBid: &bid},
+		{ID: 2, Product: "hoody", State: models.AuctionUnsold},
+	}, nil).Once()
+
+	assert.NoError(t, service.CloseDueAuctions(ctx))
+
+	failure := errors.New("connection refused")
+	storage.On("CloseDueAuctions", ctx).Return(nil, failure).Once()
+
+	assert.ErrorIs(t, service.CloseDueAuctions(ctx), failure)
+}
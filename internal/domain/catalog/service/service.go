@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/infra/notifier"
+	"time"
+)
+
+var (
+	ErrInvalidPrice  = errors.New("invalid price")
+	ErrInvalidBundle = errors.New("invalid bundle")
+)
+
+type CatalogServiceInterface interface {
+	SchedulePrice(ctx context.Context, item string, price int, effectiveFrom *time.Time) (*models.PricePoint, error)
+	PriceTimeline(ctx context.Context, item string) ([]models.PricePoint, error)
+	Restock(ctx context.Context, item string, quantity int) ([]models.RestockNotice, error)
+	ListBundles(ctx context.Context) ([]models.Bundle, error)
+	GetBundle(ctx context.Context, name string) (*models.Bundle, error)
+	CreateBundle(ctx context.Context, bundle models.Bundle) (*models.Bundle, error)
+	UpdateBundle(ctx context.Context, bundle models.Bundle) (*models.Bundle, error)
+	DeleteBundle(ctx context.Context, name string) error
+}
+
+// CatalogStorage is the part of the repository the catalog service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=CatalogStorage
+type CatalogStorage interface {
+	SchedulePrice(ctx context.Context, item string, price int, effectiveFrom time.Time) (*models.PricePoint, error)
+	PriceTimeline(ctx context.Context, item string) ([]models.PricePoint, error)
+	Restock(ctx context.Context, item string, quantity int, policy models.ReservePolicy) ([]models.RestockNotice, error)
+	ListBundles(ctx context.Context) ([]models.Bundle, error)
+	GetBundle(ctx context.Context, name string) (*models.Bundle, error)
+	CreateBundle(ctx context.Context, bundle *models.Bundle) (*models.Bundle, error)
+	UpdateBundle(ctx context.Context, bundle *models.Bundle) (*models.Bundle, error)
+	DeleteBundle(ctx context.Context, name string) error
+}
+
+type CatalogService struct {
+	storage  CatalogStorage
+	notifier notifier.Notifier
+	reserve  models.ReservePolicy
+}
+
+func NewCatalogService(storage CatalogStorage, notifier notifier.Notifier, reserve models.ReservePolicy) *CatalogService {
+	return &CatalogService{
+		storage:  storage,
+		notifier: notifier,
+		reserve:  reserve,
+	}
+}
+
+// SchedulePrice sets a new price for the product. Without effectiveFrom the
+// price takes effect immediately; prices cannot be backdated.
+func (s *CatalogService) SchedulePrice(ctx context.Context, item string, price int, effectiveFrom *time.Time) (*models.PricePoint, error) {
+	if price < 0 {
+		return nil, fmt.Errorf("%w: price cannot be negative", ErrInvalidPrice)
+	}
+
+	now := time.Now().UTC()
+	from := now
+	if effectiveFrom != nil {
+		from = effectiveFrom.UTC()
+		if from.Before(now.Add(-time.Minute)) {
+			return nil, fmt.Errorf("%w: effective date is in the past", ErrInvalidPrice)
+		}
+	}
+
+	return s.storage.SchedulePrice(ctx, item, price, from)
+}
+
+func (s *CatalogService) PriceTimeline(ctx context.Context, item string) ([]models.PricePoint, error) {
+	return s.storage.PriceTimeline(ctx, item)
+}
+
+// Restock adds units to the product's stock and notifies its waitlist in the
+// order users joined. A failed notification is logged and does not undo the restock.
+func (s *CatalogService) Restock(ctx context.Context, item string, quantity int) ([]models.RestockNotice, error) {
+	notices, err := s.storage.Restock(ctx, item, quantity, s.reserve)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range notices {
+		err := s.notifier.Notify(ctx, notifier.Message{
+			To:      notices[i].Username,
+			Subject: notices[i].Subject(),
+			Body:    notices[i].Body(),
+		})
+		if err != nil {
+			log.Printf("failed to notify %s about %s: %v", notices[i].Username, item, err)
+		}
+	}
+
+	return notices, nil
+}
+
+func (s *CatalogService) ListBundles(ctx context.Context) ([]models.Bundle, error) {
+	return s.storage.ListBundles(ctx)
+}
+
+func (s *CatalogService) GetBundle(ctx context.Context, name string) (*models.Bundle, error) {
+	return s.storage.GetBundle(ctx, name)
+}
+
+func (s *CatalogService) CreateBundle(ctx context.Context, bundle models.Bundle) (*models.Bundle, error) {
+	if err := bundle.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+
+	return s.storage.CreateBundle(ctx, &bundle)
+}
+
+// UpdateBundle replaces the price and components of the bundle named
+// bundle.Name. Orders already placed are not affected.
+func (s *CatalogService) UpdateBundle(ctx context.Context, bundle models.Bundle) (*models.Bundle, error) {
+	if err := bundle.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+
+	return s.storage.UpdateBundle(ctx, &bundle)
+}
+
+func (s *CatalogService) DeleteBundle(ctx context.Context, name string) error {
+	return s.storage.DeleteBundle(ctx, name)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/catalog/service/mocks"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/internal/infra/notifier"
+)
+
+// recordingNotifier keeps the messages it is asked to send and fails for
+// the recipients listed in fail.
+type recordingNotifier struct {
+	sent []notifier.Message
+	fail map[string]bool
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, msg notifier.Message) error {
+	if n.fail[msg.To] {
+		return errors.New("mailbox unavailable")
+	}
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+func TestSchedulePrice(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewCatalogStorage(t)
+	service := NewCatalogService(storage, &recordingNotifier{}, models.ReservePolicy{})
+
+	storage.On("SchedulePrice", ctx, "hoody", 250, mock.AnythingOfType("time.Time")).
+		Return(&models.PricePoint{Price: 250}, nil).Once()
+
+	point, err := service.SchedulePrice(ctx, "hoody", 250, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 250, point.Price)
+
+	tomorrow := time.Now().Add(24 * time.Hour)
+	storage.On("SchedulePrice", ctx, "hoody", 350, tomorrow.UTC()).
+		Return(&models.PricePoint{Price: 350, EffectiveFrom: tomorrow.UTC()}, nil).Once()
+
+	_, err = service.SchedulePrice(ctx, "hoody", 350, &tomorrow)
+	assert.NoError(t, err)
+
+	yesterday := time.Now().Add(-24 * time.Hour)
+	_, err = service.SchedulePrice(ctx, "hoody", 350, &yesterday)
+	assert.ErrorIs(t, err, ErrInvalidPrice, "Prices cannot be backdated")
+
+	_, err = service.SchedulePrice(ctx, "hoody", -1, nil)
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+
+	storage.On("SchedulePrice", ctx, "unknown", 100, mock.Anything).Return(nil, repository.ErrItemNotFound).Once()
+
+	_, err = service.SchedulePrice(ctx, "unknown", 100, nil)
+	assert.ErrorIs(t, err, repository.ErrItemNotFound)
+}
+
+func TestRestock(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewCatalogStorage(t)
+	notify := &recordingNotifier{fail: map[string]bool{"bob": true}}
+	policy := models.ReservePolicy{Count: 1, TTL: 24 * time.Hour}
+	service := NewCatalogService(storage, notify, policy)
+
+	until := time.Now().Add(time.Hour)
+	storage.On("Restock", ctx, "hoody", 5, policy).Return([]models.RestockNotice{
+		{UserID: 2, Username: "alice", Product: "hoody", ReservedUntil: &until},
+		{UserID: 3, Username: "bob", Product: "hoody"},
+		{UserID: 4, Username: "carol", Product: "hoody"},
+	}, nil).Once()
+
+	notices, err := service.Restock(ctx, "hoody", 5)
+	assert.NoError(t, err, "A failed notification should not undo the restock")
+	assert.Len(t, notices, 3)
+	if assert.Len(t, notify.sent, 2) {
+		assert.Equal(t, "alice", notify.sent[0].To, "Subscribers are notified in the order they joined")
+		assert.Equal(t, "carol", notify.sent[1].To)
+	}
+
+	storage.On("Restock", ctx, "sticker", 5, policy).Return(nil, repository.ErrStockNotTracked).Once()
+
+	_, err = service.Restock(ctx, "sticker", 5)
+	assert.ErrorIs(t, err, repository.ErrStockNotTracked)
+	assert.Len(t, notify.sent, 2)
+}
+
+func TestCreateBundle(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewCatalogStorage(t)
+	service := NewCatalogService(storage, &recordingNotifier{}, models.ReservePolicy{})
+
+	storage.On("CreateBundle", ctx, mock.MatchedBy(func(b *models.Bundle) bool {
+		return b.Items[0].Product == "cup" && b.Items[1].Product == "t-shirt"
+	})).Return(&models.Bundle{ID: 1, Name: "welcome-pack"}, nil).Once()
+
+	bundle, err := service.CreateBundle(ctx, models.Bundle{
+		Name: "welcome-pack", Price: 90,
+		Items: []models.BundleItem{{Product: "t-shirt", Quantity: 1}, {Product: "cup", Quantity: 1}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, bundle.ID)
+
+	_, err = service.CreateBundle(ctx, models.Bundle{Name: "empty-pack", Price: 10})
+	assert.ErrorIs(t, err, ErrInvalidBundle, "A bundle needs at least one component")
+
+	_, err = service.CreateBundle(ctx, models.Bundle{
+		Name: "double-pack", Price: 10,
+		Items: []models.BundleItem{{Product: "cup", Quantity: 1}, {Product: "cup", Quantity: 2}},
+	})
+	assert.ErrorIs(t, err, ErrInvalidBundle, "A component should be listed once")
+
+	storage.On("CreateBundle", ctx, mock.Anything).Return(nil, repository.ErrBundleExists).Once()
+
+	_, err = service.CreateBundle(ctx, models.Bundle{Name: "welcome-pack", Price: 80, Items: []models.BundleItem{{Product: "cup", Quantity: 1}}})
+	assert.ErrorIs(t, err, repository.ErrBundleExists)
+}
+
+func TestUpdateBundle(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewCatalogStorage(t)
+	service := NewCatalogService(storage, &recordingNotifier{}, models.ReservePolicy{})
+
+	_, err := service.UpdateBundle(ctx, models.Bundle{Name: "welcome-pack", Price: 0, Items: []models.BundleItem{{Product: "cup", Quantity: 1}}})
+	assert.ErrorIs(t, err, ErrInvalidBundle)
+
+	storage.On("UpdateBundle", ctx, mock.Anything).Return(nil, repository.ErrBundleNotFound).Once()
+
+	_, err = service.UpdateBundle(ctx, models.Bundle{Name: "missing-pack", Price: 50, Items: []models.BundleItem{{Product: "cup", Quantity: 1}}})
+	assert.ErrorIs(t, err, repository.ErrBundleNotFound)
+
+	storage.On("DeleteBundle", ctx, "missing-pack").Return(repository.ErrBundleNotFound).Once()
+
+	assert.ErrorIs(t, service.DeleteBundle(ctx, "missing-pack"), repository.ErrBundleNotFound)
+}
@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	api "merch-store-service/internal/api"
+	models "merch-store-service/internal/domain/models"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,6 +15,66 @@ type CoinServiceInterface struct {
 	mock.Mock
 }
 
+// BuyBundle provides a mock function with given fields: ctx, userID, bundle, quantity
+func (_m *CoinServiceInterface) BuyBundle(ctx context.Context, userID int, bundle string, quantity int) (*models.Order, error) {
+	ret := _m.Called(ctx, userID, bundle, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuyBundle")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) (*models.Order, error)); ok {
+		return rf(ctx, userID, bundle, quantity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) *models.Order); ok {
+		r0 = rf(ctx, userID, bundle, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = rf(ctx, userID, bundle, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BuyGift provides a mock function with given fields: ctx, purchase
+func (_m *CoinServiceInterface) BuyGift(ctx context.Context, purchase models.Purchase) (*models.Order, error) {
+	ret := _m.Called(ctx, purchase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuyGift")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Purchase) (*models.Order, error)); ok {
+		return rf(ctx, purchase)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Purchase) *models.Order); ok {
+		r0 = rf(ctx, purchase)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Purchase) error); ok {
+		r1 = rf(ctx, purchase)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BuyItem provides a mock function with given fields: ctx, userID, item
 func (_m *CoinServiceInterface) BuyItem(ctx context.Context, userID int, item string) error {
 	ret := _m.Called(ctx, userID, item)
@@ -32,6 +93,36 @@ func (_m *CoinServiceInterface) BuyItem(ctx context.Context, userID int, item st
 	return r0
 }
 
+// BuyItems provides a mock function with given fields: ctx, purchase
+func (_m *CoinServiceInterface) BuyItems(ctx context.Context, purchase models.Purchase) (*models.Order, error) {
+	ret := _m.Called(ctx, purchase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuyItems")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Purchase) (*models.Order, error)); ok {
+		return rf(ctx, purchase)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Purchase) *models.Order); ok {
+		r0 = rf(ctx, purchase)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Purchase) error); ok {
+		r1 = rf(ctx, purchase)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserInfo provides a mock function with given fields: ctx, userID
 func (_m *CoinServiceInterface) GetUserInfo(ctx context.Context, userID int) (*api.InfoResponse, error) {
 	ret := _m.Called(ctx, userID)
@@ -62,6 +153,24 @@ func (_m *CoinServiceInterface) GetUserInfo(ctx context.Context, userID int) (*a
 	return r0, r1
 }
 
+// GrantCoins provides a mock function with given fields: ctx, toUser, amount
+func (_m *CoinServiceInterface) GrantCoins(ctx context.Context, toUser string, amount int) error {
+	ret := _m.Called(ctx, toUser, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantCoins")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(ctx, toUser, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendCoins provides a mock function with given fields: ctx, fromUserID, toUser, amount
 func (_m *CoinServiceInterface) SendCoins(ctx context.Context, fromUserID int, toUser string, amount int) error {
 	ret := _m.Called(ctx, fromUserID, toUser, amount)
@@ -80,6 +189,24 @@ func (_m *CoinServiceInterface) SendCoins(ctx context.Context, fromUserID int, t
 	return r0
 }
 
+// TransferItem provides a mock function with given fields: ctx, fromUserID, toUser, item, quantity
+func (_m *CoinServiceInterface) TransferItem(ctx context.Context, fromUserID int, toUser string, item string, quantity int) error {
+	ret := _m.Called(ctx, fromUserID, toUser, item, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransferItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string, int) error); ok {
+		r0 = rf(ctx, fromUserID, toUser, item, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCoinServiceInterface creates a new instance of CoinServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCoinServiceInterface(t interface {
@@ -5,23 +5,31 @@ import (
 	"errors"
 	"fmt"
 	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
 	"merch-store-service/internal/domain/repository"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=CoinServiceInterface
 type CoinServiceInterface interface {
 	SendCoins(ctx context.Context, fromUserID int, toUser string, amount int) error
+	TransferItem(ctx context.Context, fromUserID int, toUser string, item string, quantity int) error
 	BuyItem(ctx context.Context, userID int, item string) error
+	BuyItems(ctx context.Context, purchase models.Purchase) (*models.Order, error)
+	BuyGift(ctx context.Context, purchase models.Purchase) (*models.Order, error)
+	BuyBundle(ctx context.Context, userID int, bundle string, quantity int) (*models.Order, error)
 	GetUserInfo(ctx context.Context, userID int) (*api.InfoResponse, error)
+	GrantCoins(ctx context.Context, toUser string, amount int) error
 }
 
 type CoinService struct {
-	storage *repository.Storage
+	storage          *repository.Storage
+	maxOrderQuantity int
 }
 
-func NewCoinService(storage *repository.Storage) *CoinService {
+func NewCoinService(storage *repository.Storage, maxOrderQuantity int) *CoinService {
 	return &CoinService{
-		storage: storage,
+		storage:          storage,
+		maxOrderQuantity: maxOrderQuantity,
 	}
 }
 
@@ -42,10 +50,77 @@ func (s *CoinService) SendCoins(ctx context.Context, fromUserID int, toUser stri
 	return s.storage.SendCoins(ctx, fromUserID, user.ID, amount)
 }
 
+func (s *CoinService) TransferItem(ctx context.Context, fromUserID int, toUser string, item string, quantity int) error {
+	if quantity <= 0 {
+		return fmt.Errorf("quantity must be positive: %w", repository.ErrInvalidQuantity)
+	}
+
+	user, err := s.storage.GetUserByUsername(ctx, toUser)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return fmt.Errorf("recipient user '%s' not found: %w", toUser, err)
+		}
+		return fmt.Errorf("failed to get recipient: %w", err)
+	}
+
+	if fromUserID == user.ID {
+		return models.ErrTransferToSelf
+	}
+
+	return s.storage.TransferItem(ctx, fromUserID, user.ID, item, quantity)
+}
+
 func (s *CoinService) BuyItem(ctx context.Context, userID int, item string) error {
 	return s.storage.BuyItem(ctx, userID, item)
 }
 
+func (s *CoinService) BuyItems(ctx context.Context, purchase models.Purchase) (*models.Order, error) {
+	if purchase.Quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive: %w", repository.ErrInvalidQuantity)
+	}
+
+	if s.maxOrderQuantity > 0 && purchase.Quantity > s.maxOrderQuantity {
+		return nil, fmt.Errorf("quantity exceeds the per-order maximum of %d: %w", s.maxOrderQuantity, repository.ErrInvalidQuantity)
+	}
+
+	return s.storage.Purchase(ctx, purchase)
+}
+
+// BuyBundle buys quantity bundles. The bundle price is debited once per
+// bundle, and the order lists every component with the bundle it came from.
+func (s *CoinService) BuyBundle(ctx context.Context, userID int, bundle string, quantity int) (*models.Order, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive: %w", repository.ErrInvalidQuantity)
+	}
+
+	if s.maxOrderQuantity > 0 && quantity > s.maxOrderQuantity {
+		return nil, fmt.Errorf("quantity exceeds the per-order maximum of %d: %w", s.maxOrderQuantity, repository.ErrInvalidQuantity)
+	}
+
+	return s.storage.BuyBundle(ctx, userID, bundle, quantity)
+}
+
+func (s *CoinService) BuyGift(ctx context.Context, purchase models.Purchase) (*models.Order, error) {
+	if purchase.Gift == nil {
+		return nil, fmt.Errorf("gift recipient is required")
+	}
+
+	user, err := s.storage.GetUserByUsername(ctx, purchase.Gift.Recipient)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, fmt.Errorf("recipient user '%s' not found: %w", purchase.Gift.Recipient, err)
+		}
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+	purchase.Gift.RecipientID = user.ID
+
+	if err := purchase.Gift.Validate(purchase.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.BuyItems(ctx, purchase)
+}
+
 func (s *CoinService) GetUserInfo(ctx context.Context, userID int) (*api.InfoResponse, error) {
 	coins, err := s.storage.GetUserCoins(ctx, userID)
 	if err != nil {
@@ -62,9 +137,105 @@ func (s *CoinService) GetUserInfo(ctx context.Context, userID int) (*api.InfoRes
 		return nil, fmt.Errorf("failed to get user inventory: %w", err)
 	}
 
+	received, sent, err := s.storage.GetUserGifts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user gifts: %w", err)
+	}
+
+	itemsReceived, itemsSent, err := s.storage.GetUserItemHistory(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user item history: %w", err)
+	}
+
 	return &api.InfoResponse{
 		Coins:       &coins,
 		CoinHistory: coinHistory.CoinHistory,
 		Inventory:   inventoryResponse.Inventory,
+		Gifts:       toAPIGifts(received, sent),
+		ItemHistory: toAPIItemHistory(itemsReceived, itemsSent),
 	}, nil
 }
+
+func toAPIGifts(received, sent []models.GiftRecord) *struct {
+	Received *[]api.ReceivedGift `json:"received,omitempty"`
+	Sent     *[]api.SentGift     `json:"sent,omitempty"`
+} {
+	receivedGifts := make([]api.ReceivedGift, 0, len(received))
+	for i := range received {
+		g := &received[i]
+		receivedGifts = append(receivedGifts, api.ReceivedGift{
+			FromUser:   g.VisibleSender(),
+			Item:       g.Item,
+			Quantity:   g.Quantity,
+			Message:    g.Message,
+			ReceivedAt: g.CreatedAt,
+		})
+	}
+
+	sentGifts := make([]api.SentGift, 0, len(sent))
+	for _, g := range sent {
+		sentGifts = append(sentGifts, api.SentGift{
+			ToUser:    g.Recipient,
+			Item:      g.Item,
+			Quantity:  g.Quantity,
+			Message:   g.Message,
+			Anonymous: g.Anonymous,
+			SentAt:    g.CreatedAt,
+		})
+	}
+
+	return &struct {
+		Received *[]api.ReceivedGift `json:"received,omitempty"`
+		Sent     *[]api.SentGift     `json:"sent,omitempty"`
+	}{
+		Received: &receivedGifts,
+		Sent:     &sentGifts,
+	}
+}
+
+func toAPIItemHistory(received, sent []models.ItemTransfer) *struct {
+	Received *[]api.ReceivedItem `json:"received,omitempty"`
+	Sent     *[]api.SentItem     `json:"sent,omitempty"`
+} {
+	receivedItems := make([]api.ReceivedItem, 0, len(received))
+	for _, t := range received {
+		receivedItems = append(receivedItems, api.ReceivedItem{
+			FromUser:   t.Sender,
+			Item:       t.Item,
+			Quantity:   t.Quantity,
+			ReceivedAt: t.CreatedAt,
+		})
+	}
+
+	sentItems := make([]api.SentItem, 0, len(sent))
+	for _, t := range sent {
+		sentItems = append(sentItems, api.SentItem{
+			ToUser:   t.Recipient,
+			Item:     t.Item,
+			Quantity: t.Quantity,
+			SentAt:   t.CreatedAt,
+		})
+	}
+
+	return &struct {
+		Received *[]api.ReceivedItem `json:"received,omitempty"`
+		Sent     *[]api.SentItem     `json:"sent,omitempty"`
+	}{
+		Received: &receivedItems,
+		Sent:     &sentItems,
+	}
+}
+
+// GrantCoins credits the user with new coins, for example a bonus from HR.
+func (s *CoinService) GrantCoins(ctx context.Context, toUser string, amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("amount must be positive: %w", repository.ErrInvalidQuantity)
+	}
+
+	user, err := s.storage.GetUserByUsername(ctx, toUser)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.GrantCoins(ctx, user.ID, amount)
+}
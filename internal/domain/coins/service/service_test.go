@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"merch-store-service/internal/api"
 	"merch-store-service/internal/domain/coins/service/mocks"
+	"merch-store-service/internal/domain/models"
 )
 
 func TestSendCoins(t *testing.T) {
@@ -70,6 +71,69 @@ func TestSendCoins(t *testing.T) {
 	}
 }
 
+func TestTransferItem(t *testing.T) {
+	mockService := new(mocks.CoinServiceInterface)
+
+	testCases := []struct {
+		name        string
+		fromUserID  int
+		toUsername  string
+		item        string
+		quantity    int
+		mockErr     error
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name:       "Successful item transfer",
+			fromUserID: 1,
+			toUsername: "recipient",
+			item:       "cup",
+			quantity:   1,
+			mockErr:    nil,
+			expectErr:  false,
+		},
+		{
+			name:        "Item not owned",
+			fromUserID:  1,
+			toUsername:  "recipient",
+			item:        "hoody",
+			quantity:    2,
+			mockErr:     errors.New("item is not in the user's inventory"),
+			expectErr:   true,
+			expectedErr: "not in the user's inventory",
+		},
+		{
+			name:        "Cannot transfer items to yourself",
+			fromUserID:  1,
+			toUsername:  "self",
+			item:        "cup",
+			quantity:    1,
+			mockErr:     models.ErrTransferToSelf,
+			expectErr:   true,
+			expectedErr: "to yourself",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("TransferItem", mock.Anything, tc.fromUserID, tc.toUsername, tc.item, tc.quantity).
+				Return(tc.mockErr)
+
+			err := mockService.TransferItem(context.Background(), tc.fromUserID, tc.toUsername, tc.item, tc.quantity)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestBuyItem(t *testing.T) {
 	mockService := new(mocks.CoinServiceInterface)
 
@@ -117,6 +181,156 @@ func TestBuyItem(t *testing.T) {
 	}
 }
 
+func TestBuyItems(t *testing.T) {
+	mockService := new(mocks.CoinServiceInterface)
+
+	testCases := []struct {
+		name        string
+		purchase    models.Purchase
+		discount    int
+		mockErr     error
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name:      "Successful purchase of several items",
+			purchase:  models.Purchase{UserID: 1, Item: "pen", Quantity: 10},
+			mockErr:   nil,
+			expectErr: false,
+		},
+		{
+			name:      "Purchase with a promo code",
+			purchase:  models.Purchase{UserID: 1, Item: "hoody", Quantity: 1, PromoCode: "ANNIVERSARY"},
+			discount:  60,
+			mockErr:   nil,
+			expectErr: false,
+		},
+		{
+			name:        "Quantity exceeds the per-order maximum",
+			purchase:    models.Purchase{UserID: 1, Item: "pen", Quantity: 1000},
+			mockErr:     errors.New("quantity exceeds the per-order maximum of 10"),
+			expectErr:   true,
+			expectedErr: "per-order maximum",
+		},
+		{
+			name:        "Promo code usage limit reached",
+			purchase:    models.Purchase{UserID: 2, Item: "hoody", Quantity: 1, PromoCode: "ANNIVERSARY"},
+			mockErr:     errors.New("promo code usage limit reached"),
+			expectErr:   true,
+			expectedErr: "usage limit",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mockOrder *models.Order
+			if !tc.expectErr {
+				mockOrder = &models.Order{
+					ID:       1,
+					UserID:   tc.purchase.UserID,
+					Status:   models.OrderStatusPlaced,
+					Discount: tc.discount,
+					Items:    []models.OrderItem{{ItemName: tc.purchase.Item, Quantity: tc.purchase.Quantity}},
+				}
+			}
+
+			mockService.On("BuyItems", mock.Anything, tc.purchase).
+				Return(mockOrder, tc.mockErr)
+
+			order, err := mockService.BuyItems(context.Background(), tc.purchase)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.OrderStatusPlaced, order.Status)
+				assert.Equal(t, tc.discount, order.Discount)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBuyGift(t *testing.T) {
+	mockService := new(mocks.CoinServiceInterface)
+
+	message := "С днём рождения!"
+
+	testCases := []struct {
+		name        string
+		purchase    models.Purchase
+		mockErr     error
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name: "Successful gift",
+			purchase: models.Purchase{UserID: 1, Item: "cup", Quantity: 1,
+				Gift: &models.Gift{Recipient: "colleague", Message: &message}},
+			mockErr:   nil,
+			expectErr: false,
+		},
+		{
+			name: "Anonymous gift",
+			purchase: models.Purchase{UserID: 1, Item: "book", Quantity: 1,
+				Gift: &models.Gift{Recipient: "colleague", Anonymous: true}},
+			mockErr:   nil,
+			expectErr: false,
+		},
+		{
+			name: "Gift to yourself",
+			purchase: models.Purchase{UserID: 1, Item: "cup", Quantity: 1,
+				Gift: &models.Gift{Recipient: "self"}},
+			mockErr:     models.ErrGiftToSelf,
+			expectErr:   true,
+			expectedErr: "yourself",
+		},
+		{
+			name: "Recipient not found",
+			purchase: models.Purchase{UserID: 1, Item: "cup", Quantity: 1,
+				Gift: &models.Gift{Recipient: "unknown"}},
+			mockErr:     errors.New("recipient user 'unknown' not found"),
+			expectErr:   true,
+			expectedErr: "not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mockOrder *models.Order
+			if !tc.expectErr {
+				mockOrder = &models.Order{
+					ID:     1,
+					UserID: tc.purchase.UserID,
+					Status: models.OrderStatusPlaced,
+					Items:  []models.OrderItem{{ItemName: tc.purchase.Item, Quantity: tc.purchase.Quantity}},
+					Gift:   tc.purchase.Gift,
+				}
+			}
+
+			mockService.On("BuyGift", mock.Anything, tc.purchase).
+				Return(mockOrder, tc.mockErr)
+
+			order, err := mockService.BuyGift(context.Background(), tc.purchase)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.purchase.Gift.Recipient, order.Gift.Recipient)
+				assert.Equal(t, tc.purchase.Gift.Anonymous, order.Gift.Anonymous)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestGetUserInfo(t *testing.T) {
 	mockService := new(mocks.CoinServiceInterface)
 
@@ -182,3 +396,123 @@ func TestGetUserInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestBuyBundle(t *testing.T) {
+	mockService := new(mocks.CoinServiceInterface)
+
+	bundle := "welcome-pack"
+
+	testCases := []struct {
+		name        string
+		quantity    int
+		mockErr     error
+		expectErr   bool
+		expectedErr string
+	}{
+		{
+			name:      "Successful purchase",
+			quantity:  1,
+			mockErr:   nil,
+			expectErr: false,
+		},
+		{
+			name:        "Component out of stock",
+			quantity:    2,
+			mockErr:     errors.New("domain.repository.BuyBundle: t-shirt: item out of stock"),
+			expectErr:   true,
+			expectedErr: "out of stock",
+		},
+		{
+			name:        "Insufficient funds",
+			quantity:    20,
+			mockErr:     errors.New("insufficient funds"),
+			expectErr:   true,
+			expectedErr: "insufficient funds",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mockOrder *models.Order
+			if !tc.expectErr {
+				mockOrder = &models.Order{
+					ID:     1,
+					UserID: 1,
+					Status: models.OrderStatusPlaced,
+					Total:  90 * tc.quantity,
+					Items: []models.OrderItem{
+						{ItemName: "cup", Quantity: tc.quantity, Bundle: &bundle},
+						{ItemName: "pen", Quantity: tc.quantity, Bundle: &bundle},
+						{ItemName: "t-shirt", Quantity: tc.quantity, Bundle: &bundle},
+					},
+				}
+			}
+
+			mockService.On("BuyBundle", mock.Anything, 1, bundle, tc.quantity).
+				Return(mockOrder, tc.mockErr)
+
+			order, err := mockService.BuyBundle(context.Background(), 1, bundle, tc.quantity)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 90, order.Total, "The bundle price is charged once")
+				assert.Len(t, order.Items, 3)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGrantCoins(t *testing.T) {
+	mockService := new(mocks.CoinServiceInterface)
+
+	testCases := []struct {
+		name        string
+		toUsername  string
+		amount      int
+		mockErr     error
+		expectedErr string
+	}{
+		{
+			name:       "Successful grant",
+			toUsername: "recipient",
+			amount:     500,
+		},
+		{
+			name:        "Non-positive amount",
+			toUsername:  "recipient",
+			amount:      0,
+			mockErr:     errors.New("amount must be positive: invalid quantity"),
+			expectedErr: "amount must be positive",
+		},
+		{
+			name:        "Recipient user not found",
+			toUsername:  "unknown",
+			amount:      500,
+			mockErr:     errors.New("domain.repository.GetUserByUsername: user not found"),
+			expectedErr: "user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("GrantCoins", mock.Anything, tc.toUsername, tc.amount).
+				Return(tc.mockErr)
+
+			err := mockService.GrantCoins(context.Background(), tc.toUsername, tc.amount)
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+)
+
+var ErrInvalidDrop = errors.New("invalid drop")
+
+type DropServiceInterface interface {
+	CreateDrop(ctx context.Context, drop models.Drop) (*models.Drop, error)
+	ListDrops(ctx context.Context) ([]models.Drop, error)
+}
+
+// DropStorage is the part of the repository the drop service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=DropStorage
+type DropStorage interface {
+	CreateDrop(ctx context.Context, drop *models.Drop) (*models.Drop, error)
+	ListDrops(ctx context.Context) ([]models.Drop, error)
+}
+
+type DropService struct {
+	storage DropStorage
+}
+
+func NewDropService(storage DropStorage) *DropService {
+	return &DropService{
+		storage: storage,
+	}
+}
+
+func (s *DropService) CreateDrop(ctx context.Context, drop models.Drop) (*models.Drop, error) {
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	drop.StartsAt = drop.StartsAt.UTC()
+	if drop.EndsAt != nil {
+		ends := drop.EndsAt.UTC()
+		drop.EndsAt = &ends
+	}
+
+	if err := drop.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDrop, err)
+	}
+
+	return s.storage.CreateDrop(ctx, &drop)
+}
+
+// ListDrops returns upcoming and running drops.
+func (s *DropService) ListDrops(ctx context.Context) ([]models.Drop, error) {
+	return s.storage.ListDrops(ctx)
+}
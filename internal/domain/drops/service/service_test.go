@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/drops/service/mocks"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+)
+
+func TestCreateDrop(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewDropStorage(t)
+	service := NewDropService(storage)
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2026, 12, 1, 15, 0, 0, 0, moscow)
+	end := start.Add(2 * time.Hour)
+
+	// Timestamps are stored in UTC.
+	storage.On("CreateDrop", ctx, mock.MatchedBy(func(d *models.Drop) bool {
+		return d.StartsAt.Location() == time.UTC && d.EndsAt.Location() == time.UTC && d.StartsAt.Equal(start)
+	})).Return(&models.Drop{ID: 1, Product: "pink-hoody"}, nil).Once()
+
+	drop, err := service.CreateDrop(ctx, models.Drop{Product: "pink-hoody", StartsAt: start, EndsAt: &end, Stock: 50, PerUserLimit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, drop.ID)
+
+	_, err = service.CreateDrop(ctx, models.Drop{Product: "pink-hoody", StartsAt: start, EndsAt: &start, Stock: 50, PerUserLimit: 1})
+	assert.ErrorIs(t, err, ErrInvalidDrop, "A drop should not end before it starts")
+
+	_, err = service.CreateDrop(ctx, models.Drop{Product: "pink-hoody", StartsAt: start, Stock: 50})
+	assert.ErrorIs(t, err, ErrInvalidDrop, "The per-user limit should be positive")
+
+	_, err = service.CreateDrop(ctx, models.Drop{Product: "pink-hoody", Stock: 50, PerUserLimit: 1})
+	assert.ErrorIs(t, err, ErrInvalidDrop, "The start time is required")
+
+	storage.On("CreateDrop", ctx, mock.Anything).Return(nil, repository.ErrItemNotFound).Once()
+
+	_, err = service.CreateDrop(ctx, models.Drop{Product: "golden-hoody", StartsAt: start, Stock: 5, PerUserLimit: 1})
+	assert.ErrorIs(t, err, repository.ErrItemNotFound)
+}
+
+func TestListDrops(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewDropStorage(t)
+	service := NewDropService(storage)
+
+	storage.On("ListDrops", ctx).Return([]models.Drop{{ID: 1, Product: "pink-hoody", Stock: 50, Remaining: 12}}, nil).Once()
+
+	drops, err := service.ListDrops(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, drops, 1)
+	assert.Equal(t, 12, drops[0].Remaining)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+)
+
+type MarketplaceServiceInterface interface {
+	CreateListing(ctx context.Context, sellerID int, item string, quantity, price int) (*models.Listing, error)
+	ListListings(ctx context.Context, item *string) ([]models.Listing, error)
+	CancelListing(ctx context.Context, sellerID, listingID int) (*models.Listing, error)
+	Buy(ctx context.Context, buyerID, listingID, quantity int) (*models.Trade, error)
+}
+
+// MarketplaceStorage is the part of the repository the marketplace service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=MarketplaceStorage
+type MarketplaceStorage interface {
+	CreateListing(ctx context.Context, listing *models.Listing) (*models.Listing, error)
+	ListListings(ctx context.Context, item *string) ([]models.Listing, error)
+	CancelListing(ctx context.Context, listingID, sellerID int) (*models.Listing, error)
+	BuyListing(ctx context.Context, listingID, buyerID, quantity, feePercent int) (*models.Trade, error)
+}
+
+type MarketplaceService struct {
+	storage    MarketplaceStorage
+	feePercent int
+}
+
+func NewMarketplaceService(storage MarketplaceStorage, feePercent int) *MarketplaceService {
+	return &MarketplaceService{
+		storage:    storage,
+		feePercent: feePercent,
+	}
+}
+
+// CreateListing lists items from the seller's inventory for a coin price per unit.
+func (s *MarketplaceService) CreateListing(ctx context.Context, sellerID int, item string, quantity, price int) (*models.Listing, error) {
+	listing := &models.Listing{
+		SellerID: sellerID,
+		Item:     item,
+		Quantity: quantity,
+		Price:    price,
+	}
+
+	if err := listing.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.storage.CreateListing(ctx, listing)
+}
+
+// ListListings returns active listings, optionally for a single item.
+func (s *MarketplaceService) ListListings(ctx context.Context, item *string) ([]models.Listing, error) {
+	return s.storage.ListListings(ctx, item)
+}
+
+// CancelListing withdraws the seller's listing and returns unsold units.
+func (s *MarketplaceService) CancelListing(ctx context.Context, sellerID, listingID int) (*models.Listing, error) {
+	return s.storage.CancelListing(ctx, listingID, sellerID)
+}
+
+// Buy buys units from a listing; the marketplace fee is burned.
+func (s *MarketplaceService) Buy(ctx context.Context, buyerID, listingID, quantity int) (*models.Trade, error) {
+	if quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive: %w", repository.ErrInvalidQuantity)
+	}
+
+	return s.storage.BuyListing(ctx, listingID, buyerID, quantity, s.feePercent)
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/marketplace/service/mocks"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+)
+
+func TestCreateListing(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewMarketplaceStorage(t)
+	service := NewMarketplaceService(storage, 5)
+
+	storage.On("CreateListing", ctx, &models.Listing{SellerID: 1, Item: "cup", Quantity: 2, Price: 30}).
+		Return(&models.Listing{ID: 1, SellerID: 1, Item: "cup", Quantity: 2, Remaining: 2, Price: 30, Status: models.ListingActive}, nil).Once()
+
+	listing, err := service.CreateListing(ctx, 1, "cup", 2, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ListingActive, listing.Status)
+
+	_, err = service.CreateListing(ctx, 1, "cup", 0, 30)
+	assert.ErrorIs(t, err, models.ErrInvalidListing)
+
+	_, err = service.CreateListing(ctx, 1, "cup", 2, -5)
+	assert.ErrorIs(t, err, models.ErrInvalidListing)
+
+	storage.On("CreateListing", ctx, mock.Anything).Return(nil, repository.ErrItemNotOwned).Once()
+
+	_, err = service.CreateListing(ctx, 1, "hoody", 1, 300)
+	assert.ErrorIs(t, err, repository.ErrItemNotOwned)
+}
+
+func TestCancelListing(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewMarketplaceStorage(t)
+	service := NewMarketplaceService(storage, 5)
+
+	storage.On("CancelListing", ctx, 10, 1).Return(&models.Listing{ID: 10, Status: models.ListingCancelled}, nil).Once()
+
+	listing, err := service.CancelListing(ctx, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ListingCancelled, listing.Status)
+
+	storage.On("CancelListing", ctx, 11, 1).Return(nil, repository.ErrListingNotFound).Once()
+
+	_, err = service.CancelListing(ctx, 1, 11)
+	assert.ErrorIs(t, err, repository.ErrListingNotFound)
+}
+
+func TestBuy(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewMarketplaceStorage(t)
+	service := NewMarketplaceService(storage, 5)
+
+	// The configured fee is passed on to the storage.
+	storage.On("BuyListing", ctx, 10, 2, 1, 5).
+		Return(&models.Trade{ListingID: 10, BuyerID: 2, Item: "cup", Quantity: 1, Total: 30, Fee: 1}, nil).Once()
+
+	trade, err := service.Buy(ctx, 2, 10, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, trade.Fee)
+
+	_, err = service.Buy(ctx, 2, 10, 0)
+	assert.ErrorIs(t, err, repository.ErrInvalidQuantity)
+
+	storage.On("BuyListing", ctx, 10, 1, 1, 5).Return(nil, models.ErrOwnListing).Once()
+
+	_, err = service.Buy(ctx, 1, 10, 1)
+	assert.ErrorIs(t, err, models.ErrOwnListing)
+
+	storage.On("BuyListing", ctx, 11, 2, 1, 5).Return(nil, models.ErrListingNotActive).Once()
+
+	_, err = service.Buy(ctx, 2, 11, 1)
+	assert.ErrorIs(t, err, models.ErrListingNotActive)
+
+	storage.On("BuyListing", ctx, 12, 2, 3, 5).Return(nil, repository.ErrInsufficientFunds).Once()
+
+	_, err = service.Buy(ctx, 2, 12, 3)
+	assert.ErrorIs(t, err, repository.ErrInsufficientFunds)
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type AuctionStatus string
+
+// AuctionOpen is the stored state of an auction until it is settled as sold
+// or unsold; Status refines it into upcoming, active or ended.
+const (
+	AuctionOpen     AuctionStatus = "open"
+	AuctionUpcoming AuctionStatus = "upcoming"
+	AuctionActive   AuctionStatus = "active"
+	AuctionEnded    AuctionStatus = "ended"
+	AuctionSold     AuctionStatus = "sold"
+	AuctionUnsold   AuctionStatus = "unsold"
+)
+
+var (
+	ErrAuctionNotStarted = errors.New("auction has not started yet")
+	ErrAuctionEnded      = errors.New("auction has ended")
+	ErrBidTooLow         = errors.New("bid is below the minimum")
+)
+
+// Auction sells a lot of a product to the highest bidder. The leading bid is
+// held: its coins are taken from the leader's balance and given back when
+// they are outbid. When the auction closes the lot goes to the leader if the
+// reserve price is met; otherwise the hold is released.
+type Auction struct {
+	ID           int
+	Product      string
+	Quantity     int
+	StartsAt     time.Time
+	EndsAt       time.Time
+	ReservePrice int
+	MinIncrement int
+	// Extension is the anti-sniping window: a bid placed closer than this
+	// to the end pushes the end out to now + Extension.
+	Extension  time.Duration
+	State      AuctionStatus
+	LeaderID   *int
+	Leader     *string
+	CurrentBid *int
+	CreatedAt  time.Time
+	ClosedAt   *time.Time
+}
+
+// Bid is a single bid in an auction's history.
+type Bid struct {
+	Bidder    string
+	Amount    int
+	CreatedAt time.Time
+}
+
+// Validate checks the auction definition before it is stored.
+func (a *Auction) Validate() error {
+	if a.Product == "" {
+		return errors.New("product is required")
+	}
+
+	if a.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+
+	if a.StartsAt.IsZero() || a.EndsAt.IsZero() {
+		return errors.New("start and end times are required")
+	}
+
+	if !a.EndsAt.After(a.StartsAt) {
+		return errors.New("auction ends before it starts")
+	}
+
+	if a.ReservePrice < 0 {
+		return errors.New("reserve price cannot be negative")
+	}
+
+	if a.MinIncrement <= 0 {
+		return errors.New("minimum increment must be positive")
+	}
+
+	if a.Extension < 0 {
+		return errors.New("extension cannot be negative")
+	}
+
+	return nil
+}
+
+// Status reports the state of the auction at now. An auction that has ended
+// but has not been settled yet is reported as ended.
+func (a *Auction) Status(now time.Time) AuctionStatus {
+	switch {
+	case a.State != AuctionOpen:
+		return a.State
+	case now.Before(a.StartsAt):
+		return AuctionUpcoming
+	case !now.Before(a.EndsAt):
+		return AuctionEnded
+	default:
+		return AuctionActive
+	}
+}
+
+// MinimumBid is the lowest amount the next bid may have.
+func (a *Auction) MinimumBid() int {
+	if a.CurrentBid == nil {
+		return a.MinIncrement
+	}
+	return *a.CurrentBid + a.MinIncrement
+}
+
+// ReserveMet reports whether the leading bid is high enough to sell the lot.
+func (a *Auction) ReserveMet() bool {
+	return a.CurrentBid != nil && *a.CurrentBid >= a.ReservePrice
+}
+
+// CheckBid reports why amount cannot be bid at now, if it cannot.
+func (a *Auction) CheckBid(now time.Time, amount int) error {
+	switch a.Status(now) {
+	case AuctionUpcoming:
+		return ErrAuctionNotStarted
+	case AuctionActive:
+	default:
+		return ErrAuctionEnded
+	}
+
+	if amount < a.MinimumBid() {
+		return ErrBidTooLow
+	}
+
+	return nil
+}
+
+// ExtendedEnd returns the end of the auction after a bid at now, applying
+// the anti-sniping extension.
+func (a *Auction) ExtendedEnd(now time.Time) time.Time {
+	if a.Extension > 0 && a.EndsAt.Sub(now) < a.Extension {
+		return now.Add(a.Extension)
+	}
+	return a.EndsAt
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuctionStatus(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		state    AuctionStatus
+		now      time.Time
+		expected AuctionStatus
+	}{
+		{"Before start", AuctionOpen, start.Add(-time.Minute), AuctionUpcoming},
+		{"Running", AuctionOpen, start.Add(time.Minute), AuctionActive},
+		{"Past the end but not settled", AuctionOpen, end, AuctionEnded},
+		{"Settled as sold", AuctionSold, end.Add(time.Minute), AuctionSold},
+		{"Settled as unsold", AuctionUnsold, end.Add(time.Minute), AuctionUnsold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Auction{StartsAt: start, EndsAt: end, State: tt.state}
+			assert.Equal(t, tt.expected, a.Status(tt.now))
+		})
+	}
+}
+
+func TestAuctionCheckBid(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	current := 100
+
+	tests := []struct {
+		name       string
+		currentBid *int
+		now        time.Time
+		amount     int
+		expected   error
+	}{
+		{"Opening bid", nil, start.Add(time.Minute), 10, nil},
+		{"Opening bid below the increment", nil, start.Add(time.Minute), 5, ErrBidTooLow},
+		{"Outbid by the increment", &current, start.Add(time.Minute), 110, nil},
+		{"Raise smaller than the increment", &current, start.Add(time.Minute), 109, ErrBidTooLow},
+		{"Too early", nil, start.Add(-time.Minute), 10, ErrAuctionNotStarted},
+		{"Too late", &current, end, 200, ErrAuctionEnded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Auction{StartsAt: start, EndsAt: end, MinIncrement: 10, CurrentBid: tt.currentBid, State: AuctionOpen}
+			assert.Equal(t, tt.expected, a.CheckBid(tt.now, tt.amount))
+		})
+	}
+}
+
+func TestAuctionExtendedEnd(t *testing.T) {
+	end := time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC)
+	a := Auction{EndsAt: end, Extension: 2 * time.Minute}
+
+	assert.Equal(t, end, a.ExtendedEnd(end.Add(-10*time.Minute)), "Early bids do not extend the auction")
+	assert.Equal(t, end.Add(90*time.Second), a.ExtendedEnd(end.Add(-30*time.Second)), "Last-minute bids push the end out")
+
+	a.Extension = 0
+	assert.Equal(t, end, a.ExtendedEnd(end.Add(-30*time.Second)), "Anti-sniping can be disabled")
+}
+
+func TestAuctionReserveMet(t *testing.T) {
+	low, high := 40, 60
+	a := Auction{ReservePrice: 50}
+
+	assert.False(t, a.ReserveMet(), "No bids")
+	a.CurrentBid = &low
+	assert.False(t, a.ReserveMet())
+	a.CurrentBid = &high
+	assert.True(t, a.ReserveMet())
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BundleItem is one component of a bundle: Quantity units of Product are
+// added to the buyer's inventory for every bundle bought.
+type BundleItem struct {
+	Product  string
+	Quantity int
+}
+
+// Bundle is a set of products sold together at a single price.
+type Bundle struct {
+	ID        int
+	Name      string
+	Price     int
+	Items     []BundleItem
+	Available bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks the bundle definition before it is stored and sorts the
+// components by product, the order in which their stock is locked.
+func (b *Bundle) Validate() error {
+	if b.Name == "" {
+		return errors.New("bundle name is required")
+	}
+
+	if b.Price <= 0 {
+		return errors.New("bundle price must be positive")
+	}
+
+	if len(b.Items) == 0 {
+		return errors.New("bundle must contain at least one product")
+	}
+
+	seen := make(map[string]bool, len(b.Items))
+	for _, item := range b.Items {
+		if item.Product == "" {
+			return errors.New("component product is required")
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("quantity of %s must be positive", item.Product)
+		}
+		if seen[item.Product] {
+			return fmt.Errorf("%s is listed more than once", item.Product)
+		}
+		seen[item.Product] = true
+	}
+
+	sort.Slice(b.Items, func(i, j int) bool { return b.Items[i].Product < b.Items[j].Product })
+
+	return nil
+}
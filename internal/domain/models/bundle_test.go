@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		bundle   Bundle
+		expected string
+	}{
+		{"Valid bundle", Bundle{Name: "welcome-pack", Price: 90, Items: []BundleItem{{"t-shirt", 1}, {"cup", 1}, {"pen", 2}}}, ""},
+		{"Missing name", Bundle{Price: 90, Items: []BundleItem{{"cup", 1}}}, "name is required"},
+		{"Free bundle", Bundle{Name: "free", Items: []BundleItem{{"cup", 1}}}, "price must be positive"},
+		{"No components", Bundle{Name: "empty", Price: 10}, "at least one product"},
+		{"Zero quantity", Bundle{Name: "pack", Price: 10, Items: []BundleItem{{"cup", 0}}}, "quantity of cup"},
+		{"Duplicate component", Bundle{Name: "pack", Price: 10, Items: []BundleItem{{"cup", 1}, {"cup", 2}}}, "more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bundle.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expected)
+			}
+		})
+	}
+
+	b := Bundle{Name: "welcome-pack", Price: 90, Items: []BundleItem{{"t-shirt", 1}, {"cup", 1}, {"pen", 2}}}
+	assert.NoError(t, b.Validate())
+	assert.Equal(t, []BundleItem{{"cup", 1}, {"pen", 2}, {"t-shirt", 1}}, b.Items, "Components are sorted by product")
+}
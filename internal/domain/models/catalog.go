@@ -0,0 +1,138 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CatalogEntry is one product as it appears in a catalog file. Line is the
+// line of the file the entry starts on, used to report validation errors.
+type CatalogEntry struct {
+	Name  string
+	Price int
+	Line  int
+}
+
+// CatalogProduct is a product as currently stored in the catalog.
+type CatalogProduct struct {
+	Name     string
+	Price    int
+	Archived bool
+}
+
+type CatalogChangeKind string
+
+const (
+	CatalogCreate  CatalogChangeKind = "create"
+	CatalogUpdate  CatalogChangeKind = "update"
+	CatalogRestore CatalogChangeKind = "restore"
+	CatalogArchive CatalogChangeKind = "archive"
+)
+
+// CatalogChange is one step needed to bring the stored catalog in line with
+// a catalog file. OldPrice is set for changes to existing products.
+type CatalogChange struct {
+	Kind     CatalogChangeKind
+	Name     string
+	OldPrice int
+	Price    int
+}
+
+func (c CatalogChange) String() string {
+	switch c.Kind {
+	case CatalogCreate:
+		return fmt.Sprintf("+ create  %s (price %d)", c.Name, c.Price)
+	case CatalogUpdate:
+		return fmt.Sprintf("~ update  %s (price %d -> %d)", c.Name, c.OldPrice, c.Price)
+	case CatalogRestore:
+		if c.OldPrice != c.Price {
+			return fmt.Sprintf("^ restore %s (price %d -> %d)", c.Name, c.OldPrice, c.Price)
+		}
+		return fmt.Sprintf("^ restore %s (price %d)", c.Name, c.Price)
+	default:
+		return fmt.Sprintf("- archive %s", c.Name)
+	}
+}
+
+// CatalogRowError is a problem with one row of a catalog file.
+type CatalogRowError struct {
+	Line    int
+	Message string
+}
+
+func (e CatalogRowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// CatalogErrors collects every row error found in a catalog file.
+type CatalogErrors []CatalogRowError
+
+func (e CatalogErrors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, rowErr := range e {
+		lines = append(lines, rowErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateCatalog checks every entry of a catalog file and returns all the
+// problems found, or nil.
+func ValidateCatalog(entries []CatalogEntry) CatalogErrors {
+	var errs CatalogErrors
+	seen := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		switch {
+		case entry.Name == "":
+			errs = append(errs, CatalogRowError{entry.Line, "name is required"})
+		case strings.TrimSpace(entry.Name) != entry.Name:
+			errs = append(errs, CatalogRowError{entry.Line, fmt.Sprintf("name %q has leading or trailing spaces", entry.Name)})
+		case seen[entry.Name] != 0:
+			errs = append(errs, CatalogRowError{entry.Line, fmt.Sprintf("duplicate product %q, first listed on line %d", entry.Name, seen[entry.Name])})
+		default:
+			seen[entry.Name] = entry.Line
+		}
+
+		if entry.Price < 0 {
+			errs = append(errs, CatalogRowError{entry.Line, "price cannot be negative"})
+		}
+	}
+	return errs
+}
+
+// DiffCatalog returns the changes that turn the current catalog into the
+// desired one, ordered by product name. Products missing from the desired
+// catalog are archived rather than deleted, since orders and inventories
+// still refer to them.
+func DiffCatalog(current []CatalogProduct, desired []CatalogEntry) []CatalogChange {
+	stored := make(map[string]CatalogProduct, len(current))
+	for _, product := range current {
+		stored[product.Name] = product
+	}
+
+	var changes []CatalogChange
+	listed := make(map[string]bool, len(desired))
+	for _, entry := range desired {
+		listed[entry.Name] = true
+
+		product, ok := stored[entry.Name]
+		switch {
+		case !ok:
+			changes = append(changes, CatalogChange{Kind: CatalogCreate, Name: entry.Name, Price: entry.Price})
+		case product.Archived:
+			changes = append(changes, CatalogChange{Kind: CatalogRestore, Name: entry.Name, OldPrice: product.Price, Price: entry.Price})
+		case product.Price != entry.Price:
+			changes = append(changes, CatalogChange{Kind: CatalogUpdate, Name: entry.Name, OldPrice: product.Price, Price: entry.Price})
+		}
+	}
+
+	for _, product := range current {
+		if !listed[product.Name] && !product.Archived {
+			changes = append(changes, CatalogChange{Kind: CatalogArchive, Name: product.Name, OldPrice: product.Price, Price: product.Price})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+
+	return changes
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCatalog(t *testing.T) {
+	errs := ValidateCatalog([]CatalogEntry{
+		{Name: "cup", Price: 20, Line: 2},
+		{Name: "", Price: 10, Line: 3},
+		{Name: "pen", Price: -1, Line: 4},
+		{Name: "cup", Price: 25, Line: 5},
+		{Name: " book", Price: 50, Line: 6},
+	})
+
+	assert.Equal(t, CatalogErrors{
+		{3, "name is required"},
+		{4, "price cannot be negative"},
+		{5, `duplicate product "cup", first listed on line 2`},
+		{6, `name " book" has leading or trailing spaces`},
+	}, errs)
+	assert.Contains(t, errs.Error(), "line 4: price cannot be negative")
+
+	assert.Nil(t, ValidateCatalog([]CatalogEntry{{Name: "cup", Price: 0, Line: 2}}), "Free products are allowed")
+}
+
+func TestDiffCatalog(t *testing.T) {
+	current := []CatalogProduct{
+		{Name: "cup", Price: 20},
+		{Name: "pen", Price: 10},
+		{Name: "wallet", Price: 50},
+		{Name: "socks", Price: 10, Archived: true},
+		{Name: "umbrella", Price: 200, Archived: true},
+	}
+	desired := []CatalogEntry{
+		{Name: "cup", Price: 25},
+		{Name: "pen", Price: 10},
+		{Name: "socks", Price: 15},
+		{Name: "sticker", Price: 5},
+	}
+
+	assert.Equal(t, []CatalogChange{
+		{Kind: CatalogUpdate, Name: "cup", OldPrice: 20, Price: 25},
+		{Kind: CatalogRestore, Name: "socks", OldPrice: 10, Price: 15},
+		{Kind: CatalogCreate, Name: "sticker", Price: 5},
+		{Kind: CatalogArchive, Name: "wallet", OldPrice: 50, Price: 50},
+	}, DiffCatalog(current, desired), "Archived products stay archived unless listed again")
+
+	assert.Empty(t, DiffCatalog(current, []CatalogEntry{{Name: "cup", Price: 20}, {Name: "pen", Price: 10}, {Name: "wallet", Price: 50}}))
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidCredentials   = errors.New("invalid username or password")
+	ErrUnknownAuthenticator = errors.New("unknown authenticator")
+)
+
+// Authenticator names, in the order they may be listed in the config.
+const (
+	AuthenticatorLocal = "local"
+	AuthenticatorLDAP  = "ldap"
+)
+
+// ParseAuthenticators checks the configured authenticator chain. An empty
+// chain means local passwords only.
+func ParseAuthenticators(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return []string{AuthenticatorLocal}, nil
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name != AuthenticatorLocal && name != AuthenticatorLDAP {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownAuthenticator, name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("authenticator %q is listed twice", name)
+		}
+		seen[name] = true
+	}
+	return names, nil
+}
+
+// DirectoryUser is an account in an external directory such as LDAP, with
+// the attributes that are copied to the local user on every sign-in.
+type DirectoryUser struct {
+	Source      string
+	DN          string
+	Username    string
+	DisplayName string
+	Email       string
+	Department  string
+}
+
+// UserProfile holds the directory attributes of a local user as of their
+// last sign-in through the directory.
+type UserProfile struct {
+	Username    string
+	Source      string
+	DN          string
+	DisplayName string
+	Email       string
+	Department  string
+	SyncedAt    time.Time
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAuthenticators(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "default", names: nil, want: []string{AuthenticatorLocal}},
+		{name: "local then ldap", names: []string{"local", "ldap"}, want: []string{AuthenticatorLocal, AuthenticatorLDAP}},
+		{name: "ldap only", names: []string{"ldap"}, want: []string{AuthenticatorLDAP}},
+		{name: "unknown", names: []string{"local", "kerberos"}, wantErr: true},
+		{name: "duplicate", names: []string{"ldap", "ldap"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAuthenticators(tt.names)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ParseAuthenticators([]string{"radius"})
+	assert.ErrorIs(t, err, ErrUnknownAuthenticator)
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type DropStatus string
+
+const (
+	DropUpcoming DropStatus = "upcoming"
+	DropActive   DropStatus = "active"
+	DropSoldOut  DropStatus = "sold_out"
+	DropEnded    DropStatus = "ended"
+)
+
+var (
+	ErrDropNotStarted   = errors.New("drop has not started yet")
+	ErrDropEnded        = errors.New("drop has ended")
+	ErrDropLimitReached = errors.New("per-user limit for this drop reached")
+)
+
+// Drop is a timed release of a limited number of units of a product. While a
+// product has drops it can only be bought through them.
+type Drop struct {
+	ID           int
+	Product      string
+	StartsAt     time.Time
+	EndsAt       *time.Time
+	Stock        int
+	Remaining    int
+	PerUserLimit int
+	CreatedAt    time.Time
+}
+
+// Validate checks the drop definition before it is stored.
+func (d *Drop) Validate() error {
+	if d.Product == "" {
+		return errors.New("product is required")
+	}
+
+	if d.StartsAt.IsZero() {
+		return errors.New("start time is required")
+	}
+
+	if d.EndsAt != nil && !d.EndsAt.After(d.StartsAt) {
+		return errors.New("drop ends before it starts")
+	}
+
+	if d.Stock <= 0 {
+		return errors.New("stock must be positive")
+	}
+
+	if d.PerUserLimit <= 0 {
+		return errors.New("per-user limit must be positive")
+	}
+
+	return nil
+}
+
+func (d *Drop) Status(now time.Time) DropStatus {
+	switch {
+	case now.Before(d.StartsAt):
+		return DropUpcoming
+	case d.EndsAt != nil && !now.Before(*d.EndsAt):
+		return DropEnded
+	case d.Remaining == 0:
+		return DropSoldOut
+	default:
+		return DropActive
+	}
+}
+
+// CheckAllocation reports why a user who already holds allocated units cannot
+// get quantity more at now, if they cannot. Running out of stock is left to the caller.
+func (d *Drop) CheckAllocation(now time.Time, allocated, quantity int) error {
+	switch d.Status(now) {
+	case DropUpcoming:
+		return ErrDropNotStarted
+	case DropEnded:
+		return ErrDropEnded
+	}
+
+	if allocated+quantity > d.PerUserLimit {
+		return ErrDropLimitReached
+	}
+
+	return nil
+}
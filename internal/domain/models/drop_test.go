@@ -0,0 +1,88 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDropStatus(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		drop     Drop
+		expected DropStatus
+	}{
+		{"Not started yet", Drop{StartsAt: future, Remaining: 10}, DropUpcoming},
+		{"Open without an end", Drop{StartsAt: past, Remaining: 10}, DropActive},
+		{"Open inside the window", Drop{StartsAt: past, EndsAt: &future, Remaining: 1}, DropActive},
+		{"Sold out", Drop{StartsAt: past, Remaining: 0}, DropSoldOut},
+		{"Window closed", Drop{StartsAt: past.Add(-time.Hour), EndsAt: &past, Remaining: 5}, DropEnded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.drop.Status(now))
+		})
+	}
+}
+
+func TestDropCheckAllocation(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		drop      Drop
+		allocated int
+		quantity  int
+		expected  error
+	}{
+		{"First unit", Drop{StartsAt: past, Remaining: 5, PerUserLimit: 1}, 0, 1, nil},
+		{"Second unit over the limit", Drop{StartsAt: past, Remaining: 5, PerUserLimit: 1}, 1, 1, ErrDropLimitReached},
+		{"Several units within the limit", Drop{StartsAt: past, Remaining: 5, PerUserLimit: 3}, 1, 2, nil},
+		{"Several units over the limit", Drop{StartsAt: past, Remaining: 5, PerUserLimit: 3}, 0, 4, ErrDropLimitReached},
+		{"Before the start", Drop{StartsAt: future, Remaining: 5, PerUserLimit: 1}, 0, 1, ErrDropNotStarted},
+		{"After the end", Drop{StartsAt: past.Add(-time.Hour), EndsAt: &past, Remaining: 5, PerUserLimit: 1}, 0, 1, ErrDropEnded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.drop.CheckAllocation(now, tt.allocated, tt.quantity))
+		})
+	}
+}
+
+func TestDropValidate(t *testing.T) {
+	start := time.Now()
+	before := start.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		drop    Drop
+		wantErr bool
+	}{
+		{"Valid drop", Drop{Product: "pink-hoody", StartsAt: start, Stock: 50, PerUserLimit: 1}, false},
+		{"Missing product", Drop{StartsAt: start, Stock: 50, PerUserLimit: 1}, true},
+		{"Missing start", Drop{Product: "pink-hoody", Stock: 50, PerUserLimit: 1}, true},
+		{"Ends before start", Drop{Product: "pink-hoody", StartsAt: start, EndsAt: &before, Stock: 50, PerUserLimit: 1}, true},
+		{"No stock", Drop{Product: "pink-hoody", StartsAt: start, PerUserLimit: 1}, true},
+		{"No per-user limit", Drop{Product: "pink-hoody", StartsAt: start, Stock: 50}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.drop.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
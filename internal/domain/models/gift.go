@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+const MaxGiftMessageLength = 255
+
+var (
+	ErrGiftToSelf         = errors.New("cannot send a gift to yourself")
+	ErrGiftMessageTooLong = errors.New("gift message is too long")
+)
+
+// Gift marks a purchase paid by one user for another. The items go to the
+// recipient's inventory.
+type Gift struct {
+	RecipientID int
+	Recipient   string
+	Message     *string
+	Anonymous   bool
+}
+
+// Validate checks the gift before the purchase is made.
+func (g *Gift) Validate(senderID int) error {
+	if g.RecipientID == senderID {
+		return ErrGiftToSelf
+	}
+
+	if g.Message != nil && utf8.RuneCountInString(*g.Message) > MaxGiftMessageLength {
+		return ErrGiftMessageTooLong
+	}
+
+	return nil
+}
+
+// GiftRecord is a gift as it appears in a user's history.
+type GiftRecord struct {
+	OrderID   int
+	Sender    string
+	Recipient string
+	Item      string
+	Quantity  int
+	Message   *string
+	Anonymous bool
+	CreatedAt time.Time
+}
+
+// VisibleSender returns the sender's name as the recipient may see it: nil
+// for anonymous gifts.
+func (r *GiftRecord) VisibleSender() *string {
+	if r.Anonymous {
+		return nil
+	}
+	return &r.Sender
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGiftValidate(t *testing.T) {
+	short := "С днем рождения!"
+	long := strings.Repeat("я", MaxGiftMessageLength+1)
+
+	tests := []struct {
+		name     string
+		gift     Gift
+		expected error
+	}{
+		{"Gift without a message", Gift{RecipientID: 2}, nil},
+		{"Gift with a message", Gift{RecipientID: 2, Message: &short}, nil},
+		{"Gift to yourself", Gift{RecipientID: 1}, ErrGiftToSelf},
+		{"Message too long", Gift{RecipientID: 2, Message: &long}, ErrGiftMessageTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.gift.Validate(1))
+		})
+	}
+}
+
+func TestGiftRecordVisibleSender(t *testing.T) {
+	signed := GiftRecord{Sender: "alice"}
+	assert.Equal(t, "alice", *signed.VisibleSender())
+
+	anonymous := GiftRecord{Sender: "alice", Anonymous: true}
+	assert.Nil(t, anonymous.VisibleSender(), "Anonymous gifts hide the sender")
+}
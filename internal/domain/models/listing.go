@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type ListingStatus string
+
+const (
+	ListingActive    ListingStatus = "active"
+	ListingSold      ListingStatus = "sold"
+	ListingCancelled ListingStatus = "cancelled"
+)
+
+var (
+	ErrInvalidListing   = errors.New("listing quantity and price must be positive")
+	ErrListingNotActive = errors.New("listing is no longer active")
+	ErrOwnListing       = errors.New("cannot buy from your own listing")
+)
+
+// Listing is an offer on the internal marketplace to sell items from the
+// seller's inventory. Listed units are taken out of the inventory while the
+// listing is active, so they cannot be sold twice.
+type Listing struct {
+	ID        int
+	SellerID  int
+	Seller    string
+	Item      string
+	Quantity  int
+	Remaining int
+	Price     int
+	Status    ListingStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks a new listing before it is stored.
+func (l *Listing) Validate() error {
+	if l.Quantity <= 0 || l.Price <= 0 {
+		return ErrInvalidListing
+	}
+	return nil
+}
+
+// Trade is a completed marketplace purchase.
+type Trade struct {
+	ID        int
+	ListingID int
+	BuyerID   int
+	Seller    string
+	Item      string
+	Quantity  int
+	Total     int
+	Fee       int
+	CreatedAt time.Time
+}
+
+// Proceeds is what the seller receives once the fee is burned.
+func (t *Trade) Proceeds() int {
+	return t.Total - t.Fee
+}
+
+// MarketFee returns the part of a trade total that is burned as the
+// marketplace fee, rounded down.
+func MarketFee(total, percent int) int {
+	if percent <= 0 {
+		return 0
+	}
+	if percent >= 100 {
+		return total
+	}
+	return total * percent / 100
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListingValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		listing  Listing
+		expected error
+	}{
+		{"Valid listing", Listing{Quantity: 1, Price: 30}, nil},
+		{"Zero quantity", Listing{Quantity: 0, Price: 30}, ErrInvalidListing},
+		{"Free item", Listing{Quantity: 1, Price: 0}, ErrInvalidListing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.listing.Validate())
+		})
+	}
+}
+
+func TestMarketFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		percent  int
+		expected int
+	}{
+		{"No fee", 100, 0, 0},
+		{"Five percent", 100, 5, 5},
+		{"Rounded down", 30, 5, 1},
+		{"Small trade", 10, 5, 0},
+		{"Capped at total", 100, 150, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MarketFee(tt.total, tt.percent))
+		})
+	}
+}
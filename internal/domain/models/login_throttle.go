@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+	ErrUnknownThrottleKind  = errors.New("unknown login throttle kind")
+)
+
+// LoginThrottledError is returned instead of checking the password while
+// failed attempts block the username or the client address.
+type LoginThrottledError struct {
+	RetryAt time.Time
+}
+
+func (e *LoginThrottledError) Error() string {
+	return ErrTooManyLoginAttempts.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrTooManyLoginAttempts
+}
+
+// ThrottleKind says what failed login attempts are counted against.
+type ThrottleKind string
+
+const (
+	ThrottleUsername ThrottleKind = "username"
+	ThrottleIP       ThrottleKind = "ip"
+)
+
+// ParseThrottleKind checks that name is a known throttle kind.
+func ParseThrottleKind(name string) (ThrottleKind, error) {
+	switch kind := ThrottleKind(name); kind {
+	case ThrottleUsername, ThrottleIP:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownThrottleKind, name)
+	}
+}
+
+// ThrottleKey identifies what failed attempts are counted for: a username or
+// a client address.
+type ThrottleKey struct {
+	Kind ThrottleKind
+	Key  string
+}
+
+// LoginThrottlePolicy slows down password guessing. The first FreeAttempts
+// failures cost nothing; after that every failure doubles the wait before the
+// next attempt, starting at BackoffBase and capped at BackoffMax. Reaching
+// the failure limit locks the username or address out for Lockout. Failures
+// older than Lockout are forgotten.
+type LoginThrottlePolicy struct {
+	FreeAttempts     int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	MaxFailures      int
+	MaxFailuresPerIP int
+	Lockout          time.Duration
+}
+
+// Limit returns the number of failures that locks out the kind.
+func (p LoginThrottlePolicy) Limit(kind ThrottleKind) int {
+	if kind == ThrottleIP {
+		return p.MaxFailuresPerIP
+	}
+	return p.MaxFailures
+}
+
+// RetryAt returns when the next attempt is allowed after the failures. The
+// zero time means at once.
+func (p LoginThrottlePolicy) RetryAt(f *LoginFailures) time.Time {
+	if f.LockedUntil != nil {
+		return *f.LockedUntil
+	}
+
+	excess := f.Failures - p.FreeAttempts
+	if excess <= 0 {
+		return time.Time{}
+	}
+
+	delay := p.BackoffMax
+	if excess <= 30 && p.BackoffBase<<(excess-1) < p.BackoffMax {
+		delay = p.BackoffBase << (excess - 1)
+	}
+	return f.LastFailureAt.Add(delay)
+}
+
+// LoginFailures counts recent failed logins for a username or an address.
+// BlockedUntil is set while further attempts are refused.
+type LoginFailures struct {
+	Kind          ThrottleKind
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+	BlockedUntil  *time.Time
+}
+
+// LoginAttempt is an attempt counted as a failure before the credentials are
+// checked, so that concurrent guesses cannot slip past the throttle. Previous
+// holds the failures of each key as they were before the attempt, to restore
+// them if the credentials turn out to be right.
+type LoginAttempt struct {
+	At       time.Time
+	Previous []LoginFailures
+	Failures []LoginFailures
+}
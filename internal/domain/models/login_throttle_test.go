@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottlePolicyRetryAt(t *testing.T) {
+	policy := LoginThrottlePolicy{
+		FreeAttempts: 3,
+		BackoffBase:  time.Second,
+		BackoffMax:   time.Minute,
+		Lockout:      15 * time.Minute,
+	}
+	last := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	lockedUntil := last.Add(15 * time.Minute)
+
+	tests := []struct {
+		name        string
+		failures    int
+		lockedUntil *time.Time
+		want        time.Time
+	}{
+		{name: "free attempts", failures: 3, want: time.Time{}},
+		{name: "first backoff", failures: 4, want: last.Add(time.Second)},
+		{name: "doubles", failures: 6, want: last.Add(4 * time.Second)},
+		{name: "capped", failures: 20, want: last.Add(time.Minute)},
+		{name: "no overflow", failures: 100, want: last.Add(time.Minute)},
+		{name: "locked out", failures: 10, lockedUntil: &lockedUntil, want: lockedUntil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := policy.RetryAt(&LoginFailures{Failures: tt.failures, LastFailureAt: last, LockedUntil: tt.lockedUntil})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseThrottleKind(t *testing.T) {
+	kind, err := ParseThrottleKind("ip")
+	assert.NoError(t, err)
+	assert.Equal(t, ThrottleIP, kind)
+
+	_, err = ParseThrottleKind("email")
+	assert.ErrorIs(t, err, ErrUnknownThrottleKind)
+}
+
+func TestLoginThrottledError(t *testing.T) {
+	var err error = &LoginThrottledError{RetryAt: time.Now()}
+	assert.ErrorIs(t, err, ErrTooManyLoginAttempts)
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+type OrderStatus string
+
+const (
+	OrderStatusPlaced         OrderStatus = "placed"
+	OrderStatusReadyForPickup OrderStatus = "ready_for_pickup"
+	OrderStatusDelivered      OrderStatus = "delivered"
+	OrderStatusCancelled      OrderStatus = "cancelled"
+)
+
+// orderTransitions lists the statuses every order status may move to.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPlaced:         {OrderStatusReadyForPickup, OrderStatusCancelled},
+	OrderStatusReadyForPickup: {OrderStatusDelivered, OrderStatusCancelled},
+}
+
+// Valid reports whether s is a known order status.
+func (s OrderStatus) Valid() bool {
+	switch s {
+	case OrderStatusPlaced, OrderStatusReadyForPickup, OrderStatusDelivered, OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether an order in status s may be moved to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type OrderItem struct {
+	ItemName  string
+	Quantity  int
+	UnitPrice int
+	// Bundle is set for components of a bundle, which are priced as a whole
+	// by the order total and carry no unit price of their own.
+	Bundle *string
+}
+
+type Order struct {
+	ID          int
+	UserID      int
+	Status      OrderStatus
+	Total       int
+	Discount    int
+	Items       []OrderItem
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeliveredAt *time.Time
+	Gift        *Gift
+}
+
+// CanCancel reports whether the order may be cancelled at now. Orders that
+// have not been handed over can always be cancelled; delivered orders only
+// while the return window is open.
+func (o *Order) CanCancel(now time.Time, returnWindow time.Duration) bool {
+	if o.Status.CanTransitionTo(OrderStatusCancelled) {
+		return true
+	}
+
+	if o.Status != OrderStatusDelivered || o.DeliveredAt == nil || returnWindow <= 0 {
+		return false
+	}
+
+	return now.Before(o.DeliveredAt.Add(returnWindow))
+}
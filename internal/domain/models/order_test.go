@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     OrderStatus
+		to       OrderStatus
+		expected bool
+	}{
+		{"Placed to ready for pickup", OrderStatusPlaced, OrderStatusReadyForPickup, true},
+		{"Ready for pickup to delivered", OrderStatusReadyForPickup, OrderStatusDelivered, true},
+		{"Placed to cancelled", OrderStatusPlaced, OrderStatusCancelled, true},
+		{"Ready for pickup to cancelled", OrderStatusReadyForPickup, OrderStatusCancelled, true},
+		{"Placed straight to delivered", OrderStatusPlaced, OrderStatusDelivered, false},
+		{"Delivered back to placed", OrderStatusDelivered, OrderStatusPlaced, false},
+		{"Cancelled to ready for pickup", OrderStatusCancelled, OrderStatusReadyForPickup, false},
+		{"Same status", OrderStatusPlaced, OrderStatusPlaced, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestOrderStatusValid(t *testing.T) {
+	assert.True(t, OrderStatusPlaced.Valid())
+	assert.True(t, OrderStatusCancelled.Valid())
+	assert.False(t, OrderStatus("lost").Valid())
+}
+
+func TestOrderCanCancel(t *testing.T) {
+	now := time.Now()
+	deliveredAt := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name         string
+		order        Order
+		returnWindow time.Duration
+		expected     bool
+	}{
+		{"Placed order", Order{Status: OrderStatusPlaced}, 0, true},
+		{"Ready for pickup", Order{Status: OrderStatusReadyForPickup}, 0, true},
+		{"Delivered inside return window", Order{Status: OrderStatusDelivered, DeliveredAt: &deliveredAt}, 24 * time.Hour, true},
+		{"Delivered after return window", Order{Status: OrderStatusDelivered, DeliveredAt: &deliveredAt}, time.Hour, false},
+		{"Delivered without return window", Order{Status: OrderStatusDelivered, DeliveredAt: &deliveredAt}, 0, false},
+		{"Already cancelled", Order{Status: OrderStatusCancelled}, 24 * time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.order.CanCancel(now, tt.returnWindow))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// PasswordReset is a one-time token that lets the user set a new password
+// without knowing the current one. It is issued by an administrator and
+// delivered to the user; the server keeps only its hash.
+type PasswordReset struct {
+	Username  string
+	Token     string
+	ExpiresAt time.Time
+}
+
+// NewPasswordResetToken returns a random reset token and the hash to store.
+func NewPasswordResetToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashPasswordResetToken(token), nil
+}
+
+// HashPasswordResetToken returns the hex SHA-256 of the token.
+func HashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *PasswordReset) Subject() string {
+	return "Password reset"
+}
+
+func (r *PasswordReset) Body() string {
+	return fmt.Sprintf("An administrator has started a password reset for %s. "+
+		"Use this token to set a new password before %s UTC: %s",
+		r.Username, r.ExpiresAt.Format(time.DateTime), r.Token)
+}
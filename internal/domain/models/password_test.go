@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	token, hash, err := NewPasswordResetToken()
+	assert.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashPasswordResetToken(token))
+
+	other, _, err := NewPasswordResetToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestPasswordResetMessage(t *testing.T) {
+	reset := PasswordReset{
+		Username:  "alice",
+		Token:     "reset-token",
+		ExpiresAt: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+
+	assert.Equal(t, "Password reset", reset.Subject())
+	assert.Contains(t, reset.Body(), "alice")
+	assert.Contains(t, reset.Body(), "reset-token")
+	assert.Contains(t, reset.Body(), "2026-01-02 15:04:05 UTC")
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// PricePoint is one version of a product price, effective from the given moment.
+type PricePoint struct {
+	Price         int
+	EffectiveFrom time.Time
+	CreatedAt     time.Time
+}
+
+// CurrentPrice returns the index of the price in effect at now within a
+// timeline sorted by EffectiveFrom, or -1 if none has taken effect yet.
+func CurrentPrice(timeline []PricePoint, now time.Time) int {
+	current := -1
+	for i, point := range timeline {
+		if point.EffectiveFrom.After(now) {
+			break
+		}
+		current = i
+	}
+	return current
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentPrice(t *testing.T) {
+	now := time.Now()
+
+	timeline := []PricePoint{
+		{Price: 300, EffectiveFrom: now.Add(-48 * time.Hour)},
+		{Price: 250, EffectiveFrom: now.Add(-time.Hour)},
+		{Price: 350, EffectiveFrom: now.Add(24 * time.Hour)},
+	}
+
+	assert.Equal(t, 1, CurrentPrice(timeline, now), "The latest price that already took effect is current")
+	assert.Equal(t, 2, CurrentPrice(timeline, now.Add(25*time.Hour)), "Scheduled price becomes current once it takes effect")
+	assert.Equal(t, -1, CurrentPrice(timeline, now.Add(-72*time.Hour)), "No price before the first version")
+	assert.Equal(t, -1, CurrentPrice(nil, now))
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type DiscountType string
+
+const (
+	DiscountPercent DiscountType = "percent"
+	DiscountFixed   DiscountType = "fixed"
+)
+
+var (
+	ErrPromoNotActive      = errors.New("promo code is not active")
+	ErrPromoNotApplicable  = errors.New("promo code does not apply to this item")
+	ErrPromoUsageExhausted = errors.New("promo code usage limit reached")
+)
+
+type PromoCode struct {
+	ID             int
+	Code           string
+	DiscountType   DiscountType
+	DiscountValue  int
+	Product        *string
+	ValidFrom      time.Time
+	ValidUntil     *time.Time
+	MaxUses        *int
+	MaxUsesPerUser *int
+	CreatedAt      time.Time
+}
+
+// PromoCodeStats is a promo code together with its redemption statistics.
+type PromoCodeStats struct {
+	PromoCode
+	Redemptions   int
+	UniqueUsers   int
+	TotalDiscount int
+}
+
+// Validate checks the promo code definition before it is stored.
+func (p *PromoCode) Validate() error {
+	if p.Code == "" {
+		return errors.New("promo code is required")
+	}
+
+	switch p.DiscountType {
+	case DiscountPercent:
+		if p.DiscountValue <= 0 || p.DiscountValue > 100 {
+			return errors.New("percentage discount must be between 1 and 100")
+		}
+	case DiscountFixed:
+		if p.DiscountValue <= 0 {
+			return errors.New("fixed discount must be positive")
+		}
+	default:
+		return errors.New("unknown discount type")
+	}
+
+	if p.ValidUntil != nil && !p.ValidUntil.After(p.ValidFrom) {
+		return errors.New("validity window ends before it starts")
+	}
+
+	if (p.MaxUses != nil && *p.MaxUses <= 0) || (p.MaxUsesPerUser != nil && *p.MaxUsesPerUser <= 0) {
+		return errors.New("usage caps must be positive")
+	}
+
+	return nil
+}
+
+// CheckApplicable reports why the code cannot be applied to item at now, if it cannot.
+func (p *PromoCode) CheckApplicable(now time.Time, item string) error {
+	if now.Before(p.ValidFrom) || (p.ValidUntil != nil && !now.Before(*p.ValidUntil)) {
+		return ErrPromoNotActive
+	}
+
+	if p.Product != nil && *p.Product != item {
+		return ErrPromoNotApplicable
+	}
+
+	return nil
+}
+
+// Discount returns the number of coins taken off total. It never exceeds total.
+func (p *PromoCode) Discount(total int) int {
+	var discount int
+	switch p.DiscountType {
+	case DiscountPercent:
+		discount = total * p.DiscountValue / 100
+	case DiscountFixed:
+		discount = p.DiscountValue
+	}
+
+	if discount > total {
+		return total
+	}
+	return discount
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoCodeDiscount(t *testing.T) {
+	tests := []struct {
+		name     string
+		promo    PromoCode
+		total    int
+		expected int
+	}{
+		{"Percentage discount", PromoCode{DiscountType: DiscountPercent, DiscountValue: 25}, 300, 75},
+		{"Percentage discount rounds down", PromoCode{DiscountType: DiscountPercent, DiscountValue: 15}, 10, 1},
+		{"Fixed discount", PromoCode{DiscountType: DiscountFixed, DiscountValue: 50}, 300, 50},
+		{"Fixed discount capped by total", PromoCode{DiscountType: DiscountFixed, DiscountValue: 50}, 20, 20},
+		{"Full discount", PromoCode{DiscountType: DiscountPercent, DiscountValue: 100}, 500, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.promo.Discount(tt.total))
+		})
+	}
+}
+
+func TestPromoCodeCheckApplicable(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	hoody := "hoody"
+
+	tests := []struct {
+		name     string
+		promo    PromoCode
+		item     string
+		expected error
+	}{
+		{"Catalog-wide code", PromoCode{ValidFrom: past}, "pen", nil},
+		{"Product code for that product", PromoCode{ValidFrom: past, Product: &hoody}, "hoody", nil},
+		{"Product code for another product", PromoCode{ValidFrom: past, Product: &hoody}, "pen", ErrPromoNotApplicable},
+		{"Not started yet", PromoCode{ValidFrom: future}, "pen", ErrPromoNotActive},
+		{"Already expired", PromoCode{ValidFrom: past.Add(-time.Hour), ValidUntil: &past}, "pen", ErrPromoNotActive},
+		{"Inside the window", PromoCode{ValidFrom: past, ValidUntil: &future}, "pen", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.promo.CheckApplicable(now, tt.item))
+		})
+	}
+}
+
+func TestPromoCodeValidate(t *testing.T) {
+	zero := 0
+
+	assert.NoError(t, (&PromoCode{Code: "ANNIVERSARY", DiscountType: DiscountPercent, DiscountValue: 20}).Validate())
+	assert.Error(t, (&PromoCode{Code: "", DiscountType: DiscountFixed, DiscountValue: 20}).Validate())
+	assert.Error(t, (&PromoCode{Code: "TOO-MUCH", DiscountType: DiscountPercent, DiscountValue: 120}).Validate())
+	assert.Error(t, (&PromoCode{Code: "WEIRD", DiscountType: "bogus", DiscountValue: 20}).Validate())
+	assert.Error(t, (&PromoCode{Code: "NO-USES", DiscountType: DiscountFixed, DiscountValue: 20, MaxUses: &zero}).Validate())
+}
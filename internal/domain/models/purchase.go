@@ -0,0 +1,19 @@
+package models
+
+// Purchase describes a single buy order for one catalog item. UserID pays;
+// the items go to the gift recipient if there is one.
+type Purchase struct {
+	UserID    int
+	Item      string
+	Quantity  int
+	PromoCode string
+	Gift      *Gift
+}
+
+// HolderID returns the user whose inventory receives the items.
+func (p *Purchase) HolderID() int {
+	if p.Gift != nil {
+		return p.Gift.RecipientID
+	}
+	return p.UserID
+}
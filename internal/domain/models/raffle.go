@@ -0,0 +1,164 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type RaffleStatus string
+
+// RaffleOpen is the stored state of a raffle until it is drawn or cancelled;
+// Status refines it into open or closed once ticket sales have ended.
+const (
+	RaffleOpen      RaffleStatus = "open"
+	RaffleClosed    RaffleStatus = "closed"
+	RaffleDrawn     RaffleStatus = "drawn"
+	RaffleCancelled RaffleStatus = "cancelled"
+)
+
+const raffleSeedSize = 32
+
+var (
+	ErrRaffleClosed       = errors.New("raffle ticket sales are closed")
+	ErrTicketLimitReached = errors.New("per-user ticket limit for this raffle reached")
+)
+
+// Raffle sells tickets for coins and draws Prizes winning tickets at DrawAt;
+// each winning ticket gets one unit of the product. The draw is verifiable:
+// SHA-256 of the seed is published as Commitment when the raffle is created,
+// and the seed itself is revealed after the draw so anyone can recompute the
+// winners from it and the final tickets with DrawWinners.
+type Raffle struct {
+	ID                int
+	Product           string
+	Prizes            int
+	TicketPrice       int
+	MaxTicketsPerUser *int
+	DrawAt            time.Time
+	Commitment        string
+	// Seed is only known once the raffle is no longer open.
+	Seed        *string
+	State       RaffleStatus
+	TicketsSold int
+	DrawnAt     *time.Time
+	CreatedAt   time.Time
+}
+
+// RaffleTicket is a ticket as it appears in the public draw record.
+type RaffleTicket struct {
+	Number    int
+	Holder    string
+	PrizeRank *int
+}
+
+// Validate checks the raffle definition before it is stored.
+func (r *Raffle) Validate() error {
+	if r.Product == "" {
+		return errors.New("product is required")
+	}
+
+	if r.Prizes <= 0 {
+		return errors.New("number of prizes must be positive")
+	}
+
+	if r.TicketPrice <= 0 {
+		return errors.New("ticket price must be positive")
+	}
+
+	if r.MaxTicketsPerUser != nil && *r.MaxTicketsPerUser <= 0 {
+		return errors.New("per-user ticket limit must be positive")
+	}
+
+	if r.DrawAt.IsZero() {
+		return errors.New("draw time is required")
+	}
+
+	return nil
+}
+
+// Status reports the state of the raffle at now. An open raffle whose draw
+// time has passed but that has not been drawn yet is reported as closed.
+func (r *Raffle) Status(now time.Time) RaffleStatus {
+	if r.State == RaffleOpen && !now.Before(r.DrawAt) {
+		return RaffleClosed
+	}
+	return r.State
+}
+
+// CheckTickets reports why a user who already holds held tickets cannot buy
+// count more at now, if they cannot.
+func (r *Raffle) CheckTickets(now time.Time, held, count int) error {
+	if r.Status(now) != RaffleOpen {
+		return ErrRaffleClosed
+	}
+
+	if r.MaxTicketsPerUser != nil && held+count > *r.MaxTicketsPerUser {
+		return ErrTicketLimitReached
+	}
+
+	return nil
+}
+
+// NewRaffleSeed returns a random hex-encoded seed and its commitment.
+func NewRaffleSeed() (seed, commitment string, err error) {
+	b := make([]byte, raffleSeedSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate raffle seed: %w", err)
+	}
+
+	seed = hex.EncodeToString(b)
+	commitment, err = RaffleCommitment(seed)
+	return seed, commitment, err
+}
+
+// RaffleCommitment returns the hex-encoded SHA-256 of the decoded seed.
+func RaffleCommitment(seed string) (string, error) {
+	b, err := hex.DecodeString(seed)
+	if err != nil {
+		return "", fmt.Errorf("invalid raffle seed: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DrawWinners picks the winning ticket numbers, in prize order, among the
+// final tickets of a raffle ordered by number. The draw depends on both the
+// seed and the tickets, so knowing the seed while tickets are on sale is not
+// enough to predict the winners. The key is SHA-256(seed || d), where d is
+// the SHA-256 of one "<number> <holder>\n" line per ticket. Then a partial
+// Fisher-Yates shuffle of the tickets runs: in round i (from 0) the i-th
+// ticket is swapped with the one at i + x mod (len(tickets) - i), where x is
+// the first 8 bytes, big-endian, of SHA-256(key || uint32be(i)). If there
+// are fewer tickets than prizes, every ticket wins.
+func DrawWinners(seed string, tickets []RaffleTicket, prizes int) ([]int, error) {
+	b, err := hex.DecodeString(seed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raffle seed: %w", err)
+	}
+
+	digest := sha256.New()
+	numbers := make([]int, len(tickets))
+	for i, ticket := range tickets {
+		fmt.Fprintf(digest, "%d %s\n", ticket.Number, ticket.Holder)
+		numbers[i] = ticket.Number
+	}
+	key := sha256.Sum256(append(b, digest.Sum(nil)...))
+
+	winners := min(prizes, len(numbers))
+	round := make([]byte, len(key)+4)
+	copy(round, key[:])
+	for i := 0; i < winners; i++ {
+		binary.BigEndian.PutUint32(round[len(key):], uint32(i))
+		sum := sha256.Sum256(round)
+		j := i + int(binary.BigEndian.Uint64(sum[:8])%uint64(len(numbers)-i))
+		numbers[i], numbers[j] = numbers[j], numbers[i]
+	}
+
+	return numbers[:winners], nil
+}
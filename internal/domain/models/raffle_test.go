@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaffleSeedCommitment(t *testing.T) {
+	seed, commitment, err := NewRaffleSeed()
+	assert.NoError(t, err)
+	assert.Len(t, seed, 64)
+
+	recomputed, err := RaffleCommitment(seed)
+	assert.NoError(t, err)
+	assert.Equal(t, commitment, recomputed, "The revealed seed must match the published commitment")
+
+	other, _, err := NewRaffleSeed()
+	assert.NoError(t, err)
+	assert.NotEqual(t, seed, other)
+
+	_, err = RaffleCommitment("not hex")
+	assert.Error(t, err)
+}
+
+func TestDrawWinners(t *testing.T) {
+	seed := "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+	tickets := make([]RaffleTicket, 100)
+	for i := range tickets {
+		tickets[i] = RaffleTicket{Number: i + 1, Holder: fmt.Sprintf("user%d", i%7)}
+	}
+
+	winners, err := DrawWinners(seed, tickets, 3)
+	assert.NoError(t, err)
+	assert.Len(t, winners, 3)
+
+	again, err := DrawWinners(seed, tickets, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, winners, again, "The draw is reproducible from the seed and the tickets")
+
+	seen := map[int]bool{}
+	for _, n := range winners {
+		assert.True(t, n >= 1 && n <= 100)
+		assert.False(t, seen[n], "A ticket cannot win twice")
+		seen[n] = true
+	}
+
+	// The last ticket changes hands, as if another user had bought it.
+	changed := append([]RaffleTicket(nil), tickets...)
+	changed[99].Holder = "latecomer"
+	other, err := DrawWinners(seed, changed, 3)
+	assert.NoError(t, err)
+	assert.NotEqual(t, winners, other, "The seed alone does not determine the winners")
+
+	all, err := DrawWinners(seed, tickets[:2], 5)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int{1, 2}, all, "With fewer tickets than prizes every ticket wins")
+
+	none, err := DrawWinners(seed, nil, 5)
+	assert.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestRaffleCheckTickets(t *testing.T) {
+	drawAt := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+	limit := 3
+
+	tests := []struct {
+		name     string
+		state    RaffleStatus
+		now      time.Time
+		held     int
+		count    int
+		expected error
+	}{
+		{"Tickets on sale", RaffleOpen, drawAt.Add(-time.Hour), 0, 2, nil},
+		{"Up to the limit", RaffleOpen, drawAt.Add(-time.Hour), 1, 2, nil},
+		{"Over the limit", RaffleOpen, drawAt.Add(-time.Hour), 2, 2, ErrTicketLimitReached},
+		{"Sales closed at draw time", RaffleOpen, drawAt, 0, 1, ErrRaffleClosed},
+		{"Already drawn", RaffleDrawn, drawAt.Add(-time.Hour), 0, 1, ErrRaffleClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Raffle{DrawAt: drawAt, State: tt.state, MaxTicketsPerUser: &limit}
+			assert.Equal(t, tt.expected, r.CheckTickets(tt.now, tt.held, tt.count))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+// Role grants access to a group of operations. Every user gets RoleEmployee
+// on registration; the other roles are granted by an hr-admin.
+type Role string
+
+const (
+	RoleEmployee    Role = "employee"
+	RoleShopManager Role = "shop-manager"
+	RoleHRAdmin     Role = "hr-admin"
+	RoleAuditor     Role = "auditor"
+)
+
+// Roles lists every known role.
+var Roles = []Role{RoleEmployee, RoleShopManager, RoleHRAdmin, RoleAuditor}
+
+// ParseRole returns the role with the given name.
+func ParseRole(name string) (Role, error) {
+	for _, role := range Roles {
+		if string(role) == name {
+			return role, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownRole, name)
+}
+
+// UserRoles is the set of roles held by one user.
+type UserRoles struct {
+	Username string
+	Roles    []Role
+}
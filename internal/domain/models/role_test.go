@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Role
+		wantErr error
+	}{
+		{"Employee", "employee", RoleEmployee, nil},
+		{"Shop manager", "shop-manager", RoleShopManager, nil},
+		{"HR admin", "hr-admin", RoleHRAdmin, nil},
+		{"Auditor", "auditor", RoleAuditor, nil},
+		{"Unknown role", "root", "", ErrUnknownRole},
+		{"Wrong case", "Auditor", "", ErrUnknownRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := ParseRole(tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, role)
+		})
+	}
+}
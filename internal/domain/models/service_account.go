@@ -0,0 +1,147 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAPIKey             = errors.New("invalid API key")
+	ErrUnknownScope              = errors.New("unknown scope")
+	ErrInvalidServiceAccountName = errors.New("service account name must be 3 to 32 lowercase letters, digits or '-'")
+)
+
+// Scope limits what an API key may do.
+type Scope string
+
+const (
+	ScopeCoinsGrant   Scope = "coins:grant"
+	ScopeCoinsSend    Scope = "coins:send"
+	ScopeCatalogWrite Scope = "catalog:write"
+)
+
+// Scopes lists every known scope.
+var Scopes = []Scope{ScopeCoinsGrant, ScopeCoinsSend, ScopeCatalogWrite}
+
+// ParseScopes checks that every name is a known scope. At least one scope is
+// required, and duplicates are dropped.
+func ParseScopes(names []string) ([]Scope, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrUnknownScope)
+	}
+
+	scopes := make([]Scope, 0, len(names))
+	seen := make(map[Scope]struct{}, len(names))
+	for _, name := range names {
+		scope, ok := findScope(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownScope, name)
+		}
+		if _, dup := seen[scope]; dup {
+			continue
+		}
+		seen[scope] = struct{}{}
+		scopes = append(scopes, scope)
+	}
+
+	return scopes, nil
+}
+
+func findScope(name string) (Scope, bool) {
+	for _, scope := range Scopes {
+		if string(scope) == name {
+			return scope, true
+		}
+	}
+	return "", false
+}
+
+var serviceAccountNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,31}$`)
+
+// ValidateServiceAccountName checks the name of a new service account.
+func ValidateServiceAccountName(name string) error {
+	if !serviceAccountNameRe.MatchString(name) {
+		return ErrInvalidServiceAccountName
+	}
+	return nil
+}
+
+// ServiceAccount is a non-human client, such as the HR system or the chat
+// bot, that authenticates with API keys instead of a password.
+type ServiceAccount struct {
+	Name        string
+	Description string
+	CreatedBy   string
+	CreatedAt   time.Time
+	Keys        []APIKey
+}
+
+// APIKey is what the server keeps about a key of a service account: its id,
+// which is part of the key, and only the hash of the whole key.
+type APIKey struct {
+	ID         string
+	Account    string
+	Hash       string
+	Scopes     []Scope
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// Active reports whether the key can be used at the given moment.
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// APIPrincipal is who a request authenticated with an API key acts as. When
+// the service account acts on behalf of a user, UserID and Roles are theirs.
+type APIPrincipal struct {
+	Account string
+	KeyID   string
+	Scopes  []Scope
+	UserID  int
+	Roles   []Role
+}
+
+const apiKeyPrefix = "msk"
+
+// NewAPIKey generates a key of the form msk_<id>_<secret> and returns it with
+// its id and hash. The key itself is shown to the caller once and not stored.
+func NewAPIKey() (key, id, hash string, err error) {
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	id = hex.EncodeToString(idBytes)
+	key = apiKeyPrefix + "_" + id + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return key, id, HashAPIKey(key), nil
+}
+
+// ParseAPIKey returns the id of the key, without checking the secret.
+func ParseAPIKey(key string) (string, error) {
+	parts := strings.SplitN(key, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || len(parts[1]) != 16 || parts[2] == "" {
+		return "", ErrInvalidAPIKey
+	}
+	return parts[1], nil
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 of the key. Keys are random and
+// long, so a fast hash is enough.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
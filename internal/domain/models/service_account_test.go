@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, id, hash, err := NewAPIKey()
+	assert.NoError(t, err)
+	assert.Len(t, id, 16)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashAPIKey(key))
+
+	parsed, err := ParseAPIKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	other, otherID, _, err := NewAPIKey()
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+	assert.NotEqual(t, id, otherID)
+}
+
+func TestParseAPIKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"empty", ""},
+		{"no prefix", "0123456789abcdef_secret"},
+		{"wrong prefix", "key_0123456789abcdef_secret"},
+		{"short id", "msk_0123_secret"},
+		{"no secret", "msk_0123456789abcdef_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAPIKey(tt.key)
+			assert.ErrorIs(t, err, ErrInvalidAPIKey)
+		})
+	}
+}
+
+func TestParseScopes(t *testing.T) {
+	scopes, err := ParseScopes([]string{"coins:send", "catalog:write", "coins:send"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Scope{ScopeCoinsSend, ScopeCatalogWrite}, scopes)
+
+	_, err = ParseScopes(nil)
+	assert.ErrorIs(t, err, ErrUnknownScope)
+
+	_, err = ParseScopes([]string{"coins:steal"})
+	assert.ErrorIs(t, err, ErrUnknownScope)
+}
+
+func TestAPIKeyActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	assert.True(t, (&APIKey{}).Active(now))
+	assert.True(t, (&APIKey{ExpiresAt: &future}).Active(now))
+	assert.False(t, (&APIKey{ExpiresAt: &past}).Active(now))
+	assert.False(t, (&APIKey{RevokedAt: &past}).Active(now))
+}
+
+func TestValidateServiceAccountName(t *testing.T) {
+	assert.NoError(t, ValidateServiceAccountName("hr-system"))
+	assert.NoError(t, ValidateServiceAccountName("chat-bot2"))
+	assert.ErrorIs(t, ValidateServiceAccountName("HR"), ErrInvalidServiceAccountName)
+	assert.ErrorIs(t, ValidateServiceAccountName("-bot"), ErrInvalidServiceAccountName)
+	assert.ErrorIs(t, ValidateServiceAccountName("chat bot"), ErrInvalidServiceAccountName)
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrRefreshTokenReused = errors.New("refresh token has already been used, the session is revoked")
+
+// SessionPolicy sets how long the tokens of a session stay valid. Access
+// tokens are short-lived; refresh tokens are exchanged for a new pair and
+// each can be used only once.
+type SessionPolicy struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// TokenGrant is what the server keeps about one access and refresh token
+// pair: the access token id, so it can be revoked, and only the hash of the
+// refresh token.
+type TokenGrant struct {
+	SessionID        string
+	AccessTokenID    string
+	AccessExpiresAt  time.Time
+	RefreshTokenHash string
+	RefreshExpiresAt time.Time
+}
+
+// AuthTokens is the token pair handed out to the client.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// RevokedToken is an access token that must be rejected until it expires.
+type RevokedToken struct {
+	ID        string
+	ExpiresAt time.Time
+}
+
+// NewRefreshToken returns a random refresh token and the hash to store.
+func NewRefreshToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the hex SHA-256 of the token.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// SigningKey is a private key used to sign access tokens. The newest key
+// signs; older keys are kept until RetiresAt so that tokens they signed can
+// still be verified.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey string
+	CreatedAt  time.Time
+	RetiresAt  *time.Time
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSSODisabled           = errors.New("single sign-on is not configured")
+	ErrPasswordLoginDisabled = errors.New("password login is disabled, sign in with single sign-on")
+	ErrSSOIdentityRejected   = errors.New("identity provider account cannot be used here")
+	ErrInvalidSSOPolicy      = errors.New("invalid single sign-on policy")
+)
+
+// SSOLogin is a sign-in started with the identity provider and not finished
+// yet. The state sent to the provider is stored only as a hash; the nonce and
+// code verifier are needed again when the provider redirects back.
+type SSOLogin struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// NewSSOState returns a random state for the authorization request and the
+// hash to store.
+func NewSSOState() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate sso state: %w", err)
+	}
+
+	state := base64.RawURLEncoding.EncodeToString(raw)
+	return state, HashSSOState(state), nil
+}
+
+// HashSSOState returns the hex SHA-256 of the state.
+func HashSSOState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+// ExternalIdentity is a user as vouched for by the identity provider.
+type ExternalIdentity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+}
+
+// SSO username claims.
+const (
+	SSOClaimEmail             = "email"
+	SSOClaimPreferredUsername = "preferred_username"
+	SSOClaimSubject           = "sub"
+)
+
+// SSOPolicy decides which local user an identity provider account signs in
+// as. An account is remembered by issuer and subject once it has signed in;
+// the first time, the UsernameClaim names the local user. With EmailDomain
+// set, only emails of that domain are accepted and the part before the @ is
+// the username. LinkExisting lets the account take over a local user of that
+// name; Provision creates the user if there is none.
+type SSOPolicy struct {
+	UsernameClaim string
+	EmailDomain   string
+	LinkExisting  bool
+	Provision     bool
+}
+
+func (p SSOPolicy) Validate() error {
+	switch p.UsernameClaim {
+	case SSOClaimEmail, SSOClaimPreferredUsername, SSOClaimSubject:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown username claim %q", ErrInvalidSSOPolicy, p.UsernameClaim)
+	}
+}
+
+// Username returns the name of the local user for the identity. Emails are
+// only trusted once the provider has verified them.
+func (p SSOPolicy) Username(identity *ExternalIdentity) (string, error) {
+	switch p.UsernameClaim {
+	case SSOClaimPreferredUsername:
+		if identity.PreferredUsername == "" {
+			return "", fmt.Errorf("%w: no preferred_username claim", ErrSSOIdentityRejected)
+		}
+		return identity.PreferredUsername, nil
+	case SSOClaimSubject:
+		return identity.Subject, nil
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return "", fmt.Errorf("%w: no verified email", ErrSSOIdentityRejected)
+	}
+	if p.EmailDomain == "" {
+		return identity.Email, nil
+	}
+
+	local, domain, ok := strings.Cut(identity.Email, "@")
+	if !ok || local == "" || !strings.EqualFold(domain, p.EmailDomain) {
+		return "", fmt.Errorf("%w: email is not in the %s domain", ErrSSOIdentityRejected, p.EmailDomain)
+	}
+	return local, nil
+}
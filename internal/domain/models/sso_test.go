@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSOPolicyUsername(t *testing.T) {
+	alice := &ExternalIdentity{
+		Subject:           "mock|alice",
+		Email:             "alice@corp.example",
+		EmailVerified:     true,
+		PreferredUsername: "alice.s",
+	}
+	unverified := *alice
+	unverified.EmailVerified = false
+
+	tests := []struct {
+		name     string
+		policy   SSOPolicy
+		identity *ExternalIdentity
+		want     string
+		wantErr  error
+	}{
+		{name: "email", policy: SSOPolicy{UsernameClaim: SSOClaimEmail}, identity: alice, want: "alice@corp.example"},
+		{name: "email in domain", policy: SSOPolicy{UsernameClaim: SSOClaimEmail, EmailDomain: "CORP.example"}, identity: alice, want: "alice"},
+		{name: "email outside domain", policy: SSOPolicy{UsernameClaim: SSOClaimEmail, EmailDomain: "other.example"}, identity: alice, wantErr: ErrSSOIdentityRejected},
+		{name: "unverified email", policy: SSOPolicy{UsernameClaim: SSOClaimEmail}, identity: &unverified, wantErr: ErrSSOIdentityRejected},
+		{name: "preferred username", policy: SSOPolicy{UsernameClaim: SSOClaimPreferredUsername}, identity: alice, want: "alice.s"},
+		{name: "no preferred username", policy: SSOPolicy{UsernameClaim: SSOClaimPreferredUsername}, identity: &ExternalIdentity{Subject: "x"}, wantErr: ErrSSOIdentityRejected},
+		{name: "subject", policy: SSOPolicy{UsernameClaim: SSOClaimSubject}, identity: alice, want: "mock|alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.Username(tt.identity)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSSOPolicyValidate(t *testing.T) {
+	assert.NoError(t, SSOPolicy{UsernameClaim: SSOClaimEmail}.Validate())
+	assert.ErrorIs(t, SSOPolicy{UsernameClaim: "name"}.Validate(), ErrInvalidSSOPolicy)
+}
@@ -0,0 +1,13 @@
+package models
+
+// Transaction kinds stored in transactions.kind.
+const (
+	TransactionTransfer = "transfer"
+	TransactionPurchase = "purchase"
+	TransactionRefund   = "refund"
+	TransactionMarket   = "market"
+	TransactionFee      = "market_fee"
+	TransactionAuction  = "auction"
+	TransactionRaffle   = "raffle"
+	TransactionGrant    = "grant"
+)
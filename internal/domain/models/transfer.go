@@ -0,0 +1,19 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrTransferToSelf = errors.New("cannot transfer items to yourself")
+
+// ItemTransfer is a hand-over of owned items from one user to another as it
+// appears in the item-movement history.
+type ItemTransfer struct {
+	ID        int
+	Sender    string
+	Recipient string
+	Item      string
+	Quantity  int
+	CreatedAt time.Time
+}
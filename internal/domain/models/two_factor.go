@@ -0,0 +1,223 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTwoFactorRequired    = errors.New("two-factor authentication code required")
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor authentication code")
+)
+
+// TwoFactorRequiredError is returned instead of tokens when the password was
+// right but the user has two-factor authentication enabled. The challenge
+// token is exchanged for the tokens together with a code.
+type TwoFactorRequiredError struct {
+	ChallengeToken string
+	ExpiresAt      time.Time
+}
+
+func (e *TwoFactorRequiredError) Error() string {
+	return ErrTwoFactorRequired.Error()
+}
+
+func (e *TwoFactorRequiredError) Unwrap() error {
+	return ErrTwoFactorRequired
+}
+
+// TwoFactorPolicy sets up the second login step. Issuer names the service in
+// authenticator apps; a challenge is valid for ChallengeTTL and at most
+// MaxAttempts codes can be tried with it.
+type TwoFactorPolicy struct {
+	Issuer       string
+	ChallengeTTL time.Duration
+	MaxAttempts  int
+}
+
+// TwoFactorEnrollment is a TOTP secret waiting to be confirmed with a code.
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// TwoFactorStatus describes the two-factor authentication of a user. Required
+// is set if one of the user's roles is only granted to sessions started with
+// a second factor.
+type TwoFactorStatus struct {
+	Enabled           bool
+	ConfirmedAt       *time.Time
+	RecoveryCodesLeft int
+	Required          bool
+}
+
+// TwoFactorLogin is a login waiting for its second step, with what is needed
+// to check the code.
+type TwoFactorLogin struct {
+	UserID   int
+	Username string
+	Secret   string
+	LastStep int64
+}
+
+// TwoFactorProof is a checked second factor: either the TOTP time step the
+// code belongs to, or the hash of a recovery code that still has to be found
+// unused.
+type TwoFactorProof struct {
+	Step             int64
+	RecoveryCodeHash string
+}
+
+// TOTP parameters (RFC 6238). These are the defaults of authenticator apps,
+// some of which ignore any other values in the provisioning URI.
+const (
+	totpDigits = 6
+	totpPeriod = 30
+	// totpSkew is how many periods a code may be off, to allow for clock
+	// drift and slow typing.
+	totpSkew = 1
+)
+
+// RecoveryCodeCount is how many recovery codes are issued on confirmation.
+const RecoveryCodeCount = 10
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret returns a random 160-bit secret in base32.
+func NewTOTPSecret() (string, error) {
+	raw := make([]byte, 20)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	return totpEncoding.EncodeToString(raw), nil
+}
+
+// TOTPProvisioningURI returns the otpauth:// URI that authenticator apps
+// read from a QR code.
+func TOTPProvisioningURI(issuer, username, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(totpDigits))
+	query.Set("period", strconv.Itoa(totpPeriod))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(username)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// TOTPCode returns the code for the secret at the given time.
+func TOTPCode(secret string, at time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("invalid totp secret: %w", err)
+	}
+
+	return totpCode(key, at.Unix()/totpPeriod), nil
+}
+
+// VerifyTOTP checks the code against the secret at the given time and returns
+// the time step it belongs to. The caller must reject steps that were already
+// used, so an observed code cannot be replayed.
+func VerifyTOTP(secret, code string, at time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	now := at.Unix() / totpPeriod
+	for step := now - totpSkew; step <= now+totpSkew; step++ {
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000)
+}
+
+// NewRecoveryCodes returns RecoveryCodeCount random single-use codes in the
+// form abcd-efgh and the hashes to store.
+func NewRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		code := strings.ToLower(totpEncoding.EncodeToString(raw))
+		codes[i] = code[:4] + "-" + code[4:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// IsRecoveryCode reports whether the code looks like a recovery code rather
+// than a TOTP code. Case, dashes and spaces are ignored.
+func IsRecoveryCode(code string) bool {
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != 8 {
+		return false
+	}
+	_, err := totpEncoding.DecodeString(strings.ToUpper(normalized))
+	return err == nil
+}
+
+// HashRecoveryCode returns the hex SHA-256 of the normalized code.
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// NewTwoFactorChallenge returns a random challenge token for the second login
+// step and the hash to store.
+func NewTwoFactorChallenge() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate two-factor challenge: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashTwoFactorChallenge(token), nil
+}
+
+// HashTwoFactorChallenge returns the hex SHA-256 of the challenge token.
+func HashTwoFactorChallenge(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors,
+// "12345678901234567890", in base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// The last six digits of the RFC 6238 SHA-1 vectors.
+	tests := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, want := range tests {
+		code, err := TOTPCode(rfc6238Secret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, want, code, "at %d", unix)
+	}
+
+	_, err := TOTPCode("not base32!", time.Unix(59, 0))
+	assert.Error(t, err)
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / totpPeriod
+
+	got, ok := VerifyTOTP(rfc6238Secret, "081804", now)
+	assert.True(t, ok)
+	assert.Equal(t, step, got)
+
+	previous, _ := TOTPCode(rfc6238Secret, now.Add(-totpPeriod*time.Second))
+	got, ok = VerifyTOTP(rfc6238Secret, previous, now)
+	assert.True(t, ok, "A code from the previous period should be accepted")
+	assert.Equal(t, step-1, got)
+
+	stale, _ := TOTPCode(rfc6238Secret, now.Add(-3*totpPeriod*time.Second))
+	_, ok = VerifyTOTP(rfc6238Secret, stale, now)
+	assert.False(t, ok, "A code from several periods ago should be rejected")
+
+	_, ok = VerifyTOTP(rfc6238Secret, "000000", now)
+	assert.False(t, ok)
+	_, ok = VerifyTOTP(rfc6238Secret, "81804", now)
+	assert.False(t, ok)
+	_, ok = VerifyTOTP(strings.ToLower(rfc6238Secret), "081804", now)
+	assert.True(t, ok, "The secret should be case-insensitive")
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32, "A 160-bit secret is 32 base32 characters")
+
+	code, err := TOTPCode(secret, time.Now())
+	assert.NoError(t, err)
+	_, ok := VerifyTOTP(secret, code, time.Now())
+	assert.True(t, ok)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(TOTPProvisioningURI("Merch Store", "alice", rfc6238Secret))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Merch Store:alice", uri.Path)
+	assert.Equal(t, rfc6238Secret, uri.Query().Get("secret"))
+	assert.Equal(t, "Merch Store", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes()
+	assert.NoError(t, err)
+	assert.Len(t, codes, RecoveryCodeCount)
+	assert.Len(t, hashes, RecoveryCodeCount)
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+		assert.True(t, IsRecoveryCode(code))
+		assert.Equal(t, hashes[i], HashRecoveryCode(code))
+		assert.False(t, seen[code], "Recovery codes should be unique")
+		seen[code] = true
+	}
+
+	assert.Equal(t, HashRecoveryCode("abcd-efgh"), HashRecoveryCode(" ABCDEFGH"), "Case, dashes and spaces should be ignored")
+	assert.False(t, IsRecoveryCode("123456"), "A TOTP code is not a recovery code")
+	assert.False(t, IsRecoveryCode("abcd-efg1"))
+}
+
+func TestTwoFactorRequiredError(t *testing.T) {
+	var err error = &TwoFactorRequiredError{ChallengeToken: "token"}
+	assert.ErrorIs(t, err, ErrTwoFactorRequired)
+	assert.Equal(t, ErrTwoFactorRequired.Error(), err.Error())
+
+	token, hash, err := NewTwoFactorChallenge()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, HashTwoFactorChallenge(token))
+}
@@ -1,8 +1,109 @@
 package models
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
 type User struct {
 	ID           int
 	Username     string
 	PasswordHash string
 	Coin         int
 }
+
+type RegistrationMode string
+
+const (
+	RegistrationOpen      RegistrationMode = "open"
+	RegistrationInvite    RegistrationMode = "invite"
+	RegistrationAllowlist RegistrationMode = "allowlist"
+)
+
+var (
+	ErrInvalidUsername = errors.New("invalid username")
+	ErrWeakPassword    = errors.New("password is too weak")
+	ErrNotAllowlisted  = errors.New("username is not on the registration allow-list")
+)
+
+// usernamePattern allows 3 to 32 latin letters, digits, dots, dashes and
+// underscores, starting with a letter or digit.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{2,31}$`)
+
+// RegistrationPolicy decides who may create an account and with what
+// credentials. In invite mode the invite code itself is checked on redemption.
+type RegistrationPolicy struct {
+	Mode              RegistrationMode
+	Allowlist         []string
+	PasswordMinLength int
+}
+
+// Validate checks the policy configuration.
+func (p RegistrationPolicy) Validate() error {
+	switch p.Mode {
+	case RegistrationOpen, RegistrationInvite, RegistrationAllowlist:
+		return nil
+	default:
+		return fmt.Errorf("unknown registration mode %q", p.Mode)
+	}
+}
+
+// Check returns an error if the account may not be registered.
+func (p RegistrationPolicy) Check(username, password string) error {
+	if err := ValidateUsername(username); err != nil {
+		return err
+	}
+
+	if err := p.CheckPassword(password); err != nil {
+		return err
+	}
+
+	if p.Mode == RegistrationAllowlist && !slices.Contains(p.Allowlist, username) {
+		return ErrNotAllowlisted
+	}
+
+	return nil
+}
+
+// ValidateUsername returns an error if the name may not be given to a new
+// user. It applies to registration and to users created from a directory.
+func ValidateUsername(username string) error {
+	if !usernamePattern.MatchString(username) {
+		return fmt.Errorf("%w: use 3 to 32 latin letters, digits, '.', '-' or '_', starting with a letter or digit", ErrInvalidUsername)
+	}
+	return nil
+}
+
+// CheckPassword returns an error if the password is too weak. It applies to
+// new passwords set on registration, change and reset alike.
+func (p RegistrationPolicy) CheckPassword(password string) error {
+	if len([]rune(password)) < p.PasswordMinLength {
+		return fmt.Errorf("%w: use at least %d characters", ErrWeakPassword, p.PasswordMinLength)
+	}
+	return nil
+}
+
+// Invite is a single-use code that lets someone register while registration
+// is invite-only.
+type Invite struct {
+	Code      string
+	CreatedBy string
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+	UsedBy    *string
+	UsedAt    *time.Time
+}
+
+// NewInviteCode returns a random invite code.
+func NewInviteCode() (string, error) {
+	code := make([]byte, 12)
+	if _, err := rand.Read(code); err != nil {
+		return "", fmt.Errorf("failed to generate invite code: %w", err)
+	}
+	return hex.EncodeToString(code), nil
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistrationPolicyCheck(t *testing.T) {
+	open := RegistrationPolicy{Mode: RegistrationOpen, PasswordMinLength: 8}
+	allowlist := RegistrationPolicy{Mode: RegistrationAllowlist, Allowlist: []string{"alice"}, PasswordMinLength: 8}
+
+	tests := []struct {
+		name     string
+		policy   RegistrationPolicy
+		username string
+		password string
+		wantErr  error
+	}{
+		{name: "valid", policy: open, username: "john.doe", password: "correct-horse"},
+		{name: "too short", policy: open, username: "jo", password: "correct-horse", wantErr: ErrInvalidUsername},
+		{name: "too long", policy: open, username: "a123456789012345678901234567890123", password: "correct-horse", wantErr: ErrInvalidUsername},
+		{name: "spaces", policy: open, username: "john doe", password: "correct-horse", wantErr: ErrInvalidUsername},
+		{name: "leading dot", policy: open, username: ".john", password: "correct-horse", wantErr: ErrInvalidUsername},
+		{name: "short password", policy: open, username: "john", password: "secret", wantErr: ErrWeakPassword},
+		{name: "allow-listed", policy: allowlist, username: "alice", password: "correct-horse"},
+		{name: "not allow-listed", policy: allowlist, username: "mallory", password: "correct-horse", wantErr: ErrNotAllowlisted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.username, tt.password)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistrationPolicyValidate(t *testing.T) {
+	assert.NoError(t, RegistrationPolicy{Mode: RegistrationInvite}.Validate())
+	assert.Error(t, RegistrationPolicy{Mode: "closed"}.Validate())
+}
+
+func TestNewInviteCode(t *testing.T) {
+	first, err := NewInviteCode()
+	assert.NoError(t, err)
+	second, err := NewInviteCode()
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 24)
+	assert.NotEqual(t, first, second)
+}
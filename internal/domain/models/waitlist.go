@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// WaitlistEntry is a user's subscription to a sold-out product.
+type WaitlistEntry struct {
+	Product   string
+	Position  int
+	CreatedAt time.Time
+}
+
+// ReservePolicy describes how many waitlist subscribers get a unit held for
+// them when a product is restocked, and for how long.
+type ReservePolicy struct {
+	Count int
+	TTL   time.Duration
+}
+
+// Slots returns how many of the subscribers get a reservation out of the
+// restocked units. Each reservation holds a single unit.
+func (p ReservePolicy) Slots(subscribers, restocked int) int {
+	if p.Count <= 0 || p.TTL <= 0 {
+		return 0
+	}
+	return min(p.Count, subscribers, restocked)
+}
+
+// RestockNotice tells a waitlist subscriber that the product is back.
+type RestockNotice struct {
+	UserID        int
+	Username      string
+	Product       string
+	ReservedUntil *time.Time
+}
+
+func (n *RestockNotice) Subject() string {
+	return fmt.Sprintf("%s is back in stock", n.Product)
+}
+
+func (n *RestockNotice) Body() string {
+	if n.ReservedUntil != nil {
+		return fmt.Sprintf("%s is back in stock. One unit is reserved for you until %s UTC.",
+			n.Product, n.ReservedUntil.Format(time.DateTime))
+	}
+	return fmt.Sprintf("%s is back in stock. Hurry, it may sell out again.", n.Product)
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservePolicySlots(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      ReservePolicy
+		subscribers int
+		restocked   int
+		expected    int
+	}{
+		{"Reservations disabled", ReservePolicy{}, 5, 10, 0},
+		{"No time to hold", ReservePolicy{Count: 3}, 5, 10, 0},
+		{"First N subscribers", ReservePolicy{Count: 3, TTL: time.Hour}, 5, 10, 3},
+		{"Fewer subscribers than N", ReservePolicy{Count: 3, TTL: time.Hour}, 2, 10, 2},
+		{"Fewer units than N", ReservePolicy{Count: 3, TTL: time.Hour}, 5, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.policy.Slots(tt.subscribers, tt.restocked))
+		})
+	}
+}
+
+func TestRestockNoticeBody(t *testing.T) {
+	until := time.Date(2026, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	reserved := RestockNotice{Product: "hoody", ReservedUntil: &until}
+	assert.Contains(t, reserved.Body(), "reserved for you until 2026-12-01 12:00:00")
+
+	notice := RestockNotice{Product: "hoody"}
+	assert.Equal(t, "hoody is back in stock", notice.Subject())
+	assert.NotContains(t, notice.Body(), "reserved")
+}
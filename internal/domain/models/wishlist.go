@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+const MaxWishlistNoteLength = 255
+
+var ErrWishlistNoteTooLong = errors.New("wishlist note is too long")
+
+// WishlistItem is a catalog item a user would like to get, shown with its
+// current price and whether it can be bought right now.
+type WishlistItem struct {
+	Product   string
+	Note      *string
+	Price     int
+	Available bool
+	AddedAt   time.Time
+}
+
+type Wishlist struct {
+	Public bool
+	Items  []WishlistItem
+}
+
+// ValidateWishlistNote checks the optional note attached to a wishlist item.
+func ValidateWishlistNote(note *string) error {
+	if note != nil && utf8.RuneCountInString(*note) > MaxWishlistNoteLength {
+		return ErrWishlistNoteTooLong
+	}
+	return nil
+}
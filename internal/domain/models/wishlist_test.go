@@ -0,0 +1,31 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWishlistNote(t *testing.T) {
+	short := "размер M"
+	limit := strings.Repeat("ж", MaxWishlistNoteLength)
+	long := limit + "!"
+
+	tests := []struct {
+		name     string
+		note     *string
+		expected error
+	}{
+		{"No note", nil, nil},
+		{"Short note", &short, nil},
+		{"Note at the limit counts runes, not bytes", &limit, nil},
+		{"Note over the limit", &long, ErrWishlistNoteTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ValidateWishlistNote(tt.note))
+		})
+	}
+}
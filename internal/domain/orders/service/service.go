@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"time"
+)
+
+type OrderServiceInterface interface {
+	ListUserOrders(ctx context.Context, userID int) ([]models.Order, error)
+	ListOrders(ctx context.Context, status *models.OrderStatus) ([]models.Order, error)
+	AdvanceStatus(ctx context.Context, orderID int, status models.OrderStatus) (*models.Order, error)
+	CancelOrder(ctx context.Context, userID int, orderID int) (*models.Order, error)
+	StaffCancelOrder(ctx context.Context, orderID int) (*models.Order, error)
+}
+
+// OrderStorage is the part of the repository the order service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=OrderStorage
+type OrderStorage interface {
+	ListUserOrders(ctx context.Context, userID int) ([]models.Order, error)
+	ListOrders(ctx context.Context, status *models.OrderStatus) ([]models.Order, error)
+	UpdateOrderStatus(ctx context.Context, orderID int, next models.OrderStatus) (*models.Order, error)
+	CancelOrder(ctx context.Context, orderID int, ownerID *int, returnWindow time.Duration) (*models.Order, error)
+}
+
+type OrderService struct {
+	storage      OrderStorage
+	returnWindow time.Duration
+}
+
+func NewOrderService(storage OrderStorage, returnWindow time.Duration) *OrderService {
+	return &OrderService{
+		storage:      storage,
+		returnWindow: returnWindow,
+	}
+}
+
+func (s *OrderService) ListUserOrders(ctx context.Context, userID int) ([]models.Order, error) {
+	return s.storage.ListUserOrders(ctx, userID)
+}
+
+func (s *OrderService) ListOrders(ctx context.Context, status *models.OrderStatus) ([]models.Order, error) {
+	if status != nil && !status.Valid() {
+		return nil, fmt.Errorf("unknown order status '%s'", *status)
+	}
+
+	return s.storage.ListOrders(ctx, status)
+}
+
+// AdvanceStatus moves an order forward in its fulfilment lifecycle.
+// Cancellation is not an advance and is rejected here.
+func (s *OrderService) AdvanceStatus(ctx context.Context, orderID int, status models.OrderStatus) (*models.Order, error) {
+	if !status.Valid() || status == models.OrderStatusCancelled {
+		return nil, fmt.Errorf("cannot advance order to '%s': %w", status, repository.ErrInvalidStatusTransition)
+	}
+
+	return s.storage.UpdateOrderStatus(ctx, orderID, status)
+}
+
+// CancelOrder cancels one of the user's own orders and refunds the coins paid.
+func (s *OrderService) CancelOrder(ctx context.Context, userID int, orderID int) (*models.Order, error) {
+	return s.storage.CancelOrder(ctx, orderID, &userID, s.returnWindow)
+}
+
+// StaffCancelOrder cancels any order on behalf of the shop.
+func (s *OrderService) StaffCancelOrder(ctx context.Context, orderID int) (*models.Order, error) {
+	return s.storage.CancelOrder(ctx, orderID, nil, s.returnWindow)
+}
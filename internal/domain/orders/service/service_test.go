@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/orders/service/mocks"
+	"merch-store-service/internal/domain/repository"
+)
+
+func TestListOrders(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewOrderStorage(t)
+	service := NewOrderService(storage, 0)
+
+	placed := models.OrderStatusPlaced
+	storage.On("ListOrders", ctx, &placed).Return([]models.Order{{ID: 1, Status: placed}}, nil).Once()
+
+	orders, err := service.ListOrders(ctx, &placed)
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+
+	unknown := models.OrderStatus("lost")
+	_, err = service.ListOrders(ctx, &unknown)
+	assert.ErrorContains(t, err, "unknown order status", "An unknown status should be rejected before querying")
+}
+
+func TestAdvanceStatus(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewOrderStorage(t)
+	service := NewOrderService(storage, 0)
+
+	storage.On("UpdateOrderStatus", ctx, 10, models.OrderStatusReadyForPickup).
+		Return(&models.Order{ID: 10, Status: models.OrderStatusReadyForPickup}, nil).Once()
+
+	order, err := service.AdvanceStatus(ctx, 10, models.OrderStatusReadyForPickup)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OrderStatusReadyForPickup, order.Status)
+
+	_, err = service.AdvanceStatus(ctx, 10, models.OrderStatusCancelled)
+	assert.ErrorIs(t, err, repository.ErrInvalidStatusTransition, "Cancelling goes through CancelOrder with a refund")
+
+	_, err = service.AdvanceStatus(ctx, 10, models.OrderStatus("lost"))
+	assert.ErrorIs(t, err, repository.ErrInvalidStatusTransition)
+
+	storage.On("UpdateOrderStatus", ctx, 11, models.OrderStatusDelivered).
+		Return(nil, repository.ErrInvalidStatusTransition).Once()
+
+	_, err = service.AdvanceStatus(ctx, 11, models.OrderStatusDelivered)
+	assert.ErrorIs(t, err, repository.ErrInvalidStatusTransition)
+}
+
+func TestCancelOrder(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewOrderStorage(t)
+	service := NewOrderService(storage, 72*time.Hour)
+
+	owner := 1
+	storage.On("CancelOrder", ctx, 10, &owner, 72*time.Hour).
+		Return(&models.Order{ID: 10, UserID: owner, Status: models.OrderStatusCancelled}, nil).Once()
+
+	order, err := service.CancelOrder(ctx, owner, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCancelled, order.Status)
+
+	storage.On("CancelOrder", ctx, 11, &owner, 72*time.Hour).Return(nil, repository.ErrOrderNotCancellable).Once()
+
+	_, err = service.CancelOrder(ctx, owner, 11)
+	assert.ErrorIs(t, err, repository.ErrOrderNotCancellable)
+
+	// Staff may cancel any order, so no owner is passed.
+	storage.On("CancelOrder", ctx, 12, (*int)(nil), 72*time.Hour).
+		Return(&models.Order{ID: 12, UserID: 2, Status: models.OrderStatusCancelled}, nil).Once()
+
+	_, err = service.StaffCancelOrder(ctx, 12)
+	assert.NoError(t, err)
+
+	storage.On("CancelOrder", ctx, 13, mock.Anything, 72*time.Hour).Return(nil, repository.ErrOrderNotFound).Once()
+
+	_, err = service.StaffCancelOrder(ctx, 13)
+	assert.ErrorIs(t, err, repository.ErrOrderNotFound)
+}
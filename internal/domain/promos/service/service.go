@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+)
+
+var ErrInvalidPromoCode = errors.New("invalid promo code")
+
+type PromoServiceInterface interface {
+	CreatePromoCode(ctx context.Context, promo models.PromoCode) (*models.PromoCode, error)
+	ListPromoCodes(ctx context.Context) ([]models.PromoCodeStats, error)
+}
+
+// PromoStorage is the part of the repository the promo service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=PromoStorage
+type PromoStorage interface {
+	CreatePromoCode(ctx context.Context, promo *models.PromoCode) (*models.PromoCode, error)
+	ListPromoCodeStats(ctx context.Context) ([]models.PromoCodeStats, error)
+}
+
+type PromoService struct {
+	storage PromoStorage
+}
+
+func NewPromoService(storage PromoStorage) *PromoService {
+	return &PromoService{
+		storage: storage,
+	}
+}
+
+func (s *PromoService) CreatePromoCode(ctx context.Context, promo models.PromoCode) (*models.PromoCode, error) {
+	if promo.ValidFrom.IsZero() {
+		promo.ValidFrom = time.Now()
+	}
+
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	promo.ValidFrom = promo.ValidFrom.UTC()
+	if promo.ValidUntil != nil {
+		until := promo.ValidUntil.UTC()
+		promo.ValidUntil = &until
+	}
+
+	if err := promo.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPromoCode, err)
+	}
+
+	return s.storage.CreatePromoCode(ctx, &promo)
+}
+
+// ListPromoCodes returns every promo code with its redemption statistics.
+func (s *PromoService) ListPromoCodes(ctx context.Context) ([]models.PromoCodeStats, error) {
+	return s.storage.ListPromoCodeStats(ctx)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/promos/service/mocks"
+	"merch-store-service/internal/domain/repository"
+)
+
+func TestCreatePromoCode(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewPromoStorage(t)
+	service := NewPromoService(storage)
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+	validFrom := time.Date(2026, 5, 1, 12, 0, 0, 0, moscow)
+
+	// Timestamps are stored in UTC.
+	storage.On("CreatePromoCode", ctx, mock.MatchedBy(func(p *models.PromoCode) bool {
+		return p.Code == "ANNIVERSARY" && p.ValidFrom.Location() == time.UTC && p.ValidFrom.Equal(validFrom)
+	})).Return(&models.PromoCode{ID: 1, Code: "ANNIVERSARY"}, nil).Once()
+
+	promo, err := service.CreatePromoCode(ctx, models.PromoCode{
+		Code: "ANNIVERSARY", DiscountType: models.DiscountPercent, DiscountValue: 20, ValidFrom: validFrom,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, promo.ID)
+
+	_, err = service.CreatePromoCode(ctx, models.PromoCode{Code: "HALF", DiscountType: models.DiscountPercent, DiscountValue: 150})
+	assert.ErrorIs(t, err, ErrInvalidPromoCode, "A percentage above 100 should be rejected")
+
+	until := validFrom.Add(-time.Hour)
+	_, err = service.CreatePromoCode(ctx, models.PromoCode{
+		Code: "PAST", DiscountType: models.DiscountFixed, DiscountValue: 5, ValidFrom: validFrom, ValidUntil: &until,
+	})
+	assert.ErrorIs(t, err, ErrInvalidPromoCode, "The validity window should not end before it starts")
+
+	zero := 0
+	_, err = service.CreatePromoCode(ctx, models.PromoCode{Code: "NONE", DiscountType: models.DiscountFixed, DiscountValue: 5, MaxUses: &zero})
+	assert.ErrorIs(t, err, ErrInvalidPromoCode, "Usage caps should be positive")
+
+	storage.On("CreatePromoCode", ctx, mock.Anything).Return(nil, repository.ErrPromoCodeExists).Once()
+
+	_, err = service.CreatePromoCode(ctx, models.PromoCode{Code: "ANNIVERSARY", DiscountType: models.DiscountFixed, DiscountValue: 50})
+	assert.ErrorIs(t, err, repository.ErrPromoCodeExists)
+}
+
+func TestListPromoCodes(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewPromoStorage(t)
+	service := NewPromoService(storage)
+
+	storage.On("ListPromoCodeStats", ctx).Return([]models.PromoCodeStats{
+		{PromoCode: models.PromoCode{Code: "ANNIVERSARY"}, Redemptions: 2, UniqueUsers: 2, TotalDiscount: 120},
+	}, nil).Once()
+
+	stats, err := service.ListPromoCodes(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, 120, stats[0].TotalDiscount)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/models"
+)
+
+var ErrInvalidRaffle = errors.New("invalid raffle")
+
+type RaffleServiceInterface interface {
+	CreateRaffle(ctx context.Context, raffle models.Raffle) (*models.Raffle, error)
+	CancelRaffle(ctx context.Context, raffleID int) (*models.Raffle, error)
+	ListRaffles(ctx context.Context) ([]models.Raffle, error)
+	GetRaffle(ctx context.Context, raffleID int) (*models.Raffle, []models.RaffleTicket, error)
+	BuyTickets(ctx context.Context, userID, raffleID, count int) ([]int, int, error)
+	DrawDueRaffles(ctx context.Context) error
+}
+
+// RaffleStorage is the part of the repository the raffle service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=RaffleStorage
+type RaffleStorage interface {
+	CreateRaffle(ctx context.Context, raffle *models.Raffle, seed string) (*models.Raffle, error)
+	CancelRaffle(ctx context.Context, raffleID int) (*models.Raffle, error)
+	ListRaffles(ctx context.Context) ([]models.Raffle, error)
+	GetRaffle(ctx context.Context, raffleID int) (*models.Raffle, []models.RaffleTicket, error)
+	BuyRaffleTickets(ctx context.Context, raffleID, userID, count int) ([]int, int, error)
+	DrawDueRaffles(ctx context.Context) ([]models.Raffle, error)
+}
+
+type RaffleService struct {
+	storage RaffleStorage
+}
+
+func NewRaffleService(storage RaffleStorage) *RaffleService {
+	return &RaffleService{
+		storage: storage,
+	}
+}
+
+// CreateRaffle generates the raffle's secret seed and publishes only its
+// commitment until the raffle is drawn.
+func (s *RaffleService) CreateRaffle(ctx context.Context, raffle models.Raffle) (*models.Raffle, error) {
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	raffle.DrawAt = raffle.DrawAt.UTC()
+
+	if err := raffle.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRaffle, err)
+	}
+
+	seed, commitment, err := models.NewRaffleSeed()
+	if err != nil {
+		return nil, err
+	}
+	raffle.Commitment = commitment
+
+	return s.storage.CreateRaffle(ctx, &raffle, seed)
+}
+
+func (s *RaffleService) CancelRaffle(ctx context.Context, raffleID int) (*models.Raffle, error) {
+	return s.storage.CancelRaffle(ctx, raffleID)
+}
+
+func (s *RaffleService) ListRaffles(ctx context.Context) ([]models.Raffle, error) {
+	return s.storage.ListRaffles(ctx)
+}
+
+func (s *RaffleService) GetRaffle(ctx context.Context, raffleID int) (*models.Raffle, []models.RaffleTicket, error) {
+	return s.storage.GetRaffle(ctx, raffleID)
+}
+
+func (s *RaffleService) BuyTickets(ctx context.Context, userID, raffleID, count int) ([]int, int, error) {
+	return s.storage.BuyRaffleTickets(ctx, raffleID, userID, count)
+}
+
+// DrawDueRaffles draws the raffles whose draw time has passed. It is run
+// periodically by the application.
+func (s *RaffleService) DrawDueRaffles(ctx context.Context) error {
+	drawn, err := s.storage.DrawDueRaffles(ctx)
+	for _, raffle := range drawn {
+		log.Printf("raffle %d: %s drawn among %d tickets, seed %s", raffle.ID, raffle.Product, raffle.TicketsSold, *raffle.Seed)
+	}
+
+	return err
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/raffles/service/mocks"
+	"merch-store-service/internal/domain/repository"
+)
+
+func TestCreateRaffle(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewRaffleStorage(t)
+	service := NewRaffleService(storage)
+
+	drawAt := time.Date(2025, 3, 1, 21, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	// Only the commitment to the generated seed is published.
+	var seed string
+	storage.On("CreateRaffle", ctx, mock.MatchedBy(func(r *models.Raffle) bool {
+		return r.DrawAt.Location() == time.UTC && r.DrawAt.Equal(drawAt) && r.Seed == nil
+	}), mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { seed = args.String(2) }).
+		Return(func(_ context.Context, r *models.Raffle, _ string) *models.Raffle {
+			created := *r
+			created.ID = 1
+			return &created
+		}, nil).Once()
+
+	raffle, err := service.CreateRaffle(ctx, models.Raffle{Product: "hoody", Prizes: 2, TicketPrice: 10, DrawAt: drawAt})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, raffle.ID)
+	commitment, err := models.RaffleCommitment(seed)
+	assert.NoError(t, err)
+	assert.Equal(t, commitment, raffle.Commitment, "The commitment matches the stored seed")
+
+	_, err = service.CreateRaffle(ctx, models.Raffle{Product: "hoody", TicketPrice: 10, DrawAt: drawAt})
+	assert.ErrorIs(t, err, ErrInvalidRaffle, "At least one prize is required")
+
+	limit := 0
+	_, err = service.CreateRaffle(ctx, models.Raffle{Product: "hoody", Prizes: 1, TicketPrice: 10, MaxTicketsPerUser: &limit, DrawAt: drawAt})
+	assert.ErrorIs(t, err, ErrInvalidRaffle, "The per-user ticket limit should be positive")
+
+	storage.On("CreateRaffle", ctx, mock.Anything, mock.Anything).Return(nil, repository.ErrItemNotFound).Once()
+
+	_, err = service.CreateRaffle(ctx, models.Raffle{Product: "unknown", Prizes: 1, TicketPrice: 10, DrawAt: drawAt})
+	assert.ErrorIs(t, err, repository.ErrItemNotFound)
+}
+
+func TestBuyTickets(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewRaffleStorage(t)
+	service := NewRaffleService(storage)
+
+	storage.On("BuyRaffleTickets", ctx, 1, 2, 3).Return([]int{1, 2, 3}, 30, nil).Once()
+
+	numbers, total, err := service.BuyTickets(ctx, 2, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, numbers)
+	assert.Equal(t, 30, total)
+
+	storage.On("BuyRaffleTickets", ctx, 1, 2, 3).Return(nil, 0, models.ErrTicketLimitReached).Once()
+
+	_, _, err = service.BuyTickets(ctx, 2, 1, 3)
+	assert.ErrorIs(t, err, models.ErrTicketLimitReached)
+
+	storage.On("BuyRaffleTickets", ctx, 2, 2, 1).Return(nil, 0, models.ErrRaffleClosed).Once()
+
+	_, _, err = service.BuyTickets(ctx, 2, 2, 1)
+	assert.ErrorIs(t, err, models.ErrRaffleClosed)
+
+	storage.On("BuyRaffleTickets", ctx, 3, 2, 1).Return(nil, 0, repository.ErrInsufficientFunds).Once()
+
+	_, _, err = service.BuyTickets(ctx, 2, 3, 1)
+	assert.ErrorIs(t, err, repository.ErrInsufficientFunds)
+}
+
+func TestCancelRaffle(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewRaffleStorage(t)
+	service := NewRaffleService(storage)
+
+	storage.On("CancelRaffle", ctx, 1).Return(&models.Raffle{ID: 1, State: models.RaffleCancelled}, nil).Once()
+
+	raffle, err := service.CancelRaffle(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RaffleCancelled, raffle.State)
+
+	storage.On("CancelRaffle", ctx, 2).Return(nil, repository.ErrRaffleNotOpen).Once()
+
+	_, err = service.CancelRaffle(ctx, 2)
+	assert.ErrorIs(t, err, repository.ErrRaffleNotOpen)
+}
+
+func TestDrawDueRaffles(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewRaffleStorage(t)
+	service := NewRaffleService(storage)
+
+	seed := "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	storage.On("DrawDueRaffles", ctx).Return([]models.Raffle{
+		{ID: 1, Product: "hoody", TicketsSold: 8, Seed: &seed, State: models.RaffleDrawn},
+	}, nil).Once()
+
+	assert.NoError(t, service.DrawDueRaffles(ctx))
+
+	storage.On("DrawDueRaffles", ctx).Return(nil, repository.ErrRaffleNotFound).Once()
+
+	assert.ErrorIs(t, service.DrawDueRaffles(ctx), repository.ErrRaffleNotFound)
+}
@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const selectAuctions = `
+	SELECT a.id, a.product_name, a.quantity, a.starts_at, a.ends_at, a.reserve_price, a.min_increment,
+	       a.extension_seconds, a.status, a.leader_id, u.username, a.current_bid, a.created_at, a.closed_at
+	FROM auctions a
+	LEFT JOIN users u ON u.id = a.leader_id`
+
+// CreateAuction stores the auction and takes its lot out of stock, so the
+// units cannot be sold in the shop while they are auctioned. No one receives
+// the lot yet, so units reserved for waitlist subscribers are not available.
+func (s *Storage) CreateAuction(ctx context.Context, auction *models.Auction) (*models.Auction, error) {
+	const op = "domain.repository.CreateAuction"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, stock, err := productForSale(ctx, tx, auction.Product)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if stock != nil {
+		if err = takeStock(ctx, tx, auction.Product, 0, auction.Quantity); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	created := *auction
+	created.State = models.AuctionOpen
+	err = tx.QueryRow(ctx, `
+        INSERT INTO auctions (product_name, quantity, starts_at, ends_at, reserve_price, min_increment, extension_seconds)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at`,
+		auction.Product, auction.Quantity, auction.StartsAt, auction.EndsAt, auction.ReservePrice,
+		auction.MinIncrement, int(auction.Extension/time.Second),
+	).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to insert auction: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return &created, nil
+}
+
+// ListAuctions returns open auctions ending soonest first, followed by
+// settled ones, most recently closed first.
+func (s *Storage) ListAuctions(ctx context.Context) ([]models.Auction, error) {
+	const op = "domain.repository.ListAuctions"
+
+	rows, err := s.db.Query(ctx, selectAuctions+`
+        ORDER BY a.status <> $1, CASE WHEN a.status = $1 THEN a.ends_at END, a.closed_at DESC, a.id`, models.AuctionOpen)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	auctions := make([]models.Auction, 0)
+	for rows.Next() {
+		auction, err := scanAuction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		auctions = append(auctions, *auction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auctions, nil
+}
+
+// GetAuction returns the auction with its bids, highest first.
+func (s *Storage) GetAuction(ctx context.Context, auctionID int) (*models.Auction, []models.Bid, error) {
+	const op = "domain.repository.GetAuction"
+
+	auction, err := scanAuction(s.db.QueryRow(ctx, selectAuctions+" WHERE a.id = $1", auctionID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(ctx, `
+        SELECT u.username, b.amount, b.created_at
+        FROM auction_bids b
+        JOIN users u ON u.id = b.user_id
+        WHERE b.auction_id = $1
+        ORDER BY b.amount DESC, b.id DESC`, auctionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	bids := make([]models.Bid, 0)
+	for rows.Next() {
+		var b models.Bid
+		if err := rows.Scan(&b.Bidder, &b.Amount, &b.CreatedAt); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		bids = append(bids, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return auction, bids, nil
+}
+
+// PlaceBid makes the user the leader of the auction. The bid amount is held
+// by taking it from the bidder's balance, and the previous leader's hold is
+// given back. The auction row lock serialises concurrent bids, so every bid
+// is checked against the leading bid committed before it.
+func (s *Storage) PlaceBid(ctx context.Context, auctionID, userID, amount int) (*models.Auction, error) {
+	const op = "domain.repository.PlaceBid"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback(ctx)
+			if err != nil {
+				log.Printf("%s: rollback failed: %v", op, err)
+			}
+		}
+	}()
+
+	auction, err := scanAuction(tx.QueryRow(ctx, selectAuctions+" WHERE a.id = $1 FOR UPDATE OF a", auctionID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now().UTC()
+	if err = auction.CheckBid(now, amount); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users := []int{userID}
+	if auction.LeaderID != nil {
+		users = append(users, *auction.LeaderID)
+	}
+	if err = lockUsers(ctx, tx, users...); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if auction.LeaderID != nil {
+		if err = creditCoins(ctx, tx, *auction.LeaderID, *auction.CurrentBid); err != nil {
+			return nil, fmt.Errorf("%s: failed to release hold: %w", op, err)
+		}
+	}
+
+	if err = debitCoins(ctx, tx, userID, amount); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	auction.EndsAt = auction.ExtendedEnd(now)
+	_, err = tx.Exec(ctx, "UPDATE auctions SET leader_id = $1, current_bid = $2, ends_at = $3 WHERE id = $4",
+		userID, amount, auction.EndsAt, auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to update auction: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO auction_bids (auction_id, user_id, amount) VALUES ($1, $2, $3)", auctionID, userID, amount)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to record bid: %w", op, err)
+	}
+
+	updated, err := scanAuction(tx.QueryRow(ctx, selectAuctions+" WHERE a.id = $1", auctionID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// CloseDueAuctions settles every open auction whose end has passed and
+// returns the settled auctions. Each auction is settled in its own
+// transaction; auctions locked by a concurrent bid or closer are skipped and
+// picked up on the next run. An auction that cannot be settled is logged and
+// skipped, so it does not hold up the auctions due after it, and is retried
+// on the next run.
+func (s *Storage) CloseDueAuctions(ctx context.Context) ([]models.Auction, error) {
+	const op = "domain.repository.CloseDueAuctions"
+
+	var closed []models.Auction
+	failed := make([]int, 0)
+	for {
+		auction, err := s.closeNextAuction(ctx, failed)
+		switch {
+		case err != nil && auction != nil:
+			log.Printf("%s: failed to close auction %d: %v", op, auction.ID, err)
+			failed = append(failed, auction.ID)
+		case err != nil:
+			return closed, fmt.Errorf("%s: %w", op, err)
+		case auction == nil:
+			return closed, nil
+		default:
+			closed = append(closed, *auction)
+		}
+	}
+}
+
+// closeNextAuction settles one due auction that is not in skip. It returns
+// nil if no auction is due. If the auction cannot be settled, it is returned
+// together with the error.
+func (s *Storage) closeNextAuction(ctx context.Context, skip []int) (*models.Auction, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	auction, err := scanAuction(tx.QueryRow(ctx, selectAuctions+`
+        WHERE a.status = $1 AND a.ends_at <= LOCALTIMESTAMP AND a.id <> ALL($2)
+        ORDER BY a.ends_at, a.id
+        LIMIT 1
+        FOR UPDATE OF a SKIP LOCKED`, models.AuctionOpen, skip))
+	if errors.Is(err, ErrAuctionNotFound) {
+		_ = tx.Rollback(ctx)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = settleAuction(ctx, tx, auction); err != nil {
+		return auction, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return auction, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return auction, nil
+}
+
+// settleAuction closes the auction: the lot goes to the leader if the reserve
+// price is met and their held coins are kept as payment; otherwise the hold
+// is given back and the lot returns to stock.
+func settleAuction(ctx context.Context, tx pgx.Tx, auction *models.Auction) error {
+	auction.State = models.AuctionUnsold
+	switch {
+	case auction.LeaderID != nil && auction.ReserveMet():
+		auction.State = models.AuctionSold
+
+		if err := addToInventory(ctx, tx, *auction.LeaderID, auction.Product, auction.Quantity); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, `
+            INSERT INTO transactions (from_user_id, to_user_id, amount, item_name, quantity, kind)
+            VALUES ($1, $1, $2, $3, $4, $5)`,
+			*auction.LeaderID, *auction.CurrentBid, auction.Product, auction.Quantity, models.TransactionAuction)
+		if err != nil {
+			return fmt.Errorf("failed to insert transaction: %w", err)
+		}
+	case auction.LeaderID != nil:
+		if err := creditCoins(ctx, tx, *auction.LeaderID, *auction.CurrentBid); err != nil {
+			return fmt.Errorf("failed to release hold: %w", err)
+		}
+	}
+
+	if auction.State == models.AuctionUnsold {
+		if err := returnStock(ctx, tx, auction.Product, auction.Quantity); err != nil {
+			return err
+		}
+	}
+
+	err := tx.QueryRow(ctx, "UPDATE auctions SET status = $1, closed_at = LOCALTIMESTAMP WHERE id = $2 RETURNING closed_at",
+		auction.State, auction.ID).Scan(&auction.ClosedAt)
+	if err != nil {
+		return fmt.Errorf("failed to close auction: %w", err)
+	}
+
+	return nil
+}
+
+func scanAuction(row pgx.Row) (*models.Auction, error) {
+	var (
+		a         models.Auction
+		extension int
+	)
+	err := row.Scan(&a.ID, &a.Product, &a.Quantity, &a.StartsAt, &a.EndsAt, &a.ReservePrice, &a.MinIncrement,
+		&extension, &a.State, &a.LeaderID, &a.Leader, &a.CurrentBid, &a.CreatedAt, &a.ClosedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAuctionNotFound
+		}
+		return nil, fmt.Errorf("failed to get auction: %w", err)
+	}
+	a.Extension = time.Duration(extension) * time.Second
+
+	return &a, nil
+}
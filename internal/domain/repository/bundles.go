@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// selectBundles returns one row per bundle component. A bundle is available
+// while every one of its components can be bought.
+const selectBundles = `
+	SELECT b.id, b.name, b.price, b.created_at, b.updated_at,
+	       NOT EXISTS (
+	           SELECT 1 FROM bundle_items c
+	           JOIN products p ON p.name = c.product_name
+	           WHERE c.bundle_id = b.id AND NOT (` + availableSQL + `)),
+	       bi.product_name, bi.quantity
+	FROM bundles b
+	JOIN bundle_items bi ON bi.bundle_id = b.id`
+
+// CreateBundle stores a bundle with its components.
+func (s *Storage) CreateBundle(ctx context.Context, bundle *models.Bundle) (*models.Bundle, error) {
+	const op = "domain.repository.CreateBundle"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var bundleID int
+	err = tx.QueryRow(ctx, "INSERT INTO bundles (name, price) VALUES ($1, $2) RETURNING id", bundle.Name, bundle.Price).Scan(&bundleID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, fmt.Errorf("%s: %w", op, ErrBundleExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = insertBundleItems(ctx, tx, bundleID, bundle.Items); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return s.GetBundle(ctx, bundle.Name)
+}
+
+// UpdateBundle replaces the price and components of the bundle.
+func (s *Storage) UpdateBundle(ctx context.Context, bundle *models.Bundle) (*models.Bundle, error) {
+	const op = "domain.repository.UpdateBundle"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var bundleID int
+	err = tx.QueryRow(ctx, "UPDATE bundles SET price = $1, updated_at = CURRENT_TIMESTAMP WHERE name = $2 RETURNING id",
+		bundle.Price, bundle.Name).Scan(&bundleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrBundleNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM bundle_items WHERE bundle_id = $1", bundleID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to clear bundle items: %w", op, err)
+	}
+
+	if err = insertBundleItems(ctx, tx, bundleID, bundle.Items); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return s.GetBundle(ctx, bundle.Name)
+}
+
+// DeleteBundle removes the bundle from the catalog. Orders already placed
+// for it keep their components.
+func (s *Storage) DeleteBundle(ctx context.Context, name string) error {
+	const op = "domain.repository.DeleteBundle"
+
+	tag, err := s.db.Exec(ctx, "DELETE FROM bundles WHERE name = $1", name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrBundleNotFound)
+	}
+
+	return nil
+}
+
+// ListBundles returns all bundles ordered by name.
+func (s *Storage) ListBundles(ctx context.Context) ([]models.Bundle, error) {
+	const op = "domain.repository.ListBundles"
+
+	bundles, err := s.queryBundles(ctx, selectBundles+" ORDER BY b.name, bi.product_name")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bundles, nil
+}
+
+func (s *Storage) GetBundle(ctx context.Context, name string) (*models.Bundle, error) {
+	const op = "domain.repository.GetBundle"
+
+	bundles, err := s.queryBundles(ctx, selectBundles+" WHERE b.name = $1 ORDER BY bi.product_name", name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(bundles) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrBundleNotFound)
+	}
+
+	return &bundles[0], nil
+}
+
+// BuyBundle sells quantity bundles in a single transaction: the bundle price
+// is debited once per bundle, and every component is taken from stock (or
+// its running drop) and added to the buyer's inventory. If any component is
+// unavailable nothing is bought. Components are processed in product order,
+// so concurrent bundle purchases lock product rows in the same order.
+//
+//nolint:gocyclo
+func (s *Storage) BuyBundle(ctx context.Context, userID int, name string, quantity int) (*models.Order, error) {
+	const op = "domain.repository.BuyBundle"
+
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidQuantity)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var (
+		bundleID int
+		price    int
+	)
+	err = tx.QueryRow(ctx, "SELECT id, price FROM bundles WHERE name = $1 FOR SHARE", name).Scan(&bundleID, &price)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrBundleNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get bundle: %w", op, err)
+	}
+
+	components, err := bundleItems(ctx, tx, bundleID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	drops := make(map[int]int)
+	order := &models.Order{
+		UserID: userID,
+		Status: models.OrderStatusPlaced,
+		Total:  price * quantity,
+	}
+	for _, component := range components {
+		units := component.Quantity * quantity
+
+		var stock *int
+		_, stock, err = productForSale(ctx, tx, component.Product)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, component.Product, err)
+		}
+
+		var drop *models.Drop
+		drop, err = claimDrop(ctx, tx, component.Product, userID, units)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, component.Product, err)
+		}
+		if drop != nil {
+			drops[drop.ID] += units
+		}
+
+		if stock != nil {
+			if err = takeStock(ctx, tx, component.Product, userID, units); err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", op, component.Product, err)
+			}
+		}
+
+		if err = addToInventory(ctx, tx, userID, component.Product, units); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		order.Items = append(order.Items, models.OrderItem{ItemName: component.Product, Quantity: units, Bundle: &name})
+	}
+
+	if err = debitCoins(ctx, tx, userID, order.Total); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.QueryRow(ctx, `
+        INSERT INTO orders (user_id, status, total)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at, updated_at`, order.UserID, order.Status, order.Total).
+		Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create order: %w", op, err)
+	}
+
+	for _, item := range order.Items {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO order_items (order_id, item_name, quantity, unit_price, bundle_name)
+            VALUES ($1, $2, $3, 0, $4)`, order.ID, item.ItemName, item.Quantity, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to add order item: %w", op, err)
+		}
+	}
+
+	for dropID, units := range drops {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO drop_allocations (drop_id, user_id, order_id, quantity)
+            VALUES ($1, $2, $3, $4)`, dropID, userID, order.ID, units)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to record drop allocation: %w", op, err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO transactions (from_user_id, to_user_id, amount, quantity, order_id, kind)
+        VALUES ($1, $1, $2, $3, $4, $5)`, userID, order.Total, quantity, order.ID, models.TransactionPurchase)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to insert transaction: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return order, nil
+}
+
+func insertBundleItems(ctx context.Context, tx pgx.Tx, bundleID int, items []models.BundleItem) error {
+	for _, item := range items {
+		_, err := tx.Exec(ctx, "INSERT INTO bundle_items (bundle_id, product_name, quantity) VALUES ($1, $2, $3)",
+			bundleID, item.Product, item.Quantity)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+				return fmt.Errorf("%s: %w", item.Product, ErrItemNotFound)
+			}
+			return fmt.Errorf("failed to add bundle item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func bundleItems(ctx context.Context, tx pgx.Tx, bundleID int) ([]models.BundleItem, error) {
+	rows, err := tx.Query(ctx, "SELECT product_name, quantity FROM bundle_items WHERE bundle_id = $1 ORDER BY product_name", bundleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bundle items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.BundleItem
+	for rows.Next() {
+		var item models.BundleItem
+		if err := rows.Scan(&item.Product, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan bundle item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// queryBundles runs a query over bundles joined with their components and
+// groups the rows into bundles, keeping the order in which they were returned.
+func (s *Storage) queryBundles(ctx context.Context, query string, args ...any) ([]models.Bundle, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bundles := make([]models.Bundle, 0)
+	index := make(map[int]int)
+
+	for rows.Next() {
+		var (
+			bundle models.Bundle
+			item   models.BundleItem
+		)
+		if err := rows.Scan(&bundle.ID, &bundle.Name, &bundle.Price, &bundle.CreatedAt, &bundle.UpdatedAt, &bundle.Available,
+			&item.Product, &item.Quantity); err != nil {
+			return nil, err
+		}
+
+		i, ok := index[bundle.ID]
+		if !ok {
+			bundles = append(bundles, bundle)
+			i = len(bundles) - 1
+			index[bundle.ID] = i
+		}
+		bundles[i].Items = append(bundles[i].Items, item)
+	}
+
+	return bundles, rows.Err()
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// currentPriceSQL selects the price in effect now for the product aliased as p.
+// Products without a price history fall back to their list price.
+const currentPriceSQL = `COALESCE((
+        SELECT pp.price FROM product_prices pp
+        WHERE pp.product_name = p.name AND pp.effective_from <= LOCALTIMESTAMP
+        ORDER BY pp.effective_from DESC
+        LIMIT 1), p.price)`
+
+// availableSQL reports whether the product aliased as p can be bought now.
+// Archived products are never available. A product sold through drops is available while a running drop has units
+// left; any other product while its stock, minus units reserved for waitlist
+// subscribers, is positive or not tracked.
+const availableSQL = `CASE
+        WHEN p.archived_at IS NOT NULL THEN FALSE
+        WHEN EXISTS (SELECT 1 FROM drops d WHERE d.product_name = p.name AND (d.ends_at IS NULL OR d.ends_at > LOCALTIMESTAMP))
+        THEN EXISTS (SELECT 1 FROM drops d WHERE d.product_name = p.name AND d.starts_at <= LOCALTIMESTAMP
+                     AND (d.ends_at IS NULL OR d.ends_at > LOCALTIMESTAMP) AND d.remaining > 0)
+        WHEN EXISTS (SELECT 1 FROM drops d WHERE d.product_name = p.name) THEN FALSE
+        ELSE p.stock IS NULL OR p.stock > (
+            SELECT COALESCE(SUM(r.quantity), 0) FROM stock_reservations r
+            WHERE r.product_name = p.name AND r.expires_at > LOCALTIMESTAMP)
+    END`
+
+// SchedulePrice adds a new price version for the product, effective from the given moment.
+func (s *Storage) SchedulePrice(ctx context.Context, item string, price int, effectiveFrom time.Time) (*models.PricePoint, error) {
+	const op = "domain.repository.SchedulePrice"
+
+	point := models.PricePoint{Price: price, EffectiveFrom: effectiveFrom}
+	err := s.db.QueryRow(ctx, `
+        INSERT INTO product_prices (product_name, price, effective_from)
+        VALUES ($1, $2, $3)
+        RETURNING created_at`, item, price, effectiveFrom).Scan(&point.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case foreignKeyViolation:
+				return nil, fmt.Errorf("%s: %w", op, ErrItemNotFound)
+			case uniqueViolation:
+				return nil, fmt.Errorf("%s: %w", op, ErrPriceExists)
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &point, nil
+}
+
+// PriceTimeline returns every price version of the product ordered by effective date.
+func (s *Storage) PriceTimeline(ctx context.Context, item string) ([]models.PricePoint, error) {
+	const op = "domain.repository.PriceTimeline"
+
+	var exists bool
+	err := s.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM products WHERE name = $1)", item).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, ErrItemNotFound)
+	}
+
+	rows, err := s.db.Query(ctx, `
+        SELECT price, effective_from, created_at FROM product_prices
+        WHERE product_name = $1
+        ORDER BY effective_from`, item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var timeline []models.PricePoint
+	for rows.Next() {
+		var point models.PricePoint
+		if err := rows.Scan(&point.Price, &point.EffectiveFrom, &point.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		timeline = append(timeline, point)
+	}
+
+	return timeline, rows.Err()
+}
+
+// productForSale returns the current price and stock of a product that is
+// still on sale; archived products are reported as not found.
+func productForSale(ctx context.Context, tx pgx.Tx, item string) (int, *int, error) {
+	var (
+		price int
+		stock *int
+	)
+	err := tx.QueryRow(ctx, "SELECT "+currentPriceSQL+", p.stock FROM products p WHERE p.name = $1 AND p.archived_at IS NULL", item).Scan(&price, &stock)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil, ErrItemNotFound
+		}
+		return 0, nil, fmt.Errorf("failed to get item price: %w", err)
+	}
+
+	return price, stock, nil
+}
+
+// ExportCatalog returns the products on sale with their current prices, ordered by name.
+func (s *Storage) ExportCatalog(ctx context.Context) ([]models.CatalogEntry, error) {
+	const op = "domain.repository.ExportCatalog"
+
+	rows, err := s.db.Query(ctx, `
+        SELECT p.name, `+currentPriceSQL+` FROM products p
+        WHERE p.archived_at IS NULL
+        ORDER BY p.name`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []models.CatalogEntry
+	for rows.Next() {
+		var entry models.CatalogEntry
+		if err := rows.Scan(&entry.Name, &entry.Price); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// ImportCatalog brings the stored catalog in line with the given entries and
+// returns the changes made. Products missing from the entries are archived.
+// With dryRun the changes are computed but nothing is written.
+func (s *Storage) ImportCatalog(ctx context.Context, entries []models.CatalogEntry, dryRun bool) ([]models.CatalogChange, error) {
+	const op = "domain.repository.ImportCatalog"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil || dryRun {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rows, err := tx.Query(ctx, `
+        SELECT p.name, `+currentPriceSQL+`, p.archived_at IS NOT NULL FROM products p
+        ORDER BY p.name
+        FOR UPDATE`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var current []models.CatalogProduct
+	for rows.Next() {
+		var product models.CatalogProduct
+		if err = rows.Scan(&product.Name, &product.Price, &product.Archived); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		current = append(current, product)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	changes := models.DiffCatalog(current, entries)
+	if dryRun {
+		return changes, nil
+	}
+
+	for _, change := range changes {
+		if err = applyCatalogChange(ctx, tx, change); err != nil {
+			return nil, fmt.Errorf("%s: %s %s: %w", op, change.Kind, change.Name, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return changes, nil
+}
+
+// applyCatalogChange writes one catalog change. Price changes take effect
+// immediately as a new price version, so the price history stays intact.
+func applyCatalogChange(ctx context.Context, tx pgx.Tx, change models.CatalogChange) error {
+	switch change.Kind {
+	case models.CatalogCreate:
+		if _, err := tx.Exec(ctx, "INSERT INTO products (name, price) VALUES ($1, $2)", change.Name, change.Price); err != nil {
+			return err
+		}
+	case models.CatalogRestore:
+		if _, err := tx.Exec(ctx, "UPDATE products SET archived_at = NULL WHERE name = $1", change.Name); err != nil {
+			return err
+		}
+		if change.OldPrice == change.Price {
+			return nil
+		}
+	case models.CatalogArchive:
+		_, err := tx.Exec(ctx, "UPDATE products SET archived_at = LOCALTIMESTAMP WHERE name = $1", change.Name)
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE products SET price = $2 WHERE name = $1", change.Name, change.Price); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `
+        INSERT INTO product_prices (product_name, price, effective_from)
+        VALUES ($1, $2, LOCALTIMESTAMP)`, change.Name, change.Price)
+	return err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// lockUsers locks the given users' rows in id order, so that transactions
+// touching the same pair of users from opposite sides cannot deadlock.
+func lockUsers(ctx context.Context, tx pgx.Tx, userIDs ...int) error {
+	rows, err := tx.Query(ctx, "SELECT id FROM users WHERE id = ANY($1) ORDER BY id FOR UPDATE", userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to lock users: %w", err)
+	}
+
+	locked := 0
+	for rows.Next() {
+		locked++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to lock users: %w", err)
+	}
+
+	distinct := make(map[int]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		distinct[id] = struct{}{}
+	}
+	if locked != len(distinct) {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// debitCoins takes coins from the user's balance, refusing to go negative.
+func debitCoins(ctx context.Context, tx pgx.Tx, userID, amount int) error {
+	var coins int
+	err := tx.QueryRow(ctx, "UPDATE users SET coins = coins - $1 WHERE id = $2 AND coins >= $1 RETURNING coins", amount, userID).Scan(&coins)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInsufficientFunds
+		}
+		return fmt.Errorf("failed to debit coins: %w", err)
+	}
+
+	return nil
+}
+
+// creditCoins adds coins to the user's balance.
+func creditCoins(ctx context.Context, tx pgx.Tx, userID, amount int) error {
+	tag, err := tx.Exec(ctx, "UPDATE users SET coins = coins + $1 WHERE id = $2", amount, userID)
+	if err != nil {
+		return fmt.Errorf("failed to credit coins: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// GrantCoins credits the user with coins issued by the company, for example
+// by the HR system. The grant is recorded as a transaction from the user to
+// themselves, like refunds.
+func (s *Storage) GrantCoins(ctx context.Context, userID, amount int) error {
+	const op = "domain.repository.GrantCoins"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = creditCoins(ctx, tx, userID, amount); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO transactions (from_user_id, to_user_id, amount, kind)
+        VALUES ($1, $1, $2, $3)`, userID, amount, models.TransactionGrant)
+	if err != nil {
+		return fmt.Errorf("%s: failed to insert grant transaction: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// unusableDirectoryPasswordHash is stored for users created from a
+// directory. It is not a bcrypt hash, so their password is only ever
+// checked by the directory.
+const unusableDirectoryPasswordHash = "!directory"
+
+// SyncDirectoryUser returns the local user linked to the directory entry's DN
+// and stores the entry's attributes as the user's profile. A user signing in
+// from the directory for the first time is linked to the existing local user
+// of the entry's name only with linkExisting (ErrIdentityNotLinked otherwise,
+// or if that user is linked to another entry), and created only with
+// provision (ErrUserNotFound otherwise). A new user's name must pass
+// models.ValidateUsername, as on registration.
+func (s *Storage) SyncDirectoryUser(ctx context.Context, entry *models.DirectoryUser, linkExisting, provision bool) (int, error) {
+	const op = "domain.repository.SyncDirectoryUser"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var userID int
+	err = tx.QueryRow(ctx, `
+        SELECT u.id
+        FROM directory_profiles p
+        JOIN users u ON u.id = p.user_id
+        WHERE p.source = $1 AND p.dn = $2
+        FOR UPDATE OF u`, entry.Source, entry.DN).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		userID, err = linkDirectoryUser(ctx, tx, entry, linkExisting, provision)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO directory_profiles (user_id, source, dn, display_name, email, department)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        ON CONFLICT (user_id) DO UPDATE SET
+            source = EXCLUDED.source,
+            dn = EXCLUDED.dn,
+            display_name = EXCLUDED.display_name,
+            email = EXCLUDED.email,
+            department = EXCLUDED.department,
+            synced_at = LOCALTIMESTAMP`,
+		userID, entry.Source, entry.DN, entry.DisplayName, entry.Email, entry.Department)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return userID, nil
+}
+
+// linkDirectoryUser finds the local user for an entry that is not linked
+// yet, or creates it.
+func linkDirectoryUser(ctx context.Context, tx pgx.Tx, entry *models.DirectoryUser, linkExisting, provision bool) (int, error) {
+	var (
+		userID int
+		linked bool
+	)
+	err := tx.QueryRow(ctx, `
+        SELECT u.id, p.user_id IS NOT NULL
+        FROM users u
+        LEFT JOIN directory_profiles p ON p.user_id = u.id
+        WHERE u.username = $1
+        FOR UPDATE OF u`, entry.Username).Scan(&userID, &linked)
+	switch {
+	case err == nil && (linked || !linkExisting):
+		return 0, ErrIdentityNotLinked
+	case err == nil:
+		return userID, nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return 0, err
+	case !provision:
+		return 0, ErrUserNotFound
+	}
+
+	if err := models.ValidateUsername(entry.Username); err != nil {
+		return 0, err
+	}
+
+	err = tx.QueryRow(ctx, insertUserSQL, entry.Username, unusableDirectoryPasswordHash).Scan(&userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return 0, ErrUserExists
+		}
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// GetUserProfile returns the directory attributes of the user with the given
+// name, or ErrProfileNotFound if the user never signed in from a directory.
+func (s *Storage) GetUserProfile(ctx context.Context, username string) (*models.UserProfile, error) {
+	const op = "domain.repository.GetUserProfile"
+
+	var (
+		profile  models.UserProfile
+		syncedAt *time.Time
+	)
+	err := s.db.QueryRow(ctx, `
+        SELECT u.username, COALESCE(p.source, ''), COALESCE(p.dn, ''), COALESCE(p.display_name, ''),
+               COALESCE(p.email, ''), COALESCE(p.department, ''), p.synced_at
+        FROM users u
+        LEFT JOIN directory_profiles p ON p.user_id = u.id
+        WHERE u.username = $1`, username).
+		Scan(&profile.Username, &profile.Source, &profile.DN, &profile.DisplayName, &profile.Email, &profile.Department, &syncedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrUserNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if syncedAt == nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrProfileNotFound)
+	}
+	profile.SyncedAt = *syncedAt
+
+	return &profile, nil
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const selectDrops = `
+	SELECT id, product_name, starts_at, ends_at, stock, remaining, per_user_limit, created_at
+	FROM drops`
+
+func (s *Storage) CreateDrop(ctx context.Context, drop *models.Drop) (*models.Drop, error) {
+	const op = "domain.repository.CreateDrop"
+
+	created := *drop
+	created.Remaining = drop.Stock
+	err := s.db.QueryRow(ctx, `
+        INSERT INTO drops (product_name, starts_at, ends_at, stock, remaining, per_user_limit)
+        VALUES ($1, $2, $3, $4, $4, $5)
+        RETURNING id, created_at`,
+		drop.Product, drop.StartsAt, drop.EndsAt, drop.Stock, drop.PerUserLimit,
+	).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return nil, fmt.Errorf("%s: %w", op, ErrItemNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &created, nil
+}
+
+// ListDrops returns the drops that have not ended yet, soonest first.
+func (s *Storage) ListDrops(ctx context.Context) ([]models.Drop, error) {
+	const op = "domain.repository.ListDrops"
+
+	rows, err := s.db.Query(ctx, selectDrops+`
+        WHERE ends_at IS NULL OR ends_at > LOCALTIMESTAMP
+        ORDER BY starts_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var drops []models.Drop
+	for rows.Next() {
+		var d models.Drop
+		if err := rows.Scan(&d.ID, &d.Product, &d.StartsAt, &d.EndsAt, &d.Stock, &d.Remaining, &d.PerUserLimit, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		drops = append(drops, d)
+	}
+
+	return drops, rows.Err()
+}
+
+// claimDrop reserves quantity units of the item's current drop for the user
+// who receives them, which for a gift is the recipient rather than the buyer.
+// A sold-out drop gives way to the next one scheduled for the item. It returns
+// nil if the item is not sold through drops.
+//
+// The drop row is locked for the rest of the transaction, so concurrent
+// buyers are served one at a time in the order they reached the lock: the
+// stock cannot be oversold and the per-user limit is checked against every
+// allocation committed before.
+func claimDrop(ctx context.Context, tx pgx.Tx, item string, userID, quantity int) (*models.Drop, error) {
+	var d models.Drop
+	err := tx.QueryRow(ctx, selectDrops+`
+        WHERE product_name = $1 AND (ends_at IS NULL OR ends_at > LOCALTIMESTAMP)
+        ORDER BY remaining = 0, starts_at
+        LIMIT 1
+        FOR UPDATE`, item).
+		Scan(&d.ID, &d.Product, &d.StartsAt, &d.EndsAt, &d.Stock, &d.Remaining, &d.PerUserLimit, &d.CreatedAt)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get drop: %w", err)
+		}
+
+		var ended bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM drops WHERE product_name = $1)", item).Scan(&ended)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check drops: %w", err)
+		}
+		if ended {
+			return nil, models.ErrDropEnded
+		}
+		return nil, nil
+	}
+
+	var allocated int
+	err = tx.QueryRow(ctx, `
+        SELECT COALESCE(SUM(quantity), 0) FROM drop_allocations
+        WHERE drop_id = $1 AND user_id = $2`, d.ID, userID).Scan(&allocated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count drop allocations: %w", err)
+	}
+
+	if err := d.CheckAllocation(time.Now().UTC(), allocated, quantity); err != nil {
+		return nil, err
+	}
+
+	if d.Remaining < quantity {
+		return nil, ErrOutOfStock
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE drops SET remaining = remaining - $1 WHERE id = $2", quantity, d.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update drop stock: %w", err)
+	}
+	d.Remaining -= quantity
+
+	return &d, nil
+}
+
+// releaseDropAllocations returns the units allocated to the order back to their drops.
+func releaseDropAllocations(ctx context.Context, tx pgx.Tx, orderID int) error {
+	_, err := tx.Exec(ctx, `
+        WITH released AS (
+            DELETE FROM drop_allocations WHERE order_id = $1
+            RETURNING drop_id, quantity
+        )
+        UPDATE drops d SET remaining = d.remaining + r.quantity
+        FROM (SELECT drop_id, SUM(quantity) AS quantity FROM released GROUP BY drop_id) r
+        WHERE d.id = r.drop_id`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to release drop allocations: %w", err)
+	}
+
+	return nil
+}
@@ -5,6 +5,61 @@ import (
 )
 
 var (
-	ErrUserNotFound = errors.New("user not found")
-	ErrUnauthorized = errors.New("user unauthorized")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserExists        = errors.New("username is already taken")
+	ErrUnauthorized      = errors.New("user unauthorized")
+	ErrItemNotFound      = errors.New("item not found")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrInvalidQuantity   = errors.New("invalid quantity")
+	ErrOutOfStock        = errors.New("item out of stock")
+	ErrPriceExists       = errors.New("a price is already scheduled for this moment")
+
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrOrderNotCancellable     = errors.New("order can no longer be cancelled")
+	ErrItemNotOwned            = errors.New("item is not in the user's inventory")
+
+	ErrPromoCodeNotFound = errors.New("promo code not found")
+	ErrPromoCodeExists   = errors.New("promo code already exists")
+
+	ErrItemInStock     = errors.New("item is in stock")
+	ErrNotOnWaitlist   = errors.New("user is not on the waitlist")
+	ErrInvalidRestock  = errors.New("restock quantity must be positive")
+	ErrStockNotTracked = errors.New("item has no stock tracking")
+
+	ErrWishlistItemExists   = errors.New("item is already on the wishlist")
+	ErrWishlistItemNotFound = errors.New("item is not on the wishlist")
+	ErrWishlistPrivate      = errors.New("wishlist is not shared")
+
+	ErrListingNotFound = errors.New("listing not found")
+
+	ErrAuctionNotFound = errors.New("auction not found")
+
+	ErrRaffleNotFound = errors.New("raffle not found")
+	ErrRaffleNotOpen  = errors.New("raffle is not open")
+
+	ErrBundleNotFound = errors.New("bundle not found")
+	ErrBundleExists   = errors.New("bundle already exists")
+
+	ErrInviteInvalid = errors.New("invite code is invalid, used or expired")
+
+	ErrSessionNotFound = errors.New("session not found")
+
+	ErrResetTokenInvalid         = errors.New("password reset token is invalid, used or expired")
+	ErrPasswordManagedExternally = errors.New("password is managed by single sign-on or the directory")
+
+	ErrLoginFailuresNotFound = errors.New("no failed login attempts recorded")
+
+	ErrSSOStateInvalid   = errors.New("single sign-on state is invalid, used or expired")
+	ErrIdentityNotLinked = errors.New("a local user with this name exists and is not linked to the identity provider account")
+
+	ErrProfileNotFound = errors.New("user has no directory profile")
+
+	ErrTwoFactorNotEnrolled      = errors.New("two-factor authentication is not enrolled")
+	ErrTwoFactorEnabled          = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorChallengeInvalid = errors.New("two-factor challenge is invalid, used or expired")
+
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrServiceAccountExists   = errors.New("service account already exists")
+	ErrAPIKeyNotFound         = errors.New("API key not found")
 )
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+)
+
+const selectGifts = `
+	SELECT g.order_id, su.username, ru.username, i.item_name, i.quantity, g.message, g.anonymous, g.created_at
+	FROM gifts g
+	JOIN orders o ON o.id = g.order_id
+	JOIN order_items i ON i.order_id = g.order_id
+	JOIN users su ON su.id = g.sender_id
+	JOIN users ru ON ru.id = g.recipient_id`
+
+// GetUserGifts returns the gifts the user received and sent, newest first.
+// Gifts from cancelled orders are left out.
+func (s *Storage) GetUserGifts(ctx context.Context, userID int) (received, sent []models.GiftRecord, err error) {
+	const op = "domain.repository.GetUserGifts"
+
+	received, err = s.queryGifts(ctx, selectGifts+" WHERE g.recipient_id = $1 AND o.status <> $2 ORDER BY g.created_at DESC, g.id DESC",
+		userID, models.OrderStatusCancelled)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sent, err = s.queryGifts(ctx, selectGifts+" WHERE g.sender_id = $1 AND o.status <> $2 ORDER BY g.created_at DESC, g.id DESC",
+		userID, models.OrderStatusCancelled)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return received, sent, nil
+}
+
+func (s *Storage) queryGifts(ctx context.Context, query string, args ...any) ([]models.GiftRecord, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var gifts []models.GiftRecord
+	for rows.Next() {
+		var g models.GiftRecord
+		if err := rows.Scan(&g.OrderID, &g.Sender, &g.Recipient, &g.Item, &g.Quantity, &g.Message, &g.Anonymous, &g.CreatedAt); err != nil {
+			return nil, err
+		}
+		gifts = append(gifts, g)
+	}
+
+	return gifts, rows.Err()
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// reservedByOthersSQL sums the units of product $2 held for waitlist
+// subscribers other than user $3 by reservations that have not expired.
+const reservedByOthersSQL = `(
+        SELECT COALESCE(SUM(r.quantity), 0) FROM stock_reservations r
+        WHERE r.product_name = $2 AND r.user_id <> $3 AND r.expires_at > LOCALTIMESTAMP)`
+
+// takeStock decrements the stock of a product that has limited stock. Units
+// reserved for anyone but userID, the user who receives the items, are not
+// available; that user's own reservations are used up by as many units as
+// the purchase takes.
+func takeStock(ctx context.Context, tx pgx.Tx, item string, userID, quantity int) error {
+	tag, err := tx.Exec(ctx, `
+        UPDATE products SET stock = stock - $1
+        WHERE name = $2 AND stock - `+reservedByOthersSQL+` >= $1`, quantity, item, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update stock: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrOutOfStock
+	}
+
+	// The product row is locked by the update above, so reservations of the
+	// item cannot change until the transaction ends. Reservations expiring
+	// first are used first.
+	_, err = tx.Exec(ctx, `
+        WITH held AS (
+            SELECT id, quantity,
+                   SUM(quantity) OVER (ORDER BY expires_at, id) - quantity AS before
+            FROM stock_reservations
+            WHERE product_name = $1 AND user_id = $2 AND expires_at > LOCALTIMESTAMP
+        ), used AS (
+            DELETE FROM stock_reservations r USING held h
+            WHERE r.id = h.id AND h.before + h.quantity <= $3
+        )
+        UPDATE stock_reservations r SET quantity = h.before + h.quantity - $3
+        FROM held h
+        WHERE r.id = h.id AND h.before < $3 AND h.before + h.quantity > $3`, item, userID, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to release stock reservation: %w", err)
+	}
+
+	return nil
+}
+
+// returnStock puts items back into stock. Products without stock tracking are left untouched.
+func returnStock(ctx context.Context, tx pgx.Tx, item string, quantity int) error {
+	_, err := tx.Exec(ctx, "UPDATE products SET stock = stock + $1 WHERE name = $2 AND stock IS NOT NULL", quantity, item)
+	if err != nil {
+		return fmt.Errorf("failed to return stock: %w", err)
+	}
+
+	return nil
+}
+
+// removeFromInventory decrements the user's inventory row and deletes it once it reaches zero.
+func removeFromInventory(ctx context.Context, tx pgx.Tx, userID int, item string, quantity int) error {
+	tag, err := tx.Exec(ctx, `
+        UPDATE inventory SET quantity = quantity - $1
+        WHERE user_id = $2 AND item_name = $3 AND quantity >= $1`, quantity, userID, item)
+	if err != nil {
+		return fmt.Errorf("failed to update inventory: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrItemNotOwned
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM inventory WHERE user_id = $1 AND item_name = $2 AND quantity <= 0", userID, item)
+	if err != nil {
+		return fmt.Errorf("failed to clean up inventory: %w", err)
+	}
+
+	return nil
+}
+
+// addToInventory increments the user's inventory row, creating it if needed.
+func addToInventory(ctx context.Context, tx pgx.Tx, userID int, item string, quantity int) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO inventory (user_id, item_name, quantity)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id, item_name)
+        DO UPDATE SET quantity = inventory.quantity + EXCLUDED.quantity`, userID, item, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to add item to inventory: %w", err)
+	}
+
+	return nil
+}
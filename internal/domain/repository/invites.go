@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const selectInvites = `
+	SELECT i.code, c.username, i.created_at, i.expires_at, u.username, i.used_at
+	FROM invites i
+	JOIN users c ON c.id = i.created_by
+	LEFT JOIN users u ON u.id = i.used_by`
+
+// CreateInvite stores a new invite code issued by the given user.
+func (s *Storage) CreateInvite(ctx context.Context, createdBy int, code string, expiresAt *time.Time) (*models.Invite, error) {
+	const op = "domain.repository.CreateInvite"
+
+	var id string
+	err := s.db.QueryRow(ctx, `
+        INSERT INTO invites (code, created_by, expires_at)
+        VALUES ($1, $2, $3)
+        RETURNING code`, code, createdBy, expiresAt).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	invite, err := scanInvite(s.db.QueryRow(ctx, selectInvites+" WHERE i.code = $1", id))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return invite, nil
+}
+
+// ListInvites returns all invite codes, newest first.
+func (s *Storage) ListInvites(ctx context.Context) ([]models.Invite, error) {
+	const op = "domain.repository.ListInvites"
+
+	rows, err := s.db.Query(ctx, selectInvites+" ORDER BY i.created_at DESC, i.code")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	invites := make([]models.Invite, 0)
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		invites = append(invites, *invite)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return invites, nil
+}
+
+// CreateInvitedUser creates the user and redeems the invite code in one
+// transaction, so a code can never be used twice.
+func (s *Storage) CreateInvitedUser(ctx context.Context, username, passwordHash, code string) (int, error) {
+	const op = "domain.repository.CreateInvitedUser"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var valid bool
+	err = tx.QueryRow(ctx, `
+        SELECT used_by IS NULL AND (expires_at IS NULL OR expires_at > LOCALTIMESTAMP)
+        FROM invites WHERE code = $1
+        FOR UPDATE`, code).Scan(&valid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrInviteInvalid
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if !valid {
+		err = ErrInviteInvalid
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var userID int
+	err = tx.QueryRow(ctx, insertUserSQL, username, passwordHash).Scan(&userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return 0, fmt.Errorf("%s: %w", op, ErrUserExists)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE invites SET used_by = $2, used_at = LOCALTIMESTAMP WHERE code = $1", code, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return userID, nil
+}
+
+func scanInvite(row pgx.Row) (*models.Invite, error) {
+	var invite models.Invite
+	err := row.Scan(&invite.Code, &invite.CreatedBy, &invite.CreatedAt, &invite.ExpiresAt, &invite.UsedBy, &invite.UsedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInviteInvalid
+		}
+		return nil, err
+	}
+
+	return &invite, nil
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const selectLoginFailures = `
+	SELECT kind, key, failures, last_failure_at, locked_until FROM login_failures`
+
+// ReserveLoginAttempt counts an attempt against every key before the
+// credentials are checked. The keys' rows stay locked while their backoff and
+// lockout are checked, so concurrent attempts are counted one after another.
+// If any key may not try yet, nothing is counted and a
+// *models.LoginThrottledError is returned. An attempt whose credentials turn
+// out to be right is given back with ReleaseLoginAttempt.
+func (s *Storage) ReserveLoginAttempt(ctx context.Context, keys []models.ThrottleKey, policy models.LoginThrottlePolicy, now time.Time) (*models.LoginAttempt, error) {
+	const op = "domain.repository.ReserveLoginAttempt"
+
+	// Timestamps are stored with microseconds, and ReleaseLoginAttempt
+	// compares them.
+	attempt := &models.LoginAttempt{At: now.Truncate(time.Microsecond)}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var retryAt time.Time
+	for _, key := range keys {
+		var previous *models.LoginFailures
+		previous, err = lockLoginFailures(ctx, tx, key, attempt.At)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if at := policy.RetryAt(previous); at.After(attempt.At) && at.After(retryAt) {
+			retryAt = at
+		}
+		attempt.Previous = append(attempt.Previous, *previous)
+	}
+	if !retryAt.IsZero() {
+		err = &models.LoginThrottledError{RetryAt: retryAt}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, key := range keys {
+		var failures *models.LoginFailures
+		failures, err = recordLoginFailure(ctx, tx, key, policy.Limit(key.Kind), policy.Lockout, attempt.At)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		attempt.Failures = append(attempt.Failures, *failures)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return attempt, nil
+}
+
+// ReleaseLoginAttempt gives back an attempt reserved with
+// ReserveLoginAttempt. A key that failed nothing since is restored as it was;
+// otherwise only the attempt is taken off its count.
+func (s *Storage) ReleaseLoginAttempt(ctx context.Context, attempt *models.LoginAttempt) error {
+	const op = "domain.repository.ReleaseLoginAttempt"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	for _, previous := range attempt.Previous {
+		_, err = tx.Exec(ctx, `
+            UPDATE login_failures SET
+                failures = CASE WHEN last_failure_at = $3 THEN $4 ELSE GREATEST(failures - 1, 0) END,
+                last_failure_at = CASE WHEN last_failure_at = $3 THEN $5 ELSE last_failure_at END,
+                locked_until = CASE WHEN last_failure_at = $3 THEN $6 ELSE locked_until END
+            WHERE kind = $1 AND key = $2`,
+			previous.Kind, previous.Key, attempt.At, previous.Failures, previous.LastFailureAt, previous.LockedUntil)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM login_failures WHERE kind = $1 AND key = $2 AND failures = 0", previous.Kind, previous.Key)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// lockLoginFailures locks the key's row and returns its failures. A key
+// without failures gets an empty row at now, so that it can be locked too;
+// it is removed again if the attempt is not counted after all.
+func lockLoginFailures(ctx context.Context, tx pgx.Tx, key models.ThrottleKey, now time.Time) (*models.LoginFailures, error) {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO login_failures (kind, key, failures, last_failure_at) VALUES ($1, $2, 0, $3)
+        ON CONFLICT (kind, key) DO NOTHING`, key.Kind, key.Key, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock login failures: %w", err)
+	}
+
+	failures, err := scanLoginFailure(tx.QueryRow(ctx, selectLoginFailures+`
+        WHERE kind = $1 AND key = $2
+        FOR UPDATE`, key.Kind, key.Key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock login failures: %w", err)
+	}
+
+	return failures, nil
+}
+
+// recordLoginFailure counts a failed login for the key at now. Failures older
+// than lockout are forgotten first; reaching limit locks the key out until
+// now plus lockout.
+func recordLoginFailure(ctx context.Context, tx pgx.Tx, key models.ThrottleKey, limit int, lockout time.Duration, now time.Time) (*models.LoginFailures, error) {
+	failures, err := scanLoginFailure(tx.QueryRow(ctx, `
+        INSERT INTO login_failures AS f (kind, key, failures, last_failure_at, locked_until)
+        VALUES ($1, $2, 1, $3, CASE WHEN 1 >= $4 THEN $6::timestamp END)
+        ON CONFLICT (kind, key) DO UPDATE SET
+            failures = CASE WHEN f.last_failure_at < $5 THEN 1 ELSE f.failures + 1 END,
+            last_failure_at = EXCLUDED.last_failure_at,
+            locked_until = CASE
+                WHEN (CASE WHEN f.last_failure_at < $5 THEN 1 ELSE f.failures + 1 END) >= $4 THEN $6
+                WHEN f.last_failure_at < $5 THEN NULL
+                ELSE f.locked_until
+            END
+        RETURNING kind, key, failures, last_failure_at, locked_until`,
+		key.Kind, key.Key, now, limit, now.Add(-lockout), now.Add(lockout)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return failures, nil
+}
+
+// ListLoginFailures returns the keys that failed since the given moment or
+// are still locked out, most recent first.
+func (s *Storage) ListLoginFailures(ctx context.Context, since time.Time) ([]models.LoginFailures, error) {
+	const op = "domain.repository.ListLoginFailures"
+
+	rows, err := s.db.Query(ctx, selectLoginFailures+`
+        WHERE last_failure_at >= $1 OR locked_until > LOCALTIMESTAMP
+        ORDER BY last_failure_at DESC, kind, key`, since)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	failures, err := scanLoginFailures(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return failures, nil
+}
+
+// ClearLoginFailures forgets the failures of the key, lifting its backoff
+// and lockout.
+func (s *Storage) ClearLoginFailures(ctx context.Context, key models.ThrottleKey) error {
+	const op = "domain.repository.ClearLoginFailures"
+
+	tag, err := s.db.Exec(ctx, "DELETE FROM login_failures WHERE kind = $1 AND key = $2", key.Kind, key.Key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrLoginFailuresNotFound)
+	}
+
+	return nil
+}
+
+// PurgeLoginFailures deletes failures recorded before the given moment whose
+// lockout, if any, is over.
+func (s *Storage) PurgeLoginFailures(ctx context.Context, before time.Time) error {
+	const op = "domain.repository.PurgeLoginFailures"
+
+	_, err := s.db.Exec(ctx, `
+        DELETE FROM login_failures
+        WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until <= LOCALTIMESTAMP)`, before)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func scanLoginFailures(rows pgx.Rows) ([]models.LoginFailures, error) {
+	defer rows.Close()
+
+	failures := make([]models.LoginFailures, 0)
+	for rows.Next() {
+		f, err := scanLoginFailure(rows)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, *f)
+	}
+
+	return failures, rows.Err()
+}
+
+func scanLoginFailure(row pgx.Row) (*models.LoginFailures, error) {
+	var f models.LoginFailures
+	if err := row.Scan(&f.Kind, &f.Key, &f.Failures, &f.LastFailureAt, &f.LockedUntil); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
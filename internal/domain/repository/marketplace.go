@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const selectListings = `
+	SELECT l.id, l.seller_id, u.username, l.item_name, l.quantity, l.remaining, l.price, l.status, l.created_at, l.updated_at
+	FROM market_listings l
+	JOIN users u ON u.id = l.seller_id`
+
+// CreateListing puts items from the seller's inventory up for sale. The
+// listed units leave the inventory until they are sold or the listing is
+// cancelled.
+func (s *Storage) CreateListing(ctx context.Context, listing *models.Listing) (*models.Listing, error) {
+	const op = "domain.repository.CreateListing"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback(ctx)
+			if err != nil {
+				log.Printf("%s: rollback failed: %v", op, err)
+			}
+		}
+	}()
+
+	if err = removeFromInventory(ctx, tx, listing.SellerID, listing.Item, listing.Quantity); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int
+	err = tx.QueryRow(ctx, `
+        INSERT INTO market_listings (seller_id, item_name, quantity, remaining, price)
+        VALUES ($1, $2, $3, $3, $4)
+        RETURNING id`, listing.SellerID, listing.Item, listing.Quantity, listing.Price).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create listing: %w", op, err)
+	}
+
+	created, err := scanListing(tx.QueryRow(ctx, selectListings+" WHERE l.id = $1", id))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// ListListings returns active listings, cheapest first, optionally for a
+// single item.
+func (s *Storage) ListListings(ctx context.Context, item *string) ([]models.Listing, error) {
+	const op = "domain.repository.ListListings"
+
+	rows, err := s.db.Query(ctx, selectListings+`
+        WHERE l.status = $1 AND ($2::VARCHAR IS NULL OR l.item_name = $2)
+        ORDER BY l.price, l.created_at, l.id`, models.ListingActive, item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	listings := make([]models.Listing, 0)
+	for rows.Next() {
+		listing, err := scanListing(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		listings = append(listings, *listing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return listings, nil
+}
+
+// CancelListing withdraws an active listing and returns the unsold units to
+// the seller's inventory. Only the seller may cancel a listing.
+func (s *Storage) CancelListing(ctx context.Context, listingID, sellerID int) (*models.Listing, error) {
+	const op = "domain.repository.CancelListing"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback(ctx)
+			if err != nil {
+				log.Printf("%s: rollback failed: %v", op, err)
+			}
+		}
+	}()
+
+	listing, err := scanListing(tx.QueryRow(ctx, selectListings+" WHERE l.id = $1 FOR UPDATE OF l", listingID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if listing.SellerID != sellerID {
+		err = ErrListingNotFound
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if listing.Status != models.ListingActive {
+		err = models.ErrListingNotActive
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = addToInventory(ctx, tx, listing.SellerID, listing.Item, listing.Remaining); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.QueryRow(ctx, `
+        UPDATE market_listings SET status = $1, remaining = 0, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2
+        RETURNING status, remaining, updated_at`, models.ListingCancelled, listingID).
+		Scan(&listing.Status, &listing.Remaining, &listing.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to cancel listing: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return listing, nil
+}
+
+// BuyListing trades units of an active listing in one transaction: the buyer
+// pays price * quantity, the seller receives it less the marketplace fee,
+// which is burned, and the items go to the buyer's inventory. The listing row
+// lock serialises buyers so that no unit is sold twice.
+func (s *Storage) BuyListing(ctx context.Context, listingID, buyerID, quantity, feePercent int) (*models.Trade, error) {
+	const op = "domain.repository.BuyListing"
+
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidQuantity)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback(ctx)
+			if err != nil {
+				log.Printf("%s: rollback failed: %v", op, err)
+			}
+		}
+	}()
+
+	listing, err := scanListing(tx.QueryRow(ctx, selectListings+" WHERE l.id = $1 FOR UPDATE OF l", listingID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch {
+	case listing.Status != models.ListingActive:
+		err = models.ErrListingNotActive
+	case listing.SellerID == buyerID:
+		err = models.ErrOwnListing
+	case listing.Remaining < quantity:
+		err = ErrOutOfStock
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	trade := &models.Trade{
+		ListingID: listing.ID,
+		BuyerID:   buyerID,
+		Seller:    listing.Seller,
+		Item:      listing.Item,
+		Quantity:  quantity,
+		Total:     listing.Price * quantity,
+	}
+	trade.Fee = models.MarketFee(trade.Total, feePercent)
+
+	if err = lockUsers(ctx, tx, buyerID, listing.SellerID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = debitCoins(ctx, tx, buyerID, trade.Total); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = creditCoins(ctx, tx, listing.SellerID, trade.Proceeds()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = addToInventory(ctx, tx, buyerID, listing.Item, quantity); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE market_listings
+        SET remaining = remaining - $1,
+            status = CASE WHEN remaining - $1 = 0 THEN $2 ELSE status END,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $3`, quantity, models.ListingSold, listingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to update listing: %w", op, err)
+	}
+
+	err = tx.QueryRow(ctx, `
+        INSERT INTO market_trades (listing_id, buyer_id, quantity, total, fee)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`, listingID, buyerID, quantity, trade.Total, trade.Fee).
+		Scan(&trade.ID, &trade.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to record trade: %w", op, err)
+	}
+
+	// The seller's share is a transfer between users; the fee is recorded
+	// like a shop purchase, as coins the buyer spent on themselves.
+	_, err = tx.Exec(ctx, `
+        INSERT INTO transactions (from_user_id, to_user_id, amount, item_name, quantity, unit_price, kind)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		buyerID, listing.SellerID, trade.Proceeds(), listing.Item, quantity, listing.Price, models.TransactionMarket)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to insert transaction: %w", op, err)
+	}
+
+	if trade.Fee > 0 {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO transactions (from_user_id, to_user_id, amount, item_name, quantity, kind)
+            VALUES ($1, $1, $2, $3, $4, $5)`,
+			buyerID, trade.Fee, listing.Item, quantity, models.TransactionFee)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to insert fee transaction: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return trade, nil
+}
+
+func scanListing(row pgx.Row) (*models.Listing, error) {
+	var l models.Listing
+	err := row.Scan(&l.ID, &l.SellerID, &l.Seller, &l.Item, &l.Quantity, &l.Remaining, &l.Price, &l.Status, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrListingNotFound
+		}
+		return nil, fmt.Errorf("failed to get listing: %w", err)
+	}
+
+	return &l, nil
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const selectOrders = `
+	SELECT o.id, o.user_id, o.status, o.total, o.discount, o.created_at, o.updated_at, o.delivered_at,
+	       g.recipient_id, ru.username, g.message, g.anonymous,
+	       i.item_name, i.quantity, i.unit_price, i.bundle_name
+	FROM orders o
+	JOIN order_items i ON i.order_id = o.id
+	LEFT JOIN gifts g ON g.order_id = o.id
+	LEFT JOIN users ru ON ru.id = g.recipient_id`
+
+func (s *Storage) ListUserOrders(ctx context.Context, userID int) ([]models.Order, error) {
+	const op = "domain.repository.ListUserOrders"
+
+	orders, err := s.queryOrders(ctx, selectOrders+" WHERE o.user_id = $1 ORDER BY o.id DESC, i.id", userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orders, nil
+}
+
+// ListOrders returns all orders, optionally filtered by status.
+func (s *Storage) ListOrders(ctx context.Context, status *models.OrderStatus) ([]models.Order, error) {
+	const op = "domain.repository.ListOrders"
+
+	var (
+		orders []models.Order
+		err    error
+	)
+	if status != nil {
+		orders, err = s.queryOrders(ctx, selectOrders+" WHERE o.status = $1 ORDER BY o.id DESC, i.id", *status)
+	} else {
+		orders, err = s.queryOrders(ctx, selectOrders+" ORDER BY o.id DESC, i.id")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return orders, nil
+}
+
+func (s *Storage) GetOrder(ctx context.Context, orderID int) (*models.Order, error) {
+	const op = "domain.repository.GetOrder"
+
+	orders, err := s.queryOrders(ctx, selectOrders+" WHERE o.id = $1 ORDER BY i.id", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(orders) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+	}
+
+	return &orders[0], nil
+}
+
+// UpdateOrderStatus moves the order to the next status if the lifecycle allows it.
+func (s *Storage) UpdateOrderStatus(ctx context.Context, orderID int, next models.OrderStatus) (*models.Order, error) {
+	const op = "domain.repository.UpdateOrderStatus"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var current models.OrderStatus
+	err = tx.QueryRow(ctx, "SELECT status FROM orders WHERE id = $1 FOR UPDATE", orderID).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get order status: %w", op, err)
+	}
+
+	if !current.CanTransitionTo(next) {
+		err = ErrInvalidStatusTransition
+		return nil, fmt.Errorf("%s: %s -> %s: %w", op, current, next, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE orders SET status = $1, updated_at = CURRENT_TIMESTAMP,
+            delivered_at = CASE WHEN $1 = 'delivered' THEN CURRENT_TIMESTAMP ELSE delivered_at END
+        WHERE id = $2`, next, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to update order status: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return s.GetOrder(ctx, orderID)
+}
+
+// CancelOrder cancels the order, refunds the exact coins paid, returns the
+// items to stock, removes them from the buyer's inventory and releases the
+// promo code redemption in a single transaction. If ownerID is set, only that
+// user's order can be cancelled.
+//
+//nolint:gocyclo
+func (s *Storage) CancelOrder(ctx context.Context, orderID int, ownerID *int, returnWindow time.Duration) (*models.Order, error) {
+	const op = "domain.repository.CancelOrder"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	order := models.Order{ID: orderID}
+	err = tx.QueryRow(ctx, "SELECT user_id, status, total, delivered_at FROM orders WHERE id = $1 FOR UPDATE", orderID).
+		Scan(&order.UserID, &order.Status, &order.Total, &order.DeliveredAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrOrderNotFound)
+		}
+		return nil, fmt.Errorf("%s: failed to get order: %w", op, err)
+	}
+
+	if ownerID != nil && *ownerID != order.UserID {
+		err = ErrOrderNotFound
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !order.CanCancel(time.Now(), returnWindow) {
+		err = ErrOrderNotCancellable
+		return nil, fmt.Errorf("%s: order is %s: %w", op, order.Status, err)
+	}
+
+	order.Items, err = orderItems(ctx, tx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = releaseDropAllocations(ctx, tx, orderID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Gifted items are taken back from the recipient.
+	var holderID int
+	err = tx.QueryRow(ctx, "SELECT COALESCE((SELECT recipient_id FROM gifts WHERE order_id = $1), $2)", orderID, order.UserID).Scan(&holderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get gift recipient: %w", op, err)
+	}
+
+	for _, item := range order.Items {
+		if err = removeFromInventory(ctx, tx, holderID, item.ItemName, item.Quantity); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, item.ItemName, err)
+		}
+
+		if err = returnStock(ctx, tx, item.ItemName, item.Quantity); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// The promo code use is given back, so the order no longer counts
+	// towards the code's usage caps.
+	_, err = tx.Exec(ctx, "DELETE FROM promo_redemptions WHERE order_id = $1", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to release promo code redemption: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET coins = coins + $1 WHERE id = $2", order.Total, order.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to refund coins: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO transactions (from_user_id, to_user_id, amount, order_id, kind)
+        VALUES ($1, $2, $3, $4, $5)`, order.UserID, order.UserID, order.Total, orderID, models.TransactionRefund)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to insert refund transaction: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE orders SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", models.OrderStatusCancelled, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to update order status: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return s.GetOrder(ctx, orderID)
+}
+
+func orderItems(ctx context.Context, tx pgx.Tx, orderID int) ([]models.OrderItem, error) {
+	rows, err := tx.Query(ctx, "SELECT item_name, quantity, unit_price, bundle_name FROM order_items WHERE order_id = $1 ORDER BY id", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.OrderItem
+	for rows.Next() {
+		var item models.OrderItem
+		if err := rows.Scan(&item.ItemName, &item.Quantity, &item.UnitPrice, &item.Bundle); err != nil {
+			return nil, fmt.Errorf("failed to scan order item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// queryOrders runs a query over orders joined with their items and groups
+// the rows into orders, keeping the order in which they were returned.
+func (s *Storage) queryOrders(ctx context.Context, query string, args ...any) ([]models.Order, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []models.Order
+	index := make(map[int]int)
+
+	for rows.Next() {
+		var (
+			order       models.Order
+			item        models.OrderItem
+			recipientID *int
+			recipient   *string
+			message     *string
+			anonymous   *bool
+		)
+		if err := rows.Scan(&order.ID, &order.UserID, &order.Status, &order.Total, &order.Discount, &order.CreatedAt, &order.UpdatedAt, &order.DeliveredAt,
+			&recipientID, &recipient, &message, &anonymous,
+			&item.ItemName, &item.Quantity, &item.UnitPrice, &item.Bundle); err != nil {
+			return nil, err
+		}
+
+		if recipientID != nil {
+			order.Gift = &models.Gift{RecipientID: *recipientID, Recipient: *recipient, Message: message, Anonymous: *anonymous}
+		}
+
+		i, ok := index[order.ID]
+		if !ok {
+			orders = append(orders, order)
+			i = len(orders) - 1
+			index[order.ID] = i
+		}
+		orders[i].Items = append(orders[i].Items, item)
+	}
+
+	return orders, rows.Err()
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ChangePassword stores the new password hash of the user and revokes all of
+// the user's sessions, so every device has to sign in again. Reset tokens that
+// have not been used yet stop working too. Users created by single sign-on or
+// from a directory have no password here: ErrPasswordManagedExternally is
+// returned for them.
+func (s *Storage) ChangePassword(ctx context.Context, userID int, passwordHash string) error {
+	const op = "domain.repository.ChangePassword"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = setPassword(ctx, tx, userID, passwordHash); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// CreatePasswordReset stores the hash of a reset token for the user with the
+// given name, issued by createdBy. Earlier unused tokens of the user are
+// dropped, so only the latest one works. Like ChangePassword, it returns
+// ErrPasswordManagedExternally for users without a password here.
+func (s *Storage) CreatePasswordReset(ctx context.Context, username, tokenHash string, createdBy int, expiresAt time.Time) error {
+	const op = "domain.repository.CreatePasswordReset"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var userID int
+	var passwordHash string
+	err = tx.QueryRow(ctx, "SELECT id, password_hash FROM users WHERE username = $1 FOR UPDATE", username).Scan(&userID, &passwordHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrUserNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if managedExternally(passwordHash) {
+		err = ErrPasswordManagedExternally
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM password_resets WHERE user_id = $1 AND used_at IS NULL", userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO password_resets (token_hash, user_id, created_by, expires_at)
+        VALUES ($1, $2, $3, $4)`, tokenHash, userID, createdBy, expiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// ResetPassword redeems the reset token and sets the new password hash, with
+// the same effect on sessions as ChangePassword. A token works once and only
+// until it expires; otherwise ErrResetTokenInvalid is returned.
+func (s *Storage) ResetPassword(ctx context.Context, tokenHash, passwordHash string) error {
+	const op = "domain.repository.ResetPassword"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var userID int
+	err = tx.QueryRow(ctx, `
+        UPDATE password_resets SET used_at = LOCALTIMESTAMP
+        WHERE token_hash = $1 AND used_at IS NULL AND expires_at > LOCALTIMESTAMP
+        RETURNING user_id`, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrResetTokenInvalid
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = setPassword(ctx, tx, userID, passwordHash); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+func setPassword(ctx context.Context, tx pgx.Tx, userID int, passwordHash string) error {
+	var current string
+	err := tx.QueryRow(ctx, "SELECT password_hash FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if managedExternally(current) {
+		return ErrPasswordManagedExternally
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE users SET password_hash = $1 WHERE id = $2", passwordHash, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := revokeUserAccessTokens(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE sessions SET revoked_at = LOCALTIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL", userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM password_resets WHERE user_id = $1 AND used_at IS NULL", userID)
+	return err
+}
+
+// managedExternally reports whether the password hash marks a user created by
+// single sign-on or from a directory.
+func managedExternally(passwordHash string) bool {
+	return passwordHash == unusablePasswordHash || passwordHash == unusableDirectoryPasswordHash
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+func (s *Storage) CreatePromoCode(ctx context.Context, promo *models.PromoCode) (*models.PromoCode, error) {
+	const op = "domain.repository.CreatePromoCode"
+
+	created := *promo
+	err := s.db.QueryRow(ctx, `
+        INSERT INTO promo_codes (code, discount_type, discount_value, product_name, valid_from, valid_until, max_uses, max_uses_per_user)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id, created_at`,
+		promo.Code, promo.DiscountType, promo.DiscountValue, promo.Product, promo.ValidFrom, promo.ValidUntil,
+		promo.MaxUses, promo.MaxUsesPerUser,
+	).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case uniqueViolation:
+				return nil, fmt.Errorf("%s: %w", op, ErrPromoCodeExists)
+			case foreignKeyViolation:
+				return nil, fmt.Errorf("%s: %w", op, ErrItemNotFound)
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &created, nil
+}
+
+// ListPromoCodeStats returns all promo codes with their redemption statistics.
+func (s *Storage) ListPromoCodeStats(ctx context.Context) ([]models.PromoCodeStats, error) {
+	const op = "domain.repository.ListPromoCodeStats"
+
+	rows, err := s.db.Query(ctx, `
+        SELECT p.id, p.code, p.discount_type, p.discount_value, p.product_name, p.valid_from, p.valid_until,
+               p.max_uses, p.max_uses_per_user, p.created_at,
+               COUNT(r.id), COUNT(DISTINCT r.user_id), COALESCE(SUM(r.discount), 0)
+        FROM promo_codes p
+        LEFT JOIN promo_redemptions r ON r.promo_code_id = p.id
+        GROUP BY p.id
+        ORDER BY p.id`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var stats []models.PromoCodeStats
+	for rows.Next() {
+		var st models.PromoCodeStats
+		if err := rows.Scan(&st.ID, &st.Code, &st.DiscountType, &st.DiscountValue, &st.Product, &st.ValidFrom, &st.ValidUntil,
+			&st.MaxUses, &st.MaxUsesPerUser, &st.CreatedAt,
+			&st.Redemptions, &st.UniqueUsers, &st.TotalDiscount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		stats = append(stats, st)
+	}
+
+	return stats, rows.Err()
+}
+
+// redeemablePromoCode locks the promo code row and checks that the user may
+// still apply it to item. Locking serialises concurrent redemptions so the
+// usage caps cannot be exceeded. Every redemption counts: CancelOrder deletes
+// the redemptions of cancelled orders.
+func redeemablePromoCode(ctx context.Context, tx pgx.Tx, code string, userID int, item string) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	err := tx.QueryRow(ctx, `
+        SELECT id, code, discount_type, discount_value, product_name, valid_from, valid_until, max_uses, max_uses_per_user
+        FROM promo_codes WHERE code = $1 FOR UPDATE`, code).
+		Scan(&promo.ID, &promo.Code, &promo.DiscountType, &promo.DiscountValue, &promo.Product, &promo.ValidFrom, &promo.ValidUntil,
+			&promo.MaxUses, &promo.MaxUsesPerUser)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPromoCodeNotFound
+		}
+		return nil, fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	if err := promo.CheckApplicable(time.Now(), item); err != nil {
+		return nil, err
+	}
+
+	var uses, userUses int
+	err = tx.QueryRow(ctx, `
+        SELECT COUNT(*), COUNT(*) FILTER (WHERE user_id = $2)
+        FROM promo_redemptions WHERE promo_code_id = $1`, promo.ID, userID).Scan(&uses, &userUses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count promo code redemptions: %w", err)
+	}
+
+	if (promo.MaxUses != nil && uses >= *promo.MaxUses) ||
+		(promo.MaxUsesPerUser != nil && userUses >= *promo.MaxUsesPerUser) {
+		return nil, models.ErrPromoUsageExhausted
+	}
+
+	return &promo, nil
+}
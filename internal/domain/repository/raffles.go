@@ -0,0 +1,427 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// selectRaffles keeps the seed secret while the raffle is open: it is only
+// read back once the raffle has been drawn or cancelled.
+const selectRaffles = `
+	SELECT r.id, r.product_name, r.prizes, r.ticket_price, r.max_tickets_per_user, r.draw_at, r.seed_commitment,
+	       CASE WHEN r.status <> 'open' THEN r.seed END, r.status,
+	       (SELECT COUNT(*) FROM raffle_tickets t WHERE t.raffle_id = r.id), r.drawn_at, r.created_at
+	FROM raffles r`
+
+// CreateRaffle stores the raffle with its secret seed and the commitment to
+// it, and takes the prizes out of stock, so the units cannot be sold in the
+// shop while they are raffled. No one receives them yet, so units reserved
+// for waitlist subscribers are not available.
+func (s *Storage) CreateRaffle(ctx context.Context, raffle *models.Raffle, seed string) (*models.Raffle, error) {
+	const op = "domain.repository.CreateRaffle"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, stock, err := productForSale(ctx, tx, raffle.Product)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if stock != nil {
+		if err = takeStock(ctx, tx, raffle.Product, 0, raffle.Prizes); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	created := *raffle
+	created.State = models.RaffleOpen
+	created.Seed = nil
+	err = tx.QueryRow(ctx, `
+        INSERT INTO raffles (product_name, prizes, ticket_price, max_tickets_per_user, draw_at, seed_commitment, seed)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at`,
+		raffle.Product, raffle.Prizes, raffle.TicketPrice, raffle.MaxTicketsPerUser, raffle.DrawAt, raffle.Commitment, seed,
+	).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to insert raffle: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return &created, nil
+}
+
+// ListRaffles returns open raffles drawing soonest first, followed by drawn
+// and cancelled ones, newest first.
+func (s *Storage) ListRaffles(ctx context.Context) ([]models.Raffle, error) {
+	const op = "domain.repository.ListRaffles"
+
+	rows, err := s.db.Query(ctx, selectRaffles+`
+        ORDER BY r.status <> $1, CASE WHEN r.status = $1 THEN r.draw_at END, r.draw_at DESC, r.id`, models.RaffleOpen)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	raffles := make([]models.Raffle, 0)
+	for rows.Next() {
+		raffle, err := scanRaffle(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		raffles = append(raffles, *raffle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return raffles, nil
+}
+
+// GetRaffle returns the raffle with all its tickets in number order, which
+// together with the revealed seed is everything needed to recompute the draw.
+func (s *Storage) GetRaffle(ctx context.Context, raffleID int) (*models.Raffle, []models.RaffleTicket, error) {
+	const op = "domain.repository.GetRaffle"
+
+	raffle, err := scanRaffle(s.db.QueryRow(ctx, selectRaffles+" WHERE r.id = $1", raffleID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.db.Query(ctx, `
+        SELECT t.number, u.username, t.prize_rank
+        FROM raffle_tickets t
+        JOIN users u ON u.id = t.user_id
+        WHERE t.raffle_id = $1
+        ORDER BY t.number`, raffleID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	tickets := make([]models.RaffleTicket, 0)
+	for rows.Next() {
+		var t models.RaffleTicket
+		if err := rows.Scan(&t.Number, &t.Holder, &t.PrizeRank); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tickets = append(tickets, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return raffle, tickets, nil
+}
+
+// BuyRaffleTickets debits the tickets' price from the user the same way a
+// purchase does and returns the numbers of the new tickets and the amount
+// debited. The raffle row lock serialises ticket sales, so numbers are
+// consecutive and the per-user limit holds under concurrent purchases.
+func (s *Storage) BuyRaffleTickets(ctx context.Context, raffleID, userID, count int) ([]int, int, error) {
+	const op = "domain.repository.BuyRaffleTickets"
+
+	if count <= 0 {
+		return nil, 0, fmt.Errorf("%s: %w", op, ErrInvalidQuantity)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback(ctx)
+			if err != nil {
+				log.Printf("%s: rollback failed: %v", op, err)
+			}
+		}
+	}()
+
+	raffle, err := scanRaffle(tx.QueryRow(ctx, selectRaffles+" WHERE r.id = $1 FOR UPDATE OF r", raffleID))
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var held int
+	err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM raffle_tickets WHERE raffle_id = $1 AND user_id = $2", raffleID, userID).Scan(&held)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: failed to count tickets: %w", op, err)
+	}
+
+	if err = raffle.CheckTickets(time.Now().UTC(), held, count); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	total := raffle.TicketPrice * count
+	if err = debitCoins(ctx, tx, userID, total); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	numbers := make([]int, count)
+	for i := range numbers {
+		numbers[i] = raffle.TicketsSold + i + 1
+		_, err = tx.Exec(ctx, "INSERT INTO raffle_tickets (raffle_id, number, user_id) VALUES ($1, $2, $3)",
+			raffleID, numbers[i], userID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: failed to insert ticket: %w", op, err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO transactions (from_user_id, to_user_id, amount, item_name, quantity, kind)
+        VALUES ($1, $1, $2, $3, $4, $5)`,
+		userID, total, raffle.Product, count, models.TransactionRaffle)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: failed to insert transaction: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, 0, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return numbers, total, nil
+}
+
+// CancelRaffle cancels an open raffle, refunds every ticket and returns the
+// prizes to stock.
+func (s *Storage) CancelRaffle(ctx context.Context, raffleID int) (*models.Raffle, error) {
+	const op = "domain.repository.CancelRaffle"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	raffle, err := scanRaffle(tx.QueryRow(ctx, selectRaffles+" WHERE r.id = $1 FOR UPDATE OF r", raffleID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if raffle.State != models.RaffleOpen {
+		err = ErrRaffleNotOpen
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := tx.Query(ctx, `
+        SELECT user_id, COUNT(*) FROM raffle_tickets WHERE raffle_id = $1 GROUP BY user_id ORDER BY user_id`, raffleID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get tickets: %w", op, err)
+	}
+
+	held := make(map[int]int)
+	var holders []int
+	for rows.Next() {
+		var userID, count int
+		if err = rows.Scan(&userID, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: failed to get tickets: %w", op, err)
+		}
+		held[userID] = count
+		holders = append(holders, userID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get tickets: %w", op, err)
+	}
+
+	for _, userID := range holders {
+		refund := raffle.TicketPrice * held[userID]
+		if err = creditCoins(ctx, tx, userID, refund); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO transactions (from_user_id, to_user_id, amount, item_name, quantity, kind)
+            VALUES ($1, $1, $2, $3, $4, $5)`,
+			userID, refund, raffle.Product, held[userID], models.TransactionRefund)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to insert transaction: %w", op, err)
+		}
+	}
+
+	if err = returnStock(ctx, tx, raffle.Product, raffle.Prizes); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE raffles SET status = $1 WHERE id = $2", models.RaffleCancelled, raffleID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to cancel raffle: %w", op, err)
+	}
+
+	cancelled, err := scanRaffle(tx.QueryRow(ctx, selectRaffles+" WHERE r.id = $1", raffleID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return cancelled, nil
+}
+
+// DrawDueRaffles draws every open raffle whose draw time has passed and
+// returns the drawn raffles. Each raffle is drawn in its own transaction;
+// raffles locked by a concurrent ticket sale or drawer are skipped and
+// picked up on the next run. A raffle that cannot be drawn is logged and
+// skipped, so it does not hold up the raffles due after it, and is retried
+// on the next run.
+func (s *Storage) DrawDueRaffles(ctx context.Context) ([]models.Raffle, error) {
+	const op = "domain.repository.DrawDueRaffles"
+
+	var drawn []models.Raffle
+	failed := make([]int, 0)
+	for {
+		raffleID, raffle, err := s.drawNextRaffle(ctx, failed)
+		switch {
+		case err != nil && raffleID != 0:
+			log.Printf("%s: failed to draw raffle %d: %v", op, raffleID, err)
+			failed = append(failed, raffleID)
+		case err != nil:
+			return drawn, fmt.Errorf("%s: %w", op, err)
+		case raffle == nil:
+			return drawn, nil
+		default:
+			drawn = append(drawn, *raffle)
+		}
+	}
+}
+
+// drawNextRaffle draws one due raffle that is not in skip with
+// models.DrawWinners, marks the winning tickets with their prize rank and
+// gives each winner one unit of the product; prizes left over for lack of
+// tickets return to stock. It returns nil if no raffle is due. If the raffle
+// cannot be drawn, its ID is returned together with the error.
+func (s *Storage) drawNextRaffle(ctx context.Context, skip []int) (int, *models.Raffle, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var (
+		raffleID, prizes int
+		product, seed    string
+	)
+	err = tx.QueryRow(ctx, `
+        SELECT r.id, r.product_name, r.prizes, r.seed
+        FROM raffles r
+        WHERE r.status = $1 AND r.draw_at <= LOCALTIMESTAMP AND r.id <> ALL($2)
+        ORDER BY r.draw_at, r.id
+        LIMIT 1
+        FOR UPDATE OF r SKIP LOCKED`, models.RaffleOpen, skip).Scan(&raffleID, &product, &prizes, &seed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		_ = tx.Rollback(ctx)
+		return 0, nil, nil
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get raffle: %w", err)
+	}
+
+	// Ticket sales take the raffle row lock, so the list is final.
+	rows, err := tx.Query(ctx, `
+        SELECT t.number, u.username
+        FROM raffle_tickets t
+        JOIN users u ON u.id = t.user_id
+        WHERE t.raffle_id = $1
+        ORDER BY t.number`, raffleID)
+	if err != nil {
+		return raffleID, nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+
+	var tickets []models.RaffleTicket
+	for rows.Next() {
+		var t models.RaffleTicket
+		if err = rows.Scan(&t.Number, &t.Holder); err != nil {
+			rows.Close()
+			return raffleID, nil, fmt.Errorf("failed to get tickets: %w", err)
+		}
+		tickets = append(tickets, t)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return raffleID, nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+
+	winners, err := models.DrawWinners(seed, tickets, prizes)
+	if err != nil {
+		return raffleID, nil, err
+	}
+
+	for i, number := range winners {
+		var userID int
+		err = tx.QueryRow(ctx, "UPDATE raffle_tickets SET prize_rank = $1 WHERE raffle_id = $2 AND number = $3 RETURNING user_id",
+			i+1, raffleID, number).Scan(&userID)
+		if err != nil {
+			return raffleID, nil, fmt.Errorf("failed to mark winning ticket: %w", err)
+		}
+
+		if err = addToInventory(ctx, tx, userID, product, 1); err != nil {
+			return raffleID, nil, err
+		}
+	}
+
+	if len(winners) < prizes {
+		if err = returnStock(ctx, tx, product, prizes-len(winners)); err != nil {
+			return raffleID, nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE raffles SET status = $1, drawn_at = LOCALTIMESTAMP WHERE id = $2", models.RaffleDrawn, raffleID)
+	if err != nil {
+		return raffleID, nil, fmt.Errorf("failed to draw raffle: %w", err)
+	}
+
+	raffle, err := scanRaffle(tx.QueryRow(ctx, selectRaffles+" WHERE r.id = $1", raffleID))
+	if err != nil {
+		return raffleID, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return raffleID, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return raffleID, raffle, nil
+}
+
+func scanRaffle(row pgx.Row) (*models.Raffle, error) {
+	var r models.Raffle
+	err := row.Scan(&r.ID, &r.Product, &r.Prizes, &r.TicketPrice, &r.MaxTicketsPerUser, &r.DrawAt, &r.Commitment,
+		&r.Seed, &r.State, &r.TicketsSold, &r.DrawnAt, &r.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRaffleNotFound
+		}
+		return nil, fmt.Errorf("failed to get raffle: %w", err)
+	}
+
+	return &r, nil
+}
@@ -11,6 +11,7 @@ import (
 	"merch-store-service/internal/infra/config"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type Storage struct {
@@ -54,11 +55,25 @@ func (s *Storage) GetUserCoins(ctx context.Context, userID int) (int, error) {
 }
 
 func (s *Storage) BuyItem(ctx context.Context, userID int, item string) error {
-	const op = "domain.repository.BuyItem"
+	_, err := s.Purchase(ctx, models.Purchase{UserID: userID, Item: item, Quantity: 1})
+	return err
+}
+
+// Purchase charges price * quantity, less any promo code discount, adds the
+// items to the user's inventory and places an order for them in a single
+// database transaction.
+//
+//nolint:gocyclo
+func (s *Storage) Purchase(ctx context.Context, p models.Purchase) (*models.Order, error) {
+	const op = "domain.repository.Purchase"
+
+	if p.Quantity <= 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidQuantity)
+	}
 
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
 	}
 	defer func() {
 		if err != nil {
@@ -66,40 +81,121 @@ func (s *Storage) BuyItem(ctx context.Context, userID int, item string) error {
 		}
 	}()
 
-	var price int
-	err = tx.QueryRow(ctx, "SELECT price FROM products WHERE name=$1", item).Scan(&price)
+	price, stock, err := productForSale(ctx, tx, p.Item)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("%s: item not found", op)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	drop, err := claimDrop(ctx, tx, p.Item, p.HolderID(), p.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if stock != nil {
+		err = takeStock(ctx, tx, p.Item, p.HolderID(), p.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	total := price * p.Quantity
+
+	var (
+		promo    *models.PromoCode
+		discount int
+	)
+	if p.PromoCode != "" {
+		promo, err = redeemablePromoCode(ctx, tx, p.PromoCode, p.UserID, p.Item)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-		return fmt.Errorf("%s: failed to get item price: %w", op, err)
+		discount = promo.Discount(total)
+		total -= discount
 	}
 
-	var userCoins int
-	err = tx.QueryRow(ctx, "UPDATE users SET coins = coins - $1 WHERE id = $2 AND coins >= $1 RETURNING coins", price, userID).Scan(&userCoins)
+	err = debitCoins(ctx, tx, p.UserID, total)
 	if err != nil {
-		return fmt.Errorf("%s: failed to update user coins: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = addToInventory(ctx, tx, p.HolderID(), p.Item, p.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM wishlist_items WHERE user_id = $1 AND product_name = $2", p.HolderID(), p.Item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to update wishlist: %w", op, err)
+	}
+
+	order := &models.Order{
+		UserID:   p.UserID,
+		Status:   models.OrderStatusPlaced,
+		Total:    total,
+		Discount: discount,
+		Items:    []models.OrderItem{{ItemName: p.Item, Quantity: p.Quantity, UnitPrice: price}},
+		Gift:     p.Gift,
+	}
+
+	err = tx.QueryRow(ctx, `
+        INSERT INTO orders (user_id, status, total, discount)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at, updated_at`, order.UserID, order.Status, order.Total, order.Discount).
+		Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create order: %w", op, err)
 	}
 
 	_, err = tx.Exec(ctx, `
-        INSERT INTO inventory (user_id, item_name)
-        VALUES ($1, $2)
-        ON CONFLICT (user_id, item_name)
-        DO UPDATE SET quantity = inventory.quantity + 1`, userID, item)
+        INSERT INTO order_items (order_id, item_name, quantity, unit_price)
+        VALUES ($1, $2, $3, $4)`, order.ID, p.Item, p.Quantity, price)
 	if err != nil {
-		return fmt.Errorf("%s: failed to add item to inventory: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to add order item: %w", op, err)
 	}
 
-	_, err = tx.Exec(ctx, "INSERT INTO transactions (from_user_id, to_user_id, amount) VALUES ($1, $2, $3)", userID, userID, price)
+	if p.Gift != nil {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO gifts (order_id, sender_id, recipient_id, message, anonymous)
+            VALUES ($1, $2, $3, $4, $5)`, order.ID, p.UserID, p.Gift.RecipientID, p.Gift.Message, p.Gift.Anonymous)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to record gift: %w", op, err)
+		}
+	}
+
+	if drop != nil {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO drop_allocations (drop_id, user_id, order_id, quantity)
+            VALUES ($1, $2, $3, $4)`, drop.ID, p.HolderID(), order.ID, p.Quantity)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to record drop allocation: %w", op, err)
+		}
+	}
+
+	var promoCodeID *int
+	if promo != nil {
+		promoCodeID = &promo.ID
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO promo_redemptions (promo_code_id, user_id, order_id, discount)
+            VALUES ($1, $2, $3, $4)`, promo.ID, p.UserID, order.ID, discount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to record promo code redemption: %w", op, err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO transactions (from_user_id, to_user_id, amount, item_name, quantity, unit_price, order_id, kind, discount, promo_code_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		p.UserID, p.UserID, total, p.Item, p.Quantity, price, order.ID, models.TransactionPurchase, discount, promoCodeID)
 	if err != nil {
-		return fmt.Errorf("%s: failed to insert transaction: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to insert transaction: %w", op, err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
 	}
 
-	return nil
+	return order, nil
 }
 
 //nolint:gocyclo
@@ -206,24 +302,19 @@ func (s *Storage) SendCoins(ctx context.Context, fromUserID, toUserID, amount in
 		}
 	}()
 
-	var senderCoins int
-	err = tx.QueryRow(ctx, "SELECT coins FROM users WHERE id=$1 FOR UPDATE", fromUserID).Scan(&senderCoins)
+	err = lockUsers(ctx, tx, fromUserID, toUserID)
 	if err != nil {
-		return fmt.Errorf("%s: failed to get user's coins: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if senderCoins < amount {
-		return fmt.Errorf("%s: insufficient funds", op)
-	}
-
-	_, err = tx.Exec(ctx, "UPDATE users SET coins = coins - $1 WHERE id = $2", amount, fromUserID)
+	err = debitCoins(ctx, tx, fromUserID, amount)
 	if err != nil {
-		return fmt.Errorf("%s: failed to update sender's coins: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = tx.Exec(ctx, "UPDATE users SET coins = coins + $1 WHERE id = $2", amount, toUserID)
+	err = creditCoins(ctx, tx, toUserID, amount)
 	if err != nil {
-		return fmt.Errorf("%s: failed to update recipient's coins: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	_, err = tx.Exec(ctx, "INSERT INTO transactions (from_user_id, to_user_id, amount) VALUES ($1, $2, $3)", fromUserID, toUserID, amount)
@@ -274,8 +365,12 @@ func (s *Storage) CreateUser(ctx context.Context, username, passwordHash string)
 	const op = "domain.repository.CreateUser"
 
 	var userID int
-	err := s.db.QueryRow(ctx, "INSERT INTO users(username, password_hash) VALUES  ($1, $2) RETURNING id", username, passwordHash).Scan(&userID)
+	err := s.db.QueryRow(ctx, insertUserSQL, username, passwordHash).Scan(&userID)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return 0, fmt.Errorf("%s: %w", op, ErrUserExists)
+		}
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -2,16 +2,23 @@ package repository_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"log"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"merch-store-service/internal/domain/models"
 	"merch-store-service/internal/domain/repository"
 	"merch-store-service/internal/infra/config"
+	"merch-store-service/internal/infra/jwtutils"
 )
 
 // SetupTestDatabase создает контейнер PostgreSQL с использованием Dockertest и выполняет SQL-запросы для создания таблиц
@@ -81,6 +88,8 @@ func createTables(db *pgxpool.Pool) error {
 			from_user_id INT NOT NULL,
 			to_user_id INT NOT NULL,
 			amount INT NOT NULL,
+			item_name VARCHAR(255),
+			quantity INT NOT NULL DEFAULT 1,
 			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
 			FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE
@@ -104,6 +113,63 @@ func createTables(db *pgxpool.Pool) error {
 		    CONSTRAINT unique_name UNIQUE(name)
 		);
 
+		CREATE TABLE IF NOT EXISTS orders
+		(
+			id SERIAL PRIMARY KEY,
+			user_id INT NOT NULL,
+			status VARCHAR(32) NOT NULL DEFAULT 'placed',
+			total INT NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS order_items
+		(
+			id SERIAL PRIMARY KEY,
+			order_id INT NOT NULL,
+			item_name VARCHAR(255) NOT NULL,
+			quantity INT NOT NULL,
+			unit_price INT NOT NULL,
+			FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+		);
+
+		ALTER TABLE transactions
+			ADD COLUMN IF NOT EXISTS order_id INT REFERENCES orders(id) ON DELETE SET NULL;
+
+		ALTER TABLE products ADD COLUMN IF NOT EXISTS stock INT CHECK (stock >= 0);
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP WITHOUT TIME ZONE;
+		ALTER TABLE transactions ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'transfer';
+
+		CREATE TABLE IF NOT EXISTS promo_codes
+		(
+			id SERIAL PRIMARY KEY,
+			code VARCHAR(64) NOT NULL UNIQUE,
+			discount_type VARCHAR(16) NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+			discount_value INT NOT NULL CHECK (discount_value > 0),
+			product_name VARCHAR(255) REFERENCES products(name) ON DELETE CASCADE,
+			valid_from TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			valid_until TIMESTAMP WITHOUT TIME ZONE,
+			max_uses INT,
+			max_uses_per_user INT,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS promo_redemptions
+		(
+			id SERIAL PRIMARY KEY,
+			promo_code_id INT NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+			user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+			discount INT NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount INT NOT NULL DEFAULT 0;
+		ALTER TABLE transactions
+			ADD COLUMN IF NOT EXISTS discount INT NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS promo_code_id INT REFERENCES promo_codes(id) ON DELETE SET NULL;
+
 		INSERT INTO products (name, price) VALUES
 			('t-shirt', 80),
 			('cup', 20),
@@ -116,6 +182,352 @@ func createTables(db *pgxpool.Pool) error {
 			('wallet', 50),
 			('pink-hoody', 500)
 		ON CONFLICT (name) DO NOTHING;
+
+		CREATE TABLE IF NOT EXISTS product_prices
+		(
+			id SERIAL PRIMARY KEY,
+			product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			price INT NOT NULL CHECK (price >= 0),
+			effective_from TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			CONSTRAINT unique_product_price UNIQUE (product_name, effective_from)
+		);
+
+		INSERT INTO product_prices (product_name, price, effective_from)
+		SELECT name, price, '1970-01-01' FROM products
+		ON CONFLICT (product_name, effective_from) DO NOTHING;
+
+		ALTER TABLE transactions ADD COLUMN IF NOT EXISTS unit_price INT;
+
+		CREATE TABLE IF NOT EXISTS drops
+		(
+			id SERIAL PRIMARY KEY,
+			product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			starts_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			ends_at TIMESTAMP WITHOUT TIME ZONE,
+			stock INT NOT NULL CHECK (stock > 0),
+			remaining INT NOT NULL CHECK (remaining >= 0),
+			per_user_limit INT NOT NULL CHECK (per_user_limit > 0),
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS drop_allocations
+		(
+			id SERIAL PRIMARY KEY,
+			drop_id INT NOT NULL REFERENCES drops(id) ON DELETE CASCADE,
+			user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			order_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+			quantity INT NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS waitlist
+		(
+			id SERIAL PRIMARY KEY,
+			product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			CONSTRAINT unique_waitlist UNIQUE (product_name, user_id)
+		);
+
+		CREATE TABLE IF NOT EXISTS stock_reservations
+		(
+			id SERIAL PRIMARY KEY,
+			product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			quantity INT NOT NULL CHECK (quantity > 0),
+			expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS wishlist_items
+		(
+			id SERIAL PRIMARY KEY,
+			user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			note VARCHAR(255),
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			CONSTRAINT unique_wishlist_item UNIQUE (user_id, product_name)
+		);
+
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS wishlist_public BOOLEAN NOT NULL DEFAULT FALSE;
+
+		CREATE TABLE IF NOT EXISTS gifts
+		(
+			id SERIAL PRIMARY KEY,
+			order_id INT NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+			sender_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			recipient_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			message VARCHAR(255),
+			anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS item_transfers
+		(
+			id SERIAL PRIMARY KEY,
+			from_user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			to_user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			item_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			quantity INT NOT NULL CHECK (quantity > 0),
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS market_listings
+		(
+			id SERIAL PRIMARY KEY,
+			seller_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			item_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			quantity INT NOT NULL CHECK (quantity > 0),
+			remaining INT NOT NULL CHECK (remaining >= 0),
+			price INT NOT NULL CHECK (price > 0),
+			status VARCHAR(16) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'sold', 'cancelled')),
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS market_trades
+		(
+			id SERIAL PRIMARY KEY,
+			listing_id INT NOT NULL REFERENCES market_listings(id) ON DELETE CASCADE,
+			buyer_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			quantity INT NOT NULL CHECK (quantity > 0),
+			total INT NOT NULL,
+			fee INT NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS auctions
+		(
+			id SERIAL PRIMARY KEY,
+			product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+			starts_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			ends_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			reserve_price INT NOT NULL DEFAULT 0 CHECK (reserve_price >= 0),
+			min_increment INT NOT NULL DEFAULT 1 CHECK (min_increment > 0),
+			extension_seconds INT NOT NULL DEFAULT 0 CHECK (extension_seconds >= 0),
+			status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'sold', 'unsold')),
+			leader_id INT REFERENCES users(id) ON DELETE SET NULL,
+			current_bid INT,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			closed_at TIMESTAMP WITHOUT TIME ZONE,
+			CHECK (ends_at > starts_at)
+		);
+
+		CREATE TABLE IF NOT EXISTS auction_bids
+		(
+			id SERIAL PRIMARY KEY,
+			auction_id INT NOT NULL REFERENCES auctions(id) ON DELETE CASCADE,
+			user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			amount INT NOT NULL CHECK (amount > 0),
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS raffles
+		(
+			id SERIAL PRIMARY KEY,
+			product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			prizes INT NOT NULL DEFAULT 1 CHECK (prizes > 0),
+			ticket_price INT NOT NULL CHECK (ticket_price > 0),
+			max_tickets_per_user INT CHECK (max_tickets_per_user > 0),
+			draw_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			seed_commitment CHAR(64) NOT NULL,
+			seed CHAR(64) NOT NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'drawn', 'cancelled')),
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			drawn_at TIMESTAMP WITHOUT TIME ZONE
+		);
+
+		CREATE TABLE IF NOT EXISTS raffle_tickets
+		(
+			id SERIAL PRIMARY KEY,
+			raffle_id INT NOT NULL REFERENCES raffles(id) ON DELETE CASCADE,
+			number INT NOT NULL CHECK (number > 0),
+			user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			prize_rank INT,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (raffle_id, number)
+		);
+
+		CREATE TABLE IF NOT EXISTS bundles
+		(
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL UNIQUE,
+			price INT NOT NULL CHECK (price > 0),
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS bundle_items
+		(
+			bundle_id INT NOT NULL REFERENCES bundles(id) ON DELETE CASCADE,
+			product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+			quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+			PRIMARY KEY (bundle_id, product_name)
+		);
+
+		ALTER TABLE order_items ADD COLUMN IF NOT EXISTS bundle_name VARCHAR(255);
+
+		ALTER TABLE products ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITHOUT TIME ZONE;
+
+		CREATE TABLE IF NOT EXISTS invites
+		(
+			code VARCHAR(64) PRIMARY KEY,
+			created_by INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			expires_at TIMESTAMP WITHOUT TIME ZONE,
+			used_by INT UNIQUE REFERENCES users(id) ON DELETE SET NULL,
+			used_at TIMESTAMP WITHOUT TIME ZONE
+		);
+
+		CREATE TABLE IF NOT EXISTS sessions
+		(
+			id VARCHAR(64) PRIMARY KEY,
+			user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			revoked_at TIMESTAMP WITHOUT TIME ZONE,
+			two_factor BOOLEAN NOT NULL DEFAULT FALSE
+		);
+
+		CREATE TABLE IF NOT EXISTS refresh_tokens
+		(
+			token_hash CHAR(64) PRIMARY KEY,
+			session_id VARCHAR(64) NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+			access_token_id VARCHAR(64) NOT NULL,
+			access_expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+			rotated_at TIMESTAMP WITHOUT TIME ZONE
+		);
+
+		CREATE TABLE IF NOT EXISTS revoked_tokens
+		(
+			token_id VARCHAR(64) PRIMARY KEY,
+			expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			revoked_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS signing_keys
+		(
+			kid VARCHAR(64) PRIMARY KEY,
+			algorithm VARCHAR(16) NOT NULL,
+			private_key TEXT NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			retires_at TIMESTAMP WITHOUT TIME ZONE
+		);
+
+		CREATE TABLE IF NOT EXISTS user_roles
+		(
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			role VARCHAR(32) NOT NULL,
+			granted_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			granted_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP,
+			PRIMARY KEY (user_id, role)
+		);
+
+		CREATE TABLE IF NOT EXISTS service_accounts
+		(
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(32) NOT NULL UNIQUE,
+			description TEXT NOT NULL DEFAULT '',
+			created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS api_keys
+		(
+			id VARCHAR(16) PRIMARY KEY,
+			service_account_id INTEGER NOT NULL REFERENCES service_accounts(id) ON DELETE CASCADE,
+			key_hash VARCHAR(64) NOT NULL,
+			scopes TEXT[] NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP,
+			expires_at TIMESTAMP WITHOUT TIME ZONE,
+			last_used_at TIMESTAMP WITHOUT TIME ZONE,
+			revoked_at TIMESTAMP WITHOUT TIME ZONE
+		);
+
+		CREATE TABLE IF NOT EXISTS password_resets
+		(
+			token_hash CHAR(64) PRIMARY KEY,
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP,
+			expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			used_at TIMESTAMP WITHOUT TIME ZONE
+		);
+
+		CREATE TABLE IF NOT EXISTS login_failures
+		(
+			kind VARCHAR(16) NOT NULL,
+			key TEXT NOT NULL,
+			failures INTEGER NOT NULL,
+			last_failure_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+			locked_until TIMESTAMP WITHOUT TIME ZONE,
+			PRIMARY KEY (kind, key)
+		);
+
+		CREATE TABLE IF NOT EXISTS sso_logins
+		(
+			state_hash CHAR(64) PRIMARY KEY,
+			nonce TEXT NOT NULL,
+			code_verifier TEXT NOT NULL,
+			expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS user_identities
+		(
+			issuer TEXT NOT NULL,
+			subject TEXT NOT NULL,
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			email TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP,
+			last_login_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP,
+			PRIMARY KEY (issuer, subject)
+		);
+
+		CREATE TABLE IF NOT EXISTS directory_profiles
+		(
+			user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			source VARCHAR(16) NOT NULL,
+			dn TEXT NOT NULL,
+			display_name TEXT NOT NULL DEFAULT '',
+			email TEXT NOT NULL DEFAULT '',
+			department TEXT NOT NULL DEFAULT '',
+			synced_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS two_factor
+		(
+			user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+			secret VARCHAR(64) NOT NULL,
+			created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP,
+			confirmed_at TIMESTAMP WITHOUT TIME ZONE,
+			last_used_step BIGINT NOT NULL DEFAULT 0
+		);
+
+		CREATE TABLE IF NOT EXISTS recovery_codes
+		(
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			code_hash CHAR(64) NOT NULL,
+			used_at TIMESTAMP WITHOUT TIME ZONE,
+			PRIMARY KEY (user_id, code_hash)
+		);
+
+		CREATE TABLE IF NOT EXISTS two_factor_challenges
+		(
+			token_hash CHAR(64) PRIMARY KEY,
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS two_factor_roles
+		(
+			role VARCHAR(32) PRIMARY KEY,
+			required_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			required_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP
+		);
 	`)
 	return err
 }
@@ -198,3 +610,2191 @@ func TestRepository(t *testing.T) {
 
 	log.Println("All tests passed successfully!")
 }
+
+func TestPurchaseQuantity(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	userID, err := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	assert.NoError(t, err)
+
+	order, err := storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "pen", Quantity: 10})
+	assert.NoError(t, err, "Purchase of 10 pens should succeed")
+	assert.Equal(t, models.OrderStatusPlaced, order.Status)
+	assert.Equal(t, 100, order.Total)
+
+	coins, _ := storage.GetUserCoins(ctx, userID)
+	assert.Equal(t, 900, coins, "10 pens should cost 100 coins")
+
+	var quantity, transactions int
+	err = db.QueryRow(ctx, "SELECT quantity FROM inventory WHERE user_id = $1 AND item_name = 'pen'", userID).Scan(&quantity)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, quantity)
+
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM transactions WHERE from_user_id = $1", userID).Scan(&transactions)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, transactions, "The order should be recorded as a single transaction")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "pink-hoody", Quantity: 2})
+	assert.ErrorIs(t, err, repository.ErrInsufficientFunds)
+
+	coins, _ = storage.GetUserCoins(ctx, userID)
+	assert.Equal(t, 900, coins, "Failed purchase must not charge the user")
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	userID, err := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	assert.NoError(t, err)
+
+	order, err := storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "hoody", Quantity: 1})
+	assert.NoError(t, err)
+
+	orders, err := storage.ListUserOrders(ctx, userID)
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, "hoody", orders[0].Items[0].ItemName)
+	assert.Equal(t, 300, orders[0].Items[0].UnitPrice)
+
+	_, err = storage.UpdateOrderStatus(ctx, order.ID, models.OrderStatusDelivered)
+	assert.ErrorIs(t, err, repository.ErrInvalidStatusTransition, "Order cannot skip the pickup step")
+
+	updated, err := storage.UpdateOrderStatus(ctx, order.ID, models.OrderStatusReadyForPickup)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OrderStatusReadyForPickup, updated.Status)
+
+	updated, err = storage.UpdateOrderStatus(ctx, order.ID, models.OrderStatusDelivered)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OrderStatusDelivered, updated.Status)
+
+	status := models.OrderStatusDelivered
+	delivered, err := storage.ListOrders(ctx, &status)
+	assert.NoError(t, err)
+	assert.Len(t, delivered, 1)
+
+	_, err = storage.UpdateOrderStatus(ctx, 999, models.OrderStatusReadyForPickup)
+	assert.ErrorIs(t, err, repository.ErrOrderNotFound)
+}
+
+func TestOrderCancellation(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 5 WHERE name = 'cup'")
+	assert.NoError(t, err)
+
+	userID, err := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	assert.NoError(t, err)
+	otherID, err := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	assert.NoError(t, err)
+
+	order, err := storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "cup", Quantity: 3})
+	assert.NoError(t, err)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: otherID, Item: "cup", Quantity: 3})
+	assert.ErrorIs(t, err, repository.ErrOutOfStock, "Only 2 cups are left in stock")
+
+	_, err = storage.CancelOrder(ctx, order.ID, &otherID, 0)
+	assert.ErrorIs(t, err, repository.ErrOrderNotFound, "Users cannot cancel someone else's order")
+
+	cancelled, err := storage.CancelOrder(ctx, order.ID, &userID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCancelled, cancelled.Status)
+
+	coins, _ := storage.GetUserCoins(ctx, userID)
+	assert.Equal(t, 1000, coins, "Cancelling must refund the exact coins paid")
+
+	var stock, inventoryRows, refunds int
+	err = db.QueryRow(ctx, "SELECT stock FROM products WHERE name = 'cup'").Scan(&stock)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, stock, "Cancelling must restore stock")
+
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM inventory WHERE user_id = $1", userID).Scan(&inventoryRows)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, inventoryRows, "Inventory rows that reach zero should be removed")
+
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM transactions WHERE order_id = $1 AND kind = 'refund' AND amount = 60", order.ID).Scan(&refunds)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, refunds, "The refund should be linked to the original order")
+
+	_, err = storage.CancelOrder(ctx, order.ID, &userID, 0)
+	assert.ErrorIs(t, err, repository.ErrOrderNotCancellable, "An order can be refunded only once")
+
+	delivered, err := storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "pen", Quantity: 1})
+	assert.NoError(t, err)
+	_, err = storage.UpdateOrderStatus(ctx, delivered.ID, models.OrderStatusReadyForPickup)
+	assert.NoError(t, err)
+	_, err = storage.UpdateOrderStatus(ctx, delivered.ID, models.OrderStatusDelivered)
+	assert.NoError(t, err)
+
+	_, err = storage.CancelOrder(ctx, delivered.ID, &userID, 0)
+	assert.ErrorIs(t, err, repository.ErrOrderNotCancellable, "Delivered orders need an open return window")
+
+	_, err = storage.CancelOrder(ctx, delivered.ID, &userID, time.Hour)
+	assert.NoError(t, err, "Delivered orders can be returned within the return window")
+}
+
+func TestPromoCodes(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	maxUses, maxUsesPerUser := 2, 1
+	hoody := "hoody"
+	_, err = storage.CreatePromoCode(ctx, &models.PromoCode{
+		Code:           "ANNIVERSARY",
+		DiscountType:   models.DiscountPercent,
+		DiscountValue:  20,
+		Product:        &hoody,
+		ValidFrom:      time.Now().UTC().Add(-time.Hour),
+		MaxUses:        &maxUses,
+		MaxUsesPerUser: &maxUsesPerUser,
+	})
+	assert.NoError(t, err)
+
+	_, err = storage.CreatePromoCode(ctx, &models.PromoCode{Code: "ANNIVERSARY", DiscountType: models.DiscountFixed, DiscountValue: 5})
+	assert.ErrorIs(t, err, repository.ErrPromoCodeExists)
+
+	firstID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	secondID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	thirdID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: firstID, Item: "pen", Quantity: 1, PromoCode: "ANNIVERSARY"})
+	assert.ErrorIs(t, err, models.ErrPromoNotApplicable, "The code only applies to hoodies")
+
+	order, err := storage.Purchase(ctx, models.Purchase{UserID: firstID, Item: "hoody", Quantity: 1, PromoCode: "ANNIVERSARY"})
+	assert.NoError(t, err)
+	assert.Equal(t, 60, order.Discount)
+	assert.Equal(t, 240, order.Total)
+
+	coins, _ := storage.GetUserCoins(ctx, firstID)
+	assert.Equal(t, 760, coins)
+
+	var discount int
+	err = db.QueryRow(ctx, "SELECT discount FROM transactions WHERE order_id = $1", order.ID).Scan(&discount)
+	assert.NoError(t, err)
+	assert.Equal(t, 60, discount, "The discount should be recorded on the transaction")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: firstID, Item: "hoody", Quantity: 1, PromoCode: "ANNIVERSARY"})
+	assert.ErrorIs(t, err, models.ErrPromoUsageExhausted, "Per-user cap is one use")
+
+	_, err = storage.CancelOrder(ctx, order.ID, &firstID, 0)
+	assert.NoError(t, err)
+
+	order, err = storage.Purchase(ctx, models.Purchase{UserID: firstID, Item: "hoody", Quantity: 1, PromoCode: "ANNIVERSARY"})
+	assert.NoError(t, err, "A cancelled order should give the use of the code back")
+	assert.Equal(t, 60, order.Discount)
+
+	secondOrder, err := storage.Purchase(ctx, models.Purchase{UserID: secondID, Item: "hoody", Quantity: 1, PromoCode: "ANNIVERSARY"})
+	assert.NoError(t, err)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: thirdID, Item: "hoody", Quantity: 1, PromoCode: "ANNIVERSARY"})
+	assert.ErrorIs(t, err, models.ErrPromoUsageExhausted, "Global cap is two uses")
+
+	stats, err := storage.ListPromoCodeStats(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, 2, stats[0].Redemptions)
+	assert.Equal(t, 2, stats[0].UniqueUsers)
+	assert.Equal(t, 120, stats[0].TotalDiscount)
+
+	_, err = storage.CancelOrder(ctx, secondOrder.ID, nil, 0)
+	assert.NoError(t, err)
+
+	stats, err = storage.ListPromoCodeStats(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats[0].Redemptions, "A cancelled order should not count as a redemption")
+	assert.Equal(t, 60, stats[0].TotalDiscount)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: thirdID, Item: "hoody", Quantity: 1, PromoCode: "ANNIVERSARY"})
+	assert.NoError(t, err, "A cancelled order should free a use under the global cap")
+}
+
+func TestPriceHistory(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	_, err = storage.SchedulePrice(ctx, "cup", 25, now.Add(-time.Minute))
+	assert.NoError(t, err)
+
+	_, err = storage.SchedulePrice(ctx, "cup", 40, now.Add(24*time.Hour))
+	assert.NoError(t, err)
+
+	_, err = storage.SchedulePrice(ctx, "cup", 45, now.Add(24*time.Hour))
+	assert.ErrorIs(t, err, repository.ErrPriceExists)
+
+	_, err = storage.SchedulePrice(ctx, "unknown", 10, now)
+	assert.ErrorIs(t, err, repository.ErrItemNotFound)
+
+	timeline, err := storage.PriceTimeline(ctx, "cup")
+	assert.NoError(t, err)
+	assert.Len(t, timeline, 3)
+	assert.Equal(t, 1, models.CurrentPrice(timeline, now), "The scheduled price is not in effect yet")
+
+	userID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	order, err := storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "cup", Quantity: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 50, order.Total, "The purchase should use the price in effect now")
+
+	var unitPrice int
+	err = db.QueryRow(ctx, "SELECT unit_price FROM transactions WHERE order_id = $1", order.ID).Scan(&unitPrice)
+	assert.NoError(t, err)
+	assert.Equal(t, 25, unitPrice, "The unit price paid should be recorded")
+}
+
+func TestDropFairAllocation(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	upcoming, err := storage.CreateDrop(ctx, &models.Drop{Product: "umbrella", StartsAt: now.Add(time.Hour), Stock: 5, PerUserLimit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, upcoming.Remaining)
+
+	userID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "umbrella", Quantity: 1})
+	assert.ErrorIs(t, err, models.ErrDropNotStarted, "The item cannot be bought before the drop starts")
+
+	const (
+		stock           = 10
+		buyers          = 40
+		requestsPerUser = 3
+	)
+
+	_, err = storage.CreateDrop(ctx, &models.Drop{Product: "pink-hoody", StartsAt: now.Add(-time.Minute), Stock: stock, PerUserLimit: 1})
+	assert.NoError(t, err)
+
+	userIDs := make([]int, buyers)
+	for i := range userIDs {
+		userIDs[i], err = storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+		assert.NoError(t, err)
+	}
+
+	// Every buyer hits the drop several times at once to provoke both overselling
+	// and double allocation.
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners = make(map[int]int)
+		failed  = make(map[error]int)
+		start   = make(chan struct{})
+	)
+	for _, id := range userIDs {
+		for j := 0; j < requestsPerUser; j++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				<-start
+
+				_, err := storage.Purchase(ctx, models.Purchase{UserID: id, Item: "pink-hoody", Quantity: 1})
+
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					winners[id]++
+				case errors.Is(err, models.ErrDropLimitReached):
+					failed[models.ErrDropLimitReached]++
+				case errors.Is(err, repository.ErrOutOfStock):
+					failed[repository.ErrOutOfStock]++
+				default:
+					t.Errorf("unexpected purchase error: %v", err)
+				}
+			}(id)
+		}
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Len(t, winners, stock, "Exactly as many buyers as units should win")
+	for id, units := range winners {
+		assert.Equal(t, 1, units, "User %d should get at most one unit", id)
+	}
+	assert.Equal(t, buyers*requestsPerUser-stock, failed[models.ErrDropLimitReached]+failed[repository.ErrOutOfStock])
+
+	var sold, allocated, remaining int
+	err = db.QueryRow(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM inventory WHERE item_name = 'pink-hoody'").Scan(&sold)
+	assert.NoError(t, err)
+	err = db.QueryRow(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM drop_allocations").Scan(&allocated)
+	assert.NoError(t, err)
+	err = db.QueryRow(ctx, "SELECT remaining FROM drops WHERE product_name = 'pink-hoody'").Scan(&remaining)
+	assert.NoError(t, err)
+	assert.Equal(t, stock, sold, "No units should be oversold")
+	assert.Equal(t, stock, allocated)
+	assert.Equal(t, 0, remaining)
+
+	drops, err := storage.ListDrops(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, drops, 2)
+	assert.Equal(t, models.DropSoldOut, drops[0].Status(time.Now().UTC()))
+
+	// A cancelled order puts its unit back into the drop.
+	var winner int
+	for id := range winners {
+		winner = id
+		break
+	}
+	orders, err := storage.ListUserOrders(ctx, winner)
+	assert.NoError(t, err)
+	_, err = storage.CancelOrder(ctx, orders[0].ID, &winner, 0)
+	assert.NoError(t, err)
+
+	err = db.QueryRow(ctx, "SELECT remaining FROM drops WHERE product_name = 'pink-hoody'").Scan(&remaining)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, remaining)
+
+	// The limit counts the units a person receives, so a gift uses up the
+	// recipient's allocation rather than the buyer's.
+	_, err = storage.CreateDrop(ctx, &models.Drop{Product: "socks", StartsAt: now.Add(-time.Minute), Stock: 5, PerUserLimit: 1})
+	assert.NoError(t, err)
+
+	buyerID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	friendID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: buyerID, Item: "socks", Quantity: 1, Gift: &models.Gift{RecipientID: friendID}})
+	assert.NoError(t, err)
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: friendID, Item: "socks", Quantity: 1})
+	assert.ErrorIs(t, err, models.ErrDropLimitReached, "The recipient already got their unit as a gift")
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: buyerID, Item: "socks", Quantity: 1, Gift: &models.Gift{RecipientID: friendID}})
+	assert.ErrorIs(t, err, models.ErrDropLimitReached, "A second gift would exceed the recipient's limit")
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: buyerID, Item: "socks", Quantity: 1})
+	assert.NoError(t, err, "Buying a gift does not use up the buyer's own limit")
+}
+
+func TestWaitlist(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 0 WHERE name = 'cup'")
+	assert.NoError(t, err)
+
+	firstID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	secondID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	thirdID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	_, err = storage.JoinWaitlist(ctx, firstID, "pen")
+	assert.ErrorIs(t, err, repository.ErrItemInStock, "Items in stock have no waitlist")
+
+	for i, id := range []int{firstID, secondID, thirdID} {
+		entry, err := storage.JoinWaitlist(ctx, id, "cup")
+		assert.NoError(t, err)
+		assert.Equal(t, i+1, entry.Position)
+	}
+
+	entry, err := storage.JoinWaitlist(ctx, firstID, "cup")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, entry.Position, "Joining again keeps the place in the queue")
+
+	err = storage.LeaveWaitlist(ctx, secondID, "cup")
+	assert.NoError(t, err)
+	err = storage.LeaveWaitlist(ctx, secondID, "cup")
+	assert.ErrorIs(t, err, repository.ErrNotOnWaitlist)
+
+	entries, err := storage.ListUserWaitlists(ctx, thirdID)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, 2, entries[0].Position)
+
+	notices, err := storage.Restock(ctx, "cup", 1, models.ReservePolicy{Count: 5, TTL: time.Hour})
+	assert.NoError(t, err)
+	assert.Len(t, notices, 2)
+	assert.Equal(t, firstID, notices[0].UserID, "Subscribers are notified in the order they joined")
+	assert.NotNil(t, notices[0].ReservedUntil, "The only restocked unit is held for the first subscriber")
+	assert.Nil(t, notices[1].ReservedUntil)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: thirdID, Item: "cup", Quantity: 1})
+	assert.ErrorIs(t, err, repository.ErrOutOfStock, "A reserved unit cannot be bought by someone else")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: firstID, Item: "cup", Quantity: 1})
+	assert.NoError(t, err)
+
+	var reservations int
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM stock_reservations").Scan(&reservations)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, reservations, "The purchase uses up the reservation")
+
+	entries, err = storage.ListUserWaitlists(ctx, thirdID)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "Notified subscribers leave the waitlist")
+
+	_, err = storage.Restock(ctx, "pen", 5, models.ReservePolicy{})
+	assert.ErrorIs(t, err, repository.ErrStockNotTracked, "A product without stock tracking cannot be restocked")
+
+	var penStock *int
+	err = db.QueryRow(ctx, "SELECT stock FROM products WHERE name = 'pen'").Scan(&penStock)
+	assert.NoError(t, err)
+	assert.Nil(t, penStock, "The product should stay unlimited")
+
+	// A purchase uses up only as many reserved units as it takes.
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 3 WHERE name = 'cup'")
+	assert.NoError(t, err)
+	_, err = db.Exec(ctx, `
+		INSERT INTO stock_reservations (product_name, user_id, quantity, expires_at)
+		VALUES ('cup', $1, 2, $2)`, thirdID, time.Now().UTC().Add(time.Hour))
+	assert.NoError(t, err)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: thirdID, Item: "cup", Quantity: 1})
+	assert.NoError(t, err)
+
+	var held int
+	err = db.QueryRow(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM stock_reservations WHERE user_id = $1", thirdID).Scan(&held)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, held, "One reserved unit should be left")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: firstID, Item: "cup", Quantity: 1})
+	assert.NoError(t, err)
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: firstID, Item: "cup", Quantity: 1})
+	assert.ErrorIs(t, err, repository.ErrOutOfStock, "The last unit is still held")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: thirdID, Item: "cup", Quantity: 1})
+	assert.NoError(t, err)
+
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM stock_reservations").Scan(&reservations)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, reservations)
+}
+
+func TestWishlist(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 0 WHERE name = 'umbrella'")
+	assert.NoError(t, err)
+
+	username := uuid.New().String()
+	userID, _ := storage.CreateUser(ctx, username, "password_hash")
+
+	note := "размер M"
+	assert.NoError(t, storage.AddWishlistItem(ctx, userID, "hoody", &note))
+	assert.NoError(t, storage.AddWishlistItem(ctx, userID, "umbrella", nil))
+	assert.ErrorIs(t, storage.AddWishlistItem(ctx, userID, "hoody", nil), repository.ErrWishlistItemExists)
+	assert.ErrorIs(t, storage.AddWishlistItem(ctx, userID, "yacht", nil), repository.ErrItemNotFound)
+
+	wishlist, err := storage.GetWishlist(ctx, userID)
+	assert.NoError(t, err)
+	assert.False(t, wishlist.Public)
+	assert.Len(t, wishlist.Items, 2)
+	assert.Equal(t, 300, wishlist.Items[0].Price)
+	assert.True(t, wishlist.Items[0].Available)
+	assert.False(t, wishlist.Items[1].Available, "A sold-out item is shown as unavailable")
+
+	_, err = storage.GetPublicWishlist(ctx, username)
+	assert.ErrorIs(t, err, repository.ErrWishlistPrivate)
+
+	assert.NoError(t, storage.SetWishlistPublic(ctx, userID, true))
+	assert.NoError(t, storage.UpdateWishlistItem(ctx, userID, "umbrella", &note))
+	assert.ErrorIs(t, storage.UpdateWishlistItem(ctx, userID, "pen", &note), repository.ErrWishlistItemNotFound)
+
+	shared, err := storage.GetPublicWishlist(ctx, username)
+	assert.NoError(t, err)
+	assert.Len(t, shared.Items, 2)
+	assert.Equal(t, note, *shared.Items[1].Note)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "hoody", Quantity: 1})
+	assert.NoError(t, err)
+
+	wishlist, err = storage.GetWishlist(ctx, userID)
+	assert.NoError(t, err)
+	assert.Len(t, wishlist.Items, 1, "A bought item leaves the wishlist")
+	assert.Equal(t, "umbrella", wishlist.Items[0].Product)
+
+	assert.NoError(t, storage.RemoveWishlistItem(ctx, userID, "umbrella"))
+	assert.ErrorIs(t, storage.RemoveWishlistItem(ctx, userID, "umbrella"), repository.ErrWishlistItemNotFound)
+}
+
+func TestGifts(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	sender := uuid.New().String()
+	senderID, _ := storage.CreateUser(ctx, sender, "password_hash")
+	recipient := uuid.New().String()
+	recipientID, _ := storage.CreateUser(ctx, recipient, "password_hash")
+
+	assert.NoError(t, storage.AddWishlistItem(ctx, recipientID, "cup", nil))
+
+	message := "Спасибо за помощь с релизом!"
+	order, err := storage.Purchase(ctx, models.Purchase{
+		UserID:   senderID,
+		Item:     "cup",
+		Quantity: 2,
+		Gift:     &models.Gift{RecipientID: recipientID, Recipient: recipient, Message: &message},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, recipient, order.Gift.Recipient)
+
+	_, err = storage.Purchase(ctx, models.Purchase{
+		UserID:   senderID,
+		Item:     "pen",
+		Quantity: 1,
+		Gift:     &models.Gift{RecipientID: recipientID, Recipient: recipient, Anonymous: true},
+	})
+	assert.NoError(t, err)
+
+	senderCoins, _ := storage.GetUserCoins(ctx, senderID)
+	assert.Equal(t, 1000-2*20-10, senderCoins, "The sender pays for the gift")
+	recipientCoins, _ := storage.GetUserCoins(ctx, recipientID)
+	assert.Equal(t, 1000, recipientCoins)
+
+	var senderItems, recipientCups int
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM inventory WHERE user_id = $1", senderID).Scan(&senderItems)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, senderItems, "Gifts do not land in the sender's inventory")
+	err = db.QueryRow(ctx, "SELECT quantity FROM inventory WHERE user_id = $1 AND item_name = 'cup'", recipientID).Scan(&recipientCups)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, recipientCups)
+
+	wishlist, err := storage.GetWishlist(ctx, recipientID)
+	assert.NoError(t, err)
+	assert.Empty(t, wishlist.Items, "A gifted item leaves the recipient's wishlist")
+
+	received, sent, err := storage.GetUserGifts(ctx, recipientID)
+	assert.NoError(t, err)
+	assert.Empty(t, sent)
+	assert.Len(t, received, 2)
+	assert.Equal(t, "pen", received[0].Item)
+	assert.Nil(t, received[0].VisibleSender(), "Anonymous gifts hide the sender")
+	assert.Equal(t, sender, *received[1].VisibleSender())
+	assert.Equal(t, message, *received[1].Message)
+
+	_, sent, err = storage.GetUserGifts(ctx, senderID)
+	assert.NoError(t, err)
+	assert.Len(t, sent, 2)
+
+	orders, err := storage.ListUserOrders(ctx, senderID)
+	assert.NoError(t, err)
+	assert.Len(t, orders, 2)
+	for _, o := range orders {
+		assert.NotNil(t, o.Gift, "Gift orders carry the gift marker")
+	}
+
+	_, err = storage.CancelOrder(ctx, order.ID, &senderID, 0)
+	assert.NoError(t, err)
+
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM inventory WHERE user_id = $1 AND item_name = 'cup'", recipientID).Scan(&recipientCups)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, recipientCups, "Cancelling a gift takes the items back from the recipient")
+
+	received, _, err = storage.GetUserGifts(ctx, recipientID)
+	assert.NoError(t, err)
+	assert.Len(t, received, 1, "Cancelled gifts leave the history")
+
+	// A unit reserved for the recipient can be bought for them as a gift.
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 1 WHERE name = 'umbrella'")
+	assert.NoError(t, err)
+	_, err = db.Exec(ctx, `
+		INSERT INTO stock_reservations (product_name, user_id, quantity, expires_at)
+		VALUES ('umbrella', $1, 1, $2)`, recipientID, time.Now().UTC().Add(time.Hour))
+	assert.NoError(t, err)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: senderID, Item: "umbrella", Quantity: 1})
+	assert.ErrorIs(t, err, repository.ErrOutOfStock, "The unit is held for the recipient")
+
+	_, err = storage.Purchase(ctx, models.Purchase{
+		UserID:   senderID,
+		Item:     "umbrella",
+		Quantity: 1,
+		Gift:     &models.Gift{RecipientID: recipientID, Recipient: recipient},
+	})
+	assert.NoError(t, err, "A gift should use the recipient's reservation")
+
+	var reservations int
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM stock_reservations").Scan(&reservations)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, reservations)
+}
+
+func TestItemTransfer(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	sender := uuid.New().String()
+	senderID, _ := storage.CreateUser(ctx, sender, "password_hash")
+	recipient := uuid.New().String()
+	recipientID, _ := storage.CreateUser(ctx, recipient, "password_hash")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: senderID, Item: "book", Quantity: 2})
+	assert.NoError(t, err)
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: recipientID, Item: "book", Quantity: 1})
+	assert.NoError(t, err)
+
+	err = storage.TransferItem(ctx, senderID, recipientID, "book", 3)
+	assert.ErrorIs(t, err, repository.ErrItemNotOwned, "Users cannot hand over more than they own")
+	err = storage.TransferItem(ctx, senderID, recipientID, "cup", 1)
+	assert.ErrorIs(t, err, repository.ErrItemNotOwned)
+
+	assert.NoError(t, storage.TransferItem(ctx, senderID, recipientID, "book", 1))
+	assert.NoError(t, storage.TransferItem(ctx, senderID, recipientID, "book", 1))
+
+	var senderRows, recipientBooks int
+	err = db.QueryRow(ctx, "SELECT COUNT(*) FROM inventory WHERE user_id = $1", senderID).Scan(&senderRows)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, senderRows, "Inventory rows that reach zero should be removed")
+	err = db.QueryRow(ctx, "SELECT quantity FROM inventory WHERE user_id = $1 AND item_name = 'book'", recipientID).Scan(&recipientBooks)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, recipientBooks)
+
+	received, sent, err := storage.GetUserItemHistory(ctx, recipientID)
+	assert.NoError(t, err)
+	assert.Empty(t, sent)
+	assert.Len(t, received, 2)
+	assert.Equal(t, sender, received[0].Sender)
+	assert.Equal(t, "book", received[0].Item)
+
+	_, sent, err = storage.GetUserItemHistory(ctx, senderID)
+	assert.NoError(t, err)
+	assert.Len(t, sent, 2)
+	assert.Equal(t, recipient, sent[0].Recipient)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = storage.TransferItem(ctx, recipientID, senderID, "book", 1)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = storage.TransferItem(ctx, senderID, recipientID, "book", 1)
+		}()
+	}
+	wg.Wait()
+
+	var total int
+	err = db.QueryRow(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM inventory WHERE item_name = 'book' AND user_id IN ($1, $2)",
+		senderID, recipientID).Scan(&total)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total, "Concurrent transfers must neither lose nor duplicate items")
+}
+
+func TestMarketplace(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	sellerID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	buyerID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: sellerID, Item: "cup", Quantity: 3})
+	assert.NoError(t, err)
+
+	_, err = storage.CreateListing(ctx, &models.Listing{SellerID: sellerID, Item: "cup", Quantity: 4, Price: 30})
+	assert.ErrorIs(t, err, repository.ErrItemNotOwned, "Sellers cannot list more than they own")
+
+	listing, err := storage.CreateListing(ctx, &models.Listing{SellerID: sellerID, Item: "cup", Quantity: 2, Price: 30})
+	assert.NoError(t, err)
+	assert.Equal(t, models.ListingActive, listing.Status)
+
+	_, err = storage.CreateListing(ctx, &models.Listing{SellerID: sellerID, Item: "cup", Quantity: 2, Price: 30})
+	assert.ErrorIs(t, err, repository.ErrItemNotOwned, "Listed units are locked and cannot be listed twice")
+
+	item := "cup"
+	listings, err := storage.ListListings(ctx, &item)
+	assert.NoError(t, err)
+	assert.Len(t, listings, 1)
+
+	_, err = storage.BuyListing(ctx, listing.ID, sellerID, 1, 10)
+	assert.ErrorIs(t, err, models.ErrOwnListing)
+	_, err = storage.BuyListing(ctx, listing.ID, buyerID, 3, 10)
+	assert.ErrorIs(t, err, repository.ErrOutOfStock)
+
+	trade, err := storage.BuyListing(ctx, listing.ID, buyerID, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, trade.Total)
+	assert.Equal(t, 3, trade.Fee)
+
+	sellerCoins, _ := storage.GetUserCoins(ctx, sellerID)
+	assert.Equal(t, 1000-3*20+27, sellerCoins, "The seller receives the price less the burned fee")
+	buyerCoins, _ := storage.GetUserCoins(ctx, buyerID)
+	assert.Equal(t, 1000-30, buyerCoins)
+
+	var buyerCups int
+	err = db.QueryRow(ctx, "SELECT quantity FROM inventory WHERE user_id = $1 AND item_name = 'cup'", buyerID).Scan(&buyerCups)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, buyerCups)
+
+	cancelled, err := storage.CancelListing(ctx, listing.ID, buyerID)
+	assert.ErrorIs(t, err, repository.ErrListingNotFound, "Only the seller can cancel a listing")
+	assert.Nil(t, cancelled)
+
+	cancelled, err = storage.CancelListing(ctx, listing.ID, sellerID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ListingCancelled, cancelled.Status)
+
+	var sellerCups int
+	err = db.QueryRow(ctx, "SELECT quantity FROM inventory WHERE user_id = $1 AND item_name = 'cup'", sellerID).Scan(&sellerCups)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sellerCups, "Unsold units return to the seller's inventory")
+
+	_, err = storage.BuyListing(ctx, listing.ID, buyerID, 1, 10)
+	assert.ErrorIs(t, err, models.ErrListingNotActive)
+
+	listing, err = storage.CreateListing(ctx, &models.Listing{SellerID: sellerID, Item: "cup", Quantity: 2, Price: 10})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	var sold atomic.Int32
+	for i := 0; i < 10; i++ {
+		buyer, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := storage.BuyListing(ctx, listing.ID, buyer, 1, 10); err == nil {
+				sold.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), sold.Load(), "A listing cannot be double-sold")
+	listings, err = storage.ListListings(ctx, &item)
+	assert.NoError(t, err)
+	assert.Empty(t, listings, "Sold-out listings are closed")
+}
+
+func TestAuctions(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	alice := uuid.New().String()
+	aliceID, _ := storage.CreateUser(ctx, alice, "password_hash")
+	bobID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 1 WHERE name IN ('book', 'hoody')")
+	assert.NoError(t, err)
+
+	auction, err := storage.CreateAuction(ctx, &models.Auction{
+		Product: "book", Quantity: 1, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour),
+		ReservePrice: 200, MinIncrement: 10, Extension: 2 * time.Minute,
+	})
+	assert.NoError(t, err)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: bobID, Item: "book", Quantity: 1})
+	assert.ErrorIs(t, err, repository.ErrOutOfStock, "The lot is taken out of stock while it is auctioned")
+	_, err = storage.CreateAuction(ctx, &models.Auction{
+		Product: "book", Quantity: 1, StartsAt: now, EndsAt: now.Add(time.Hour), MinIncrement: 10,
+	})
+	assert.ErrorIs(t, err, repository.ErrOutOfStock, "A lot cannot be auctioned twice")
+
+	_, err = storage.PlaceBid(ctx, auction.ID, aliceID, 150)
+	assert.NoError(t, err)
+	_, err = storage.PlaceBid(ctx, auction.ID, bobID, 155)
+	assert.ErrorIs(t, err, models.ErrBidTooLow, "Bids must beat the leader by the minimum increment")
+	_, err = storage.PlaceBid(ctx, auction.ID, bobID, 2000)
+	assert.ErrorIs(t, err, repository.ErrInsufficientFunds, "Bidders cannot hold more coins than they have")
+
+	aliceCoins, _ := storage.GetUserCoins(ctx, aliceID)
+	assert.Equal(t, 850, aliceCoins, "The leading bid is held")
+
+	updated, err := storage.PlaceBid(ctx, auction.ID, bobID, 160)
+	assert.NoError(t, err)
+	assert.Equal(t, bobID, *updated.LeaderID)
+	assert.False(t, updated.ReserveMet())
+	assert.Equal(t, auction.EndsAt.Unix(), updated.EndsAt.Unix(), "Early bids do not extend the auction")
+
+	aliceCoins, _ = storage.GetUserCoins(ctx, aliceID)
+	assert.Equal(t, 1000, aliceCoins, "The hold is released when outbid")
+
+	var wg sync.WaitGroup
+	var accepted atomic.Int32
+	for i := 0; i < 10; i++ {
+		bidder, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := storage.PlaceBid(ctx, auction.ID, bidder, 170); err == nil {
+				accepted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), accepted.Load(), "Only one of several equal concurrent bids can lead")
+
+	var held int
+	err = db.QueryRow(ctx, "SELECT COALESCE(SUM(1000 - coins), 0) FROM users").Scan(&held)
+	assert.NoError(t, err)
+	assert.Equal(t, 170, held, "Only the leader's coins are held")
+
+	_, err = db.Exec(ctx, "UPDATE auctions SET ends_at = LOCALTIMESTAMP + INTERVAL '30 seconds' WHERE id = $1", auction.ID)
+	assert.NoError(t, err)
+	updated, err = storage.PlaceBid(ctx, auction.ID, aliceID, 250)
+	assert.NoError(t, err)
+	assert.True(t, updated.EndsAt.After(time.Now().UTC().Add(time.Minute)), "Last-minute bids extend the auction")
+
+	closed, err := storage.CloseDueAuctions(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, closed, "Running auctions are not closed")
+
+	unsold, err := storage.CreateAuction(ctx, &models.Auction{
+		Product: "hoody", Quantity: 1, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour),
+		ReservePrice: 500, MinIncrement: 10,
+	})
+	assert.NoError(t, err)
+	_, err = storage.PlaceBid(ctx, unsold.ID, bobID, 100)
+	assert.NoError(t, err)
+
+	// The hold of this auction cannot be given back, as the leader's balance
+	// would overflow. It ends first but must not block the others.
+	broken, err := storage.CreateAuction(ctx, &models.Auction{
+		Product: "pen", Quantity: 1, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour),
+		ReservePrice: 2147483647, MinIncrement: 10,
+	})
+	assert.NoError(t, err)
+	_, err = db.Exec(ctx, "UPDATE auctions SET leader_id = $1, current_bid = 2147483646 WHERE id = $2", bobID, broken.ID)
+	assert.NoError(t, err)
+
+	_, err = db.Exec(ctx, "UPDATE auctions SET ends_at = LOCALTIMESTAMP - INTERVAL '1 second'")
+	assert.NoError(t, err)
+	_, err = db.Exec(ctx, "UPDATE auctions SET ends_at = LOCALTIMESTAMP - INTERVAL '1 minute' WHERE id = $1", broken.ID)
+	assert.NoError(t, err)
+
+	closed, err = storage.CloseDueAuctions(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, closed, 2, "An auction that cannot be settled is skipped")
+
+	result, _, err := storage.GetAuction(ctx, broken.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuctionOpen, result.State, "The failed auction is retried on the next run")
+
+	result, bids, err := storage.GetAuction(ctx, auction.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuctionSold, result.State)
+	assert.Equal(t, alice, *result.Leader)
+	assert.Equal(t, 250, bids[0].Amount)
+
+	aliceCoins, _ = storage.GetUserCoins(ctx, aliceID)
+	assert.Equal(t, 750, aliceCoins, "The winner is charged the winning bid")
+	var books int
+	err = db.QueryRow(ctx, "SELECT quantity FROM inventory WHERE user_id = $1 AND item_name = 'book'", aliceID).Scan(&books)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, books, "The winner receives the lot")
+
+	result, _, err = storage.GetAuction(ctx, unsold.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuctionUnsold, result.State, "Lots below the reserve price are not sold")
+	bobCoins, _ := storage.GetUserCoins(ctx, bobID)
+	assert.Equal(t, 1000, bobCoins, "The hold is released when the reserve is not met")
+	var hoodies int
+	err = db.QueryRow(ctx, "SELECT stock FROM products WHERE name = 'hoody'").Scan(&hoodies)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, hoodies, "An unsold lot returns to stock")
+
+	_, err = storage.PlaceBid(ctx, auction.ID, bobID, 500)
+	assert.ErrorIs(t, err, models.ErrAuctionEnded)
+}
+
+func TestRaffles(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	aliceID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	bobID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 2 WHERE name = 'hoody'")
+	assert.NoError(t, err)
+
+	seed, commitment, err := models.NewRaffleSeed()
+	assert.NoError(t, err)
+	limit := 5
+	raffle, err := storage.CreateRaffle(ctx, &models.Raffle{
+		Product: "hoody", Prizes: 2, TicketPrice: 10, MaxTicketsPerUser: &limit,
+		DrawAt: now.Add(time.Hour), Commitment: commitment,
+	}, seed)
+	assert.NoError(t, err)
+
+	var stock int
+	err = db.QueryRow(ctx, "SELECT stock FROM products WHERE name = 'hoody'").Scan(&stock)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stock, "The prizes are taken from stock when the raffle is created")
+
+	_, err = storage.CreateRaffle(ctx, &models.Raffle{
+		Product: "hoody", Prizes: 1, TicketPrice: 10, DrawAt: now.Add(time.Hour), Commitment: commitment,
+	}, seed)
+	assert.ErrorIs(t, err, repository.ErrOutOfStock)
+
+	numbers, total, err := storage.BuyRaffleTickets(ctx, raffle.ID, aliceID, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, numbers)
+	assert.Equal(t, 30, total)
+	_, _, err = storage.BuyRaffleTickets(ctx, raffle.ID, aliceID, 3)
+	assert.ErrorIs(t, err, models.ErrTicketLimitReached)
+
+	aliceCoins, _ := storage.GetUserCoins(ctx, aliceID)
+	assert.Equal(t, 970, aliceCoins, "Tickets are paid for like a purchase")
+
+	var wg sync.WaitGroup
+	var sold atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := storage.BuyRaffleTickets(ctx, raffle.ID, bobID, 1); err == nil {
+				sold.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(limit), sold.Load(), "The per-user limit holds under concurrent purchases")
+
+	open, tickets, err := storage.GetRaffle(ctx, raffle.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, open.Seed, "The seed stays secret before the draw")
+	assert.Equal(t, 8, open.TicketsSold)
+	for i, ticket := range tickets {
+		assert.Equal(t, i+1, ticket.Number, "Ticket numbers are consecutive")
+	}
+
+	drawn, err := storage.DrawDueRaffles(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, drawn, "Raffles are not drawn before their time")
+
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 2 WHERE name = 'umbrella'")
+	assert.NoError(t, err)
+	leftover, err := storage.CreateRaffle(ctx, &models.Raffle{
+		Product: "umbrella", Prizes: 2, TicketPrice: 10, DrawAt: now.Add(time.Hour), Commitment: commitment,
+	}, seed)
+	assert.NoError(t, err)
+	_, _, err = storage.BuyRaffleTickets(ctx, leftover.ID, bobID, 1)
+	assert.NoError(t, err)
+
+	broken, err := storage.CreateRaffle(ctx, &models.Raffle{
+		Product: "pen", Prizes: 1, TicketPrice: 10, DrawAt: now.Add(time.Hour), Commitment: commitment,
+	}, "not-a-hex-seed")
+	assert.NoError(t, err)
+
+	_, err = db.Exec(ctx, "UPDATE raffles SET draw_at = LOCALTIMESTAMP - INTERVAL '1 second' WHERE id = ANY($1)", []int{raffle.ID, leftover.ID})
+	assert.NoError(t, err)
+	_, err = db.Exec(ctx, "UPDATE raffles SET draw_at = LOCALTIMESTAMP - INTERVAL '1 minute' WHERE id = $1", broken.ID)
+	assert.NoError(t, err)
+	_, _, err = storage.BuyRaffleTickets(ctx, raffle.ID, aliceID, 1)
+	assert.ErrorIs(t, err, models.ErrRaffleClosed)
+
+	drawn, err = storage.DrawDueRaffles(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, drawn, 2, "A raffle that cannot be drawn does not hold up the others")
+	assert.Equal(t, raffle.ID, drawn[0].ID)
+	assert.Equal(t, seed, *drawn[0].Seed, "The seed is revealed after the draw")
+
+	stuck, _, err := storage.GetRaffle(ctx, broken.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RaffleOpen, stuck.State, "A failed raffle is retried on the next run")
+
+	err = db.QueryRow(ctx, "SELECT stock FROM products WHERE name = 'umbrella'").Scan(&stock)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stock, "Prizes left over for lack of tickets return to stock")
+
+	result, tickets, err := storage.GetRaffle(ctx, raffle.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RaffleDrawn, result.State)
+
+	recomputed, err := models.RaffleCommitment(*result.Seed)
+	assert.NoError(t, err)
+	assert.Equal(t, result.Commitment, recomputed)
+
+	expected, err := models.DrawWinners(*result.Seed, tickets, result.Prizes)
+	assert.NoError(t, err)
+	winners := 0
+	for _, ticket := range tickets {
+		if ticket.PrizeRank != nil {
+			assert.Equal(t, expected[*ticket.PrizeRank-1], ticket.Number, "Anyone can recompute the draw")
+			winners++
+		}
+	}
+	assert.Equal(t, 2, winners)
+
+	var hoodies int
+	err = db.QueryRow(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM inventory WHERE item_name = 'hoody'").Scan(&hoodies)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, hoodies, "Each winning ticket receives one unit")
+
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 3 WHERE name = 'cup'")
+	assert.NoError(t, err)
+	cancelled, err := storage.CreateRaffle(ctx, &models.Raffle{
+		Product: "cup", Prizes: 1, TicketPrice: 25, DrawAt: now.Add(time.Hour), Commitment: commitment,
+	}, seed)
+	assert.NoError(t, err)
+	_, _, err = storage.BuyRaffleTickets(ctx, cancelled.ID, aliceID, 2)
+	assert.NoError(t, err)
+
+	result, err = storage.CancelRaffle(ctx, cancelled.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RaffleCancelled, result.State)
+	aliceCoins, _ = storage.GetUserCoins(ctx, aliceID)
+	assert.Equal(t, 970, aliceCoins, "Cancelling a raffle refunds its tickets")
+	err = db.QueryRow(ctx, "SELECT stock FROM products WHERE name = 'cup'").Scan(&stock)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stock, "Cancelling a raffle returns its prizes to stock")
+
+	_, err = storage.CancelRaffle(ctx, raffle.ID)
+	assert.ErrorIs(t, err, repository.ErrRaffleNotOpen)
+}
+
+func TestBundles(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	userID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	bundle, err := storage.CreateBundle(ctx, &models.Bundle{
+		Name:  "welcome-pack",
+		Price: 90,
+		Items: []models.BundleItem{{Product: "cup", Quantity: 1}, {Product: "pen", Quantity: 2}, {Product: "t-shirt", Quantity: 1}},
+	})
+	assert.NoError(t, err)
+	assert.True(t, bundle.Available)
+	assert.Len(t, bundle.Items, 3)
+
+	_, err = storage.CreateBundle(ctx, &models.Bundle{Name: "welcome-pack", Price: 10, Items: []models.BundleItem{{Product: "cup", Quantity: 1}}})
+	assert.ErrorIs(t, err, repository.ErrBundleExists)
+	_, err = storage.CreateBundle(ctx, &models.Bundle{Name: "mystery-pack", Price: 10, Items: []models.BundleItem{{Product: "unknown", Quantity: 1}}})
+	assert.ErrorIs(t, err, repository.ErrItemNotFound)
+
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 3 WHERE name = 'cup'")
+	assert.NoError(t, err)
+	_, err = db.Exec(ctx, "UPDATE products SET stock = 1 WHERE name = 't-shirt'")
+	assert.NoError(t, err)
+
+	order, err := storage.BuyBundle(ctx, userID, "welcome-pack", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 90, order.Total, "The bundle price is charged once")
+	assert.Len(t, order.Items, 3)
+
+	coins, _ := storage.GetUserCoins(ctx, userID)
+	assert.Equal(t, 910, coins)
+
+	var cups, pens int
+	err = db.QueryRow(ctx, "SELECT quantity FROM inventory WHERE user_id = $1 AND item_name = 'cup'", userID).Scan(&cups)
+	assert.NoError(t, err)
+	err = db.QueryRow(ctx, "SELECT quantity FROM inventory WHERE user_id = $1 AND item_name = 'pen'", userID).Scan(&pens)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cups)
+	assert.Equal(t, 2, pens, "Each component is added in its bundle quantity")
+
+	var cupStock int
+	err = db.QueryRow(ctx, "SELECT stock FROM products WHERE name = 'cup'").Scan(&cupStock)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cupStock, "Stock is decremented for each component")
+
+	soldOut, err := storage.GetBundle(ctx, "welcome-pack")
+	assert.NoError(t, err)
+	assert.False(t, soldOut.Available, "A bundle is unavailable once a component sells out")
+
+	_, err = storage.BuyBundle(ctx, userID, "welcome-pack", 1)
+	assert.ErrorIs(t, err, repository.ErrOutOfStock)
+
+	coins, _ = storage.GetUserCoins(ctx, userID)
+	assert.Equal(t, 910, coins, "A failed bundle purchase charges nothing")
+	err = db.QueryRow(ctx, "SELECT stock FROM products WHERE name = 'cup'").Scan(&cupStock)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cupStock, "A failed bundle purchase takes no stock")
+
+	cancelled, err := storage.CancelOrder(ctx, order.ID, &userID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, models.OrderStatusCancelled, cancelled.Status)
+	assert.Equal(t, "welcome-pack", *cancelled.Items[0].Bundle)
+	coins, _ = storage.GetUserCoins(ctx, userID)
+	assert.Equal(t, 1000, coins, "Cancelling a bundle order refunds the bundle price")
+
+	updated, err := storage.UpdateBundle(ctx, &models.Bundle{Name: "welcome-pack", Price: 40, Items: []models.BundleItem{{Product: "pen", Quantity: 4}}})
+	assert.NoError(t, err)
+	assert.Equal(t, 40, updated.Price)
+	assert.Equal(t, []models.BundleItem{{Product: "pen", Quantity: 4}}, updated.Items)
+
+	err = storage.DeleteBundle(ctx, "welcome-pack")
+	assert.NoError(t, err)
+	_, err = storage.GetBundle(ctx, "welcome-pack")
+	assert.ErrorIs(t, err, repository.ErrBundleNotFound)
+	err = storage.DeleteBundle(ctx, "welcome-pack")
+	assert.ErrorIs(t, err, repository.ErrBundleNotFound)
+}
+
+func TestCatalogImport(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	userID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	original, err := storage.ExportCatalog(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, original, 10)
+
+	// Drop the pen, reprice the cup and add stickers.
+	var desired []models.CatalogEntry
+	for _, entry := range original {
+		switch entry.Name {
+		case "pen":
+			continue
+		case "cup":
+			entry.Price = 25
+		}
+		desired = append(desired, entry)
+	}
+	desired = append(desired, models.CatalogEntry{Name: "stickers", Price: 5})
+
+	want := []models.CatalogChange{
+		{Kind: models.CatalogUpdate, Name: "cup", OldPrice: 20, Price: 25},
+		{Kind: models.CatalogArchive, Name: "pen", OldPrice: 10, Price: 10},
+		{Kind: models.CatalogCreate, Name: "stickers", Price: 5},
+	}
+
+	changes, err := storage.ImportCatalog(ctx, desired, true)
+	assert.NoError(t, err)
+	assert.Equal(t, want, changes)
+
+	exported, err := storage.ExportCatalog(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, original, exported, "Dry run should not change the catalog")
+
+	changes, err = storage.ImportCatalog(ctx, desired, false)
+	assert.NoError(t, err)
+	assert.Equal(t, want, changes)
+
+	changes, err = storage.ImportCatalog(ctx, desired, true)
+	assert.NoError(t, err)
+	assert.Empty(t, changes, "Import should be idempotent")
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "pen", Quantity: 1})
+	assert.ErrorIs(t, err, repository.ErrItemNotFound, "Archived products should not be sold")
+
+	order, err := storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "cup", Quantity: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 25, order.Total)
+
+	_, err = storage.Purchase(ctx, models.Purchase{UserID: userID, Item: "stickers", Quantity: 1})
+	assert.NoError(t, err)
+
+	changes, err = storage.ImportCatalog(ctx, append(desired, models.CatalogEntry{Name: "pen", Price: 15}), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.CatalogChange{{Kind: models.CatalogRestore, Name: "pen", OldPrice: 10, Price: 15}}, changes)
+
+	timeline, err := storage.PriceTimeline(ctx, "pen")
+	assert.NoError(t, err)
+	assert.Equal(t, 15, timeline[len(timeline)-1].Price)
+}
+
+func TestInvites(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	staffName := uuid.New().String()
+	staffID, _ := storage.CreateUser(ctx, staffName, "password_hash")
+
+	_, err = storage.CreateUser(ctx, staffName, "password_hash")
+	assert.ErrorIs(t, err, repository.ErrUserExists)
+
+	invite, err := storage.CreateInvite(ctx, staffID, "invite-1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, staffName, invite.CreatedBy)
+	assert.Nil(t, invite.UsedBy)
+
+	expired := time.Now().UTC().Add(-time.Hour)
+	_, err = storage.CreateInvite(ctx, staffID, "invite-expired", &expired)
+	assert.NoError(t, err)
+
+	_, err = storage.CreateInvitedUser(ctx, "expired-user", "password_hash", "invite-expired")
+	assert.ErrorIs(t, err, repository.ErrInviteInvalid)
+
+	_, err = storage.CreateInvitedUser(ctx, "unknown-user", "password_hash", "no-such-code")
+	assert.ErrorIs(t, err, repository.ErrInviteInvalid)
+
+	_, err = storage.CreateInvitedUser(ctx, staffName, "password_hash", "invite-1")
+	assert.ErrorIs(t, err, repository.ErrUserExists, "A taken username should not use up the invite")
+
+	userID, err := storage.CreateInvitedUser(ctx, "invited-user", "password_hash", "invite-1")
+	assert.NoError(t, err)
+	assert.NotZero(t, userID)
+
+	_, err = storage.CreateInvitedUser(ctx, "second-user", "password_hash", "invite-1")
+	assert.ErrorIs(t, err, repository.ErrInviteInvalid, "Invites are single-use")
+
+	invites, err := storage.ListInvites(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, invites, 2)
+	for _, invite := range invites {
+		if invite.Code == "invite-1" {
+			assert.Equal(t, "invited-user", *invite.UsedBy)
+			assert.NotNil(t, invite.UsedAt)
+		} else {
+			assert.Nil(t, invite.UsedBy)
+		}
+	}
+}
+
+func TestSessions(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	userID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+
+	now := time.Now().UTC()
+	grant := func(refreshHash, accessID string) models.TokenGrant {
+		return models.TokenGrant{
+			AccessTokenID:    accessID,
+			AccessExpiresAt:  now.Add(15 * time.Minute),
+			RefreshTokenHash: refreshHash,
+			RefreshExpiresAt: now.Add(time.Hour),
+		}
+	}
+
+	first := grant(models.HashRefreshToken("refresh-1"), "access-1")
+	first.SessionID = "session-1"
+	assert.NoError(t, storage.CreateSession(ctx, userID, first))
+
+	gotUser, sessionID, err := storage.RotateRefreshToken(ctx, models.HashRefreshToken("refresh-1"), grant(models.HashRefreshToken("refresh-2"), "access-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, userID, gotUser)
+	assert.Equal(t, "session-1", sessionID)
+
+	_, _, err = storage.RotateRefreshToken(ctx, models.HashRefreshToken("unknown"), grant(models.HashRefreshToken("refresh-x"), "access-x"))
+	assert.ErrorIs(t, err, repository.ErrUnauthorized)
+
+	revoked, err := storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, revoked)
+
+	// Presenting the rotated token again revokes the whole session.
+	_, _, err = storage.RotateRefreshToken(ctx, models.HashRefreshToken("refresh-1"), grant(models.HashRefreshToken("refresh-3"), "access-3"))
+	assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+
+	_, _, err = storage.RotateRefreshToken(ctx, models.HashRefreshToken("refresh-2"), grant(models.HashRefreshToken("refresh-4"), "access-4"))
+	assert.ErrorIs(t, err, repository.ErrUnauthorized, "The latest refresh token should stop working too")
+
+	revoked, err = storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	ids := make([]string, 0, len(revoked))
+	for _, token := range revoked {
+		ids = append(ids, token.ID)
+	}
+	assert.ElementsMatch(t, []string{"access-1", "access-2"}, ids)
+
+	second := grant(models.HashRefreshToken("refresh-5"), "access-5")
+	second.SessionID = "session-2"
+	assert.NoError(t, storage.CreateSession(ctx, userID, second))
+	assert.NoError(t, storage.RevokeSession(ctx, "session-2"))
+	assert.ErrorIs(t, storage.RevokeSession(ctx, "no-such-session"), repository.ErrSessionNotFound)
+
+	_, _, err = storage.RotateRefreshToken(ctx, models.HashRefreshToken("refresh-5"), grant(models.HashRefreshToken("refresh-6"), "access-6"))
+	assert.ErrorIs(t, err, repository.ErrUnauthorized, "Refresh tokens of a logged out session should not work")
+
+	revoked, err = storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, revoked, 3)
+
+	assert.NoError(t, storage.PurgeExpiredSessions(ctx))
+	revoked, err = storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, revoked, 3, "Unexpired entries should be kept")
+}
+
+func TestSigningKeys(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	keys, err := storage.ListSigningKeys(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// Two instances sharing the database see each other's keys.
+	cfg := &config.Config{
+		JWTAlgorithm:      jwtutils.AlgorithmEdDSA,
+		JWTIssuer:         "merch-store-service",
+		JWTAudience:       "merch-store-service",
+		JWTKeyGracePeriod: time.Hour,
+	}
+	first := jwtutils.NewJWTManager(cfg, storage)
+	assert.NoError(t, first.Rotate(ctx))
+
+	token, err := first.NewSessionToken(1, "session-1", uuid.NewString(), nil, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
+	rotating := *cfg
+	rotating.JWTKeyRotation = time.Nanosecond
+	second := jwtutils.NewJWTManager(&rotating, storage)
+	assert.NoError(t, second.Rotate(ctx))
+
+	keys, err = storage.ListSigningKeys(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.NotNil(t, keys[0].RetiresAt, "The previous key should be retiring")
+	assert.Nil(t, keys[1].RetiresAt)
+
+	_, err = second.ValidateToken(token)
+	assert.NoError(t, err, "Tokens signed by another instance should verify during the grace period")
+	assert.Len(t, second.JWKS(), 2)
+}
+
+func TestRoles(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	admin := uuid.New().String()
+	adminID, _ := storage.CreateUser(ctx, admin, "password_hash")
+	username := uuid.New().String()
+	userID, _ := storage.CreateUser(ctx, username, "password_hash")
+
+	roles, err := storage.GetUserRoles(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee}, roles, "New users should be employees")
+
+	assert.NoError(t, storage.SeedRoles(ctx, []string{admin, "no-such-user"}, models.RoleHRAdmin))
+	roles, err = storage.GetUserRoles(ctx, adminID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee, models.RoleHRAdmin}, roles)
+
+	now := time.Now().UTC()
+	assert.NoError(t, storage.CreateSession(ctx, userID, models.TokenGrant{
+		SessionID:        "session-1",
+		AccessTokenID:    "access-1",
+		AccessExpiresAt:  now.Add(15 * time.Minute),
+		RefreshTokenHash: models.HashRefreshToken("refresh-1"),
+		RefreshExpiresAt: now.Add(time.Hour),
+	}))
+
+	granted, err := storage.GrantRole(ctx, username, models.RoleAuditor, adminID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleAuditor, models.RoleEmployee}, granted.Roles)
+
+	revoked, err := storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, revoked, 1, "Access tokens issued before the change should be revoked")
+
+	// The session survives, so the user can refresh to get the new role.
+	gotUser, _, err := storage.RotateRefreshToken(ctx, models.HashRefreshToken("refresh-1"), models.TokenGrant{
+		AccessTokenID:    "access-2",
+		AccessExpiresAt:  now.Add(15 * time.Minute),
+		RefreshTokenHash: models.HashRefreshToken("refresh-2"),
+		RefreshExpiresAt: now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, userID, gotUser)
+
+	_, err = storage.GrantRole(ctx, username, models.RoleAuditor, adminID)
+	assert.NoError(t, err)
+	revoked, err = storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, revoked, 1, "Granting a role the user already has should change nothing")
+
+	remaining, err := storage.RevokeRole(ctx, username, models.RoleAuditor)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee}, remaining.Roles)
+	revoked, err = storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, revoked, 2)
+
+	listed, err := storage.ListUserRoles(ctx, username)
+	assert.NoError(t, err)
+	assert.Equal(t, remaining, listed)
+
+	_, err = storage.GrantRole(ctx, "no-such-user", models.RoleAuditor, adminID)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+	_, err = storage.ListUserRoles(ctx, "no-such-user")
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
+
+func TestServiceAccounts(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	admin := uuid.New().String()
+	adminID, _ := storage.CreateUser(ctx, admin, "password_hash")
+
+	account, err := storage.CreateServiceAccount(ctx, adminID, "hr-system", "Начисления от HR")
+	assert.NoError(t, err)
+	assert.Equal(t, admin, account.CreatedBy)
+	_, err = storage.CreateServiceAccount(ctx, adminID, "hr-system", "")
+	assert.ErrorIs(t, err, repository.ErrServiceAccountExists)
+
+	_, id, hash, err := models.NewAPIKey()
+	assert.NoError(t, err)
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Microsecond)
+	key, err := storage.CreateAPIKey(ctx, models.APIKey{
+		ID:        id,
+		Account:   "hr-system",
+		Hash:      hash,
+		Scopes:    []models.Scope{models.ScopeCoinsGrant},
+		ExpiresAt: &expiresAt,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Scope{models.ScopeCoinsGrant}, key.Scopes)
+
+	_, err = storage.CreateAPIKey(ctx, models.APIKey{ID: "0123456789abcdef", Account: "nobody", Hash: hash})
+	assert.ErrorIs(t, err, repository.ErrServiceAccountNotFound)
+
+	stored, err := storage.GetAPIKey(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, stored.Hash)
+	assert.Equal(t, "hr-system", stored.Account)
+	assert.Nil(t, stored.LastUsedAt)
+
+	assert.NoError(t, storage.TouchAPIKey(ctx, id))
+	stored, err = storage.GetAPIKey(ctx, id)
+	assert.NoError(t, err)
+	assert.NotNil(t, stored.LastUsedAt, "Using the key should be recorded")
+
+	accounts, err := storage.ListServiceAccounts(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 1)
+	assert.Len(t, accounts[0].Keys, 1)
+
+	assert.ErrorIs(t, storage.RevokeAPIKey(ctx, "hr-system", "0123456789abcdef"), repository.ErrAPIKeyNotFound)
+	assert.NoError(t, storage.RevokeAPIKey(ctx, "hr-system", id))
+	stored, err = storage.GetAPIKey(ctx, id)
+	assert.NoError(t, err)
+	assert.False(t, stored.Active(time.Now()), "A revoked key should not be active")
+
+	_, err = storage.GetAPIKey(ctx, "0123456789abcdef")
+	assert.ErrorIs(t, err, repository.ErrAPIKeyNotFound)
+
+	assert.NoError(t, storage.GrantCoins(ctx, adminID, 250))
+	coins, err := storage.GetUserCoins(ctx, adminID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1250, coins, "Granted coins should be added to the balance")
+}
+
+func TestPasswords(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	adminID, _ := storage.CreateUser(ctx, uuid.New().String(), "password_hash")
+	username := uuid.New().String()
+	userID, _ := storage.CreateUser(ctx, username, "password_hash")
+
+	now := time.Now().UTC()
+	assert.NoError(t, storage.CreateSession(ctx, userID, models.TokenGrant{
+		SessionID:        "session-1",
+		AccessTokenID:    "access-1",
+		AccessExpiresAt:  now.Add(15 * time.Minute),
+		RefreshTokenHash: models.HashRefreshToken("refresh-1"),
+		RefreshExpiresAt: now.Add(time.Hour),
+	}))
+
+	assert.NoError(t, storage.ChangePassword(ctx, userID, "new_hash"))
+	user, err := storage.GetUserByUsername(ctx, username)
+	assert.NoError(t, err)
+	assert.Equal(t, "new_hash", user.PasswordHash)
+	assert.ErrorIs(t, storage.ChangePassword(ctx, -1, "new_hash"), repository.ErrUserNotFound)
+
+	revoked, err := storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, revoked, 1, "Access tokens should be revoked on password change")
+	_, _, err = storage.RotateRefreshToken(ctx, models.HashRefreshToken("refresh-1"), models.TokenGrant{
+		AccessTokenID:    "access-2",
+		AccessExpiresAt:  now.Add(15 * time.Minute),
+		RefreshTokenHash: models.HashRefreshToken("refresh-2"),
+		RefreshExpiresAt: now.Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, repository.ErrUnauthorized, "Sessions should be revoked on password change")
+
+	expiresAt := now.Add(time.Hour)
+	assert.ErrorIs(t, storage.CreatePasswordReset(ctx, "no-such-user", models.HashPasswordResetToken("reset-0"), adminID, expiresAt), repository.ErrUserNotFound)
+	assert.NoError(t, storage.CreatePasswordReset(ctx, username, models.HashPasswordResetToken("reset-1"), adminID, expiresAt))
+	assert.NoError(t, storage.CreatePasswordReset(ctx, username, models.HashPasswordResetToken("reset-2"), adminID, expiresAt))
+
+	err = storage.ResetPassword(ctx, models.HashPasswordResetToken("reset-1"), "reset_hash")
+	assert.ErrorIs(t, err, repository.ErrResetTokenInvalid, "A newer token should replace the older one")
+
+	assert.NoError(t, storage.ResetPassword(ctx, models.HashPasswordResetToken("reset-2"), "reset_hash"))
+	user, err = storage.GetUserByUsername(ctx, username)
+	assert.NoError(t, err)
+	assert.Equal(t, "reset_hash", user.PasswordHash)
+
+	err = storage.ResetPassword(ctx, models.HashPasswordResetToken("reset-2"), "other_hash")
+	assert.ErrorIs(t, err, repository.ErrResetTokenInvalid, "A reset token should work once")
+
+	assert.NoError(t, storage.CreatePasswordReset(ctx, username, models.HashPasswordResetToken("reset-3"), adminID, now.Add(-time.Minute)))
+	err = storage.ResetPassword(ctx, models.HashPasswordResetToken("reset-3"), "other_hash")
+	assert.ErrorIs(t, err, repository.ErrResetTokenInvalid, "An expired reset token should not work")
+
+	ssoUsername := uuid.New().String()
+	ssoID, _ := storage.CreateUser(ctx, ssoUsername, "!sso")
+	err = storage.ChangePassword(ctx, ssoID, "new_hash")
+	assert.ErrorIs(t, err, repository.ErrPasswordManagedExternally, "Single sign-on users have no password to change")
+	err = storage.CreatePasswordReset(ctx, ssoUsername, models.HashPasswordResetToken("reset-4"), adminID, expiresAt)
+	assert.ErrorIs(t, err, repository.ErrPasswordManagedExternally)
+
+	directoryUsername := uuid.New().String()
+	directoryID, _ := storage.CreateUser(ctx, directoryUsername, "!directory")
+	_, err = db.Exec(ctx, `
+        INSERT INTO password_resets (token_hash, user_id, created_by, expires_at)
+        VALUES ($1, $2, $3, $4)`, models.HashPasswordResetToken("reset-5"), directoryID, adminID, expiresAt)
+	assert.NoError(t, err)
+	err = storage.ResetPassword(ctx, models.HashPasswordResetToken("reset-5"), "reset_hash")
+	assert.ErrorIs(t, err, repository.ErrPasswordManagedExternally, "A reset token issued earlier should not give a directory user a password")
+	user, err = storage.GetUserByUsername(ctx, directoryUsername)
+	assert.NoError(t, err)
+	assert.Equal(t, "!directory", user.PasswordHash)
+}
+
+func TestLoginFailures(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	alice := models.ThrottleKey{Kind: models.ThrottleUsername, Key: "alice"}
+	address := models.ThrottleKey{Kind: models.ThrottleIP, Key: "192.0.2.1"}
+	keys := []models.ThrottleKey{alice, address}
+	policy := models.LoginThrottlePolicy{
+		FreeAttempts:     2,
+		BackoffBase:      time.Second,
+		BackoffMax:       time.Minute,
+		MaxFailures:      3,
+		MaxFailuresPerIP: 100,
+		Lockout:          15 * time.Minute,
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i := 1; i <= 2; i++ {
+		attempt, err := storage.ReserveLoginAttempt(ctx, keys, policy, now)
+		assert.NoError(t, err)
+		assert.Len(t, attempt.Failures, 2)
+		assert.Equal(t, i, attempt.Failures[0].Failures, "The attempt should be counted before the password is checked")
+		assert.Nil(t, attempt.Failures[0].LockedUntil)
+	}
+
+	_, err = storage.ReserveLoginAttempt(ctx, keys, policy, now)
+	var throttled *models.LoginThrottledError
+	assert.ErrorAs(t, err, &throttled, "The third attempt should wait for the backoff")
+	assert.Equal(t, now.Add(time.Second), throttled.RetryAt)
+
+	// A right password gives the attempt back, without touching failures
+	// recorded before it.
+	attempt, err := storage.ReserveLoginAttempt(ctx, keys, policy, now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Second+15*time.Minute), *attempt.Failures[0].LockedUntil, "Reaching the limit should lock the key out")
+	assert.NoError(t, storage.ReleaseLoginAttempt(ctx, attempt))
+
+	got, err := storage.ListLoginFailures(ctx, now.Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	for _, failures := range got {
+		assert.Equal(t, 2, failures.Failures)
+		assert.Equal(t, now, failures.LastFailureAt)
+		assert.Nil(t, failures.LockedUntil)
+	}
+
+	// A key that had no failures is removed again.
+	bob := []models.ThrottleKey{{Kind: models.ThrottleUsername, Key: "bob"}}
+	attempt, err = storage.ReserveLoginAttempt(ctx, bob, policy, now)
+	assert.NoError(t, err)
+	assert.NoError(t, storage.ReleaseLoginAttempt(ctx, attempt))
+
+	// Failures older than the lockout are forgotten.
+	later := now.Add(time.Hour)
+	attempt, err = storage.ReserveLoginAttempt(ctx, []models.ThrottleKey{alice}, policy, later)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempt.Failures[0].Failures)
+	assert.Nil(t, attempt.Failures[0].LockedUntil)
+
+	listed, err := storage.ListLoginFailures(ctx, now.Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, listed, 2)
+	assert.Equal(t, alice.Key, listed[0].Key, "The most recent failure should come first")
+
+	assert.NoError(t, storage.ClearLoginFailures(ctx, alice))
+	assert.ErrorIs(t, storage.ClearLoginFailures(ctx, alice), repository.ErrLoginFailuresNotFound)
+
+	assert.NoError(t, storage.PurgeLoginFailures(ctx, now.Add(time.Minute)))
+	got, err = storage.ListLoginFailures(ctx, time.Time{})
+	assert.NoError(t, err)
+	assert.Empty(t, got, "Old failures should be purged")
+}
+
+func TestSSO(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	login := models.SSOLogin{StateHash: models.HashSSOState("state"), Nonce: "nonce", CodeVerifier: "verifier", ExpiresAt: now.Add(10 * time.Minute)}
+	assert.NoError(t, storage.CreateSSOLogin(ctx, login))
+	taken, err := storage.TakeSSOLogin(ctx, login.StateHash)
+	assert.NoError(t, err)
+	assert.Equal(t, "verifier", taken.CodeVerifier)
+	_, err = storage.TakeSSOLogin(ctx, login.StateHash)
+	assert.ErrorIs(t, err, repository.ErrSSOStateInvalid, "A state should be accepted once")
+
+	expired := models.SSOLogin{StateHash: models.HashSSOState("old"), Nonce: "n", CodeVerifier: "v", ExpiresAt: now.Add(-time.Minute)}
+	assert.NoError(t, storage.CreateSSOLogin(ctx, expired))
+	_, err = storage.TakeSSOLogin(ctx, expired.StateHash)
+	assert.ErrorIs(t, err, repository.ErrSSOStateInvalid, "An expired state should be rejected")
+
+	_, err = storage.CreateUser(ctx, "alice", "hash")
+	assert.NoError(t, err)
+
+	identity := &models.ExternalIdentity{Issuer: "http://idp.test", Subject: "mock|alice", Email: "alice@example.com", EmailVerified: true}
+	_, err = storage.SignInExternalUser(ctx, identity, "alice", models.SSOPolicy{Provision: true})
+	assert.ErrorIs(t, err, repository.ErrIdentityNotLinked, "An existing user should not be taken over without LinkExisting")
+
+	policy := models.SSOPolicy{LinkExisting: true, Provision: true}
+	aliceID, err := storage.SignInExternalUser(ctx, identity, "alice", policy)
+	assert.NoError(t, err)
+	user, err := storage.GetUserByUsername(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, aliceID, "The account should be linked to the existing user")
+
+	// Once linked, the account keeps its user even if the claim changes.
+	again, err := storage.SignInExternalUser(ctx, identity, "alice.renamed", models.SSOPolicy{})
+	assert.NoError(t, err)
+	assert.Equal(t, aliceID, again)
+
+	bob := &models.ExternalIdentity{Issuer: "http://idp.test", Subject: "mock|bob", Email: "bob@example.com", EmailVerified: true}
+	_, err = storage.SignInExternalUser(ctx, bob, "bob", models.SSOPolicy{LinkExisting: true})
+	assert.ErrorIs(t, err, repository.ErrUserNotFound, "Without Provision no user should be created")
+
+	bobID, err := storage.SignInExternalUser(ctx, bob, "bob", policy)
+	assert.NoError(t, err)
+	roles, err := storage.GetUserRoles(ctx, bobID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee}, roles, "A provisioned user should be an employee")
+	created, err := storage.GetUserByUsername(ctx, "bob")
+	assert.NoError(t, err)
+	assert.Error(t, bcrypt.CompareHashAndPassword([]byte(created.PasswordHash), []byte("")), "A provisioned user should have no usable password")
+}
+
+func TestDirectoryUsers(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+
+	_, err = storage.CreateUser(ctx, "alice", "hash")
+	assert.NoError(t, err)
+	_, err = storage.GetUserProfile(ctx, "alice")
+	assert.ErrorIs(t, err, repository.ErrProfileNotFound, "A local user should have no directory profile")
+	_, err = storage.GetUserProfile(ctx, "nobody")
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+
+	alice := &models.DirectoryUser{Source: models.AuthenticatorLDAP, DN: "uid=alice,ou=people,dc=example,dc=org", Username: "alice", DisplayName: "Alice", Department: "Sales"}
+	_, err = storage.SyncDirectoryUser(ctx, alice, false, true)
+	assert.ErrorIs(t, err, repository.ErrIdentityNotLinked, "An existing user should not be taken over without linkExisting")
+
+	aliceID, err := storage.SyncDirectoryUser(ctx, alice, true, true)
+	assert.NoError(t, err)
+	user, err := storage.GetUserByUsername(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, aliceID, "The directory entry should be linked to the existing user")
+
+	// Once linked, later sign-ins sync the attributes without linkExisting.
+	alice.Department = "Marketing"
+	again, err := storage.SyncDirectoryUser(ctx, alice, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, aliceID, again)
+	profile, err := storage.GetUserProfile(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "Marketing", profile.Department, "Attributes should be synced on every sign-in")
+	assert.Equal(t, alice.DN, profile.DN)
+	assert.Equal(t, "Alice", profile.DisplayName)
+
+	// Linked users are found by their DN, not by the name.
+	renamed := *alice
+	renamed.Username = "Alice"
+	again, err = storage.SyncDirectoryUser(ctx, &renamed, false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, aliceID, again, "The same entry should not get a second user")
+
+	impostor := &models.DirectoryUser{Source: models.AuthenticatorLDAP, DN: "uid=alice,ou=contractors,dc=example,dc=org", Username: "alice"}
+	_, err = storage.SyncDirectoryUser(ctx, impostor, true, true)
+	assert.ErrorIs(t, err, repository.ErrIdentityNotLinked, "A user linked to one entry should not be taken over by another")
+
+	invalid := &models.DirectoryUser{Source: models.AuthenticatorLDAP, DN: "cn=Bob Jones,ou=people,dc=example,dc=org", Username: "Bob Jones"}
+	_, err = storage.SyncDirectoryUser(ctx, invalid, true, true)
+	assert.ErrorIs(t, err, models.ErrInvalidUsername, "A new user's name should pass the registration policy")
+
+	bob := &models.DirectoryUser{Source: models.AuthenticatorLDAP, DN: "uid=bob,ou=people,dc=example,dc=org", Username: "bob", Email: "bob@example.org"}
+	_, err = storage.SyncDirectoryUser(ctx, bob, true, false)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound, "Without provision no user should be created")
+
+	bobID, err := storage.SyncDirectoryUser(ctx, bob, true, true)
+	assert.NoError(t, err)
+	roles, err := storage.GetUserRoles(ctx, bobID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee}, roles, "A provisioned user should be an employee")
+	created, err := storage.GetUserByUsername(ctx, "bob")
+	assert.NoError(t, err)
+	assert.Error(t, bcrypt.CompareHashAndPassword([]byte(created.PasswordHash), []byte("")), "A provisioned user should have no usable password")
+	profile, err = storage.GetUserProfile(ctx, "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, "bob@example.org", profile.Email)
+}
+
+func TestTwoFactor(t *testing.T) {
+	db, dbPort, teardown := SetupTestDatabase()
+	defer teardown()
+
+	err := createTables(db)
+	assert.NoError(t, err, "Table creation should execute without errors")
+
+	storage, err := repository.New(&config.Config{
+		DBUser:     "postgres",
+		DBPassword: "postgres",
+		DBHost:     "localhost",
+		DBPort:     dbPort,
+		DBName:     "postgres",
+	})
+	assert.NoError(t, err, "Database connection should be successful")
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	userID, err := storage.CreateUser(ctx, "alice", "hash")
+	assert.NoError(t, err)
+	_, err = storage.GrantRole(ctx, "alice", models.RoleHRAdmin, userID)
+	assert.NoError(t, err)
+
+	enabled, err := storage.HasTwoFactor(ctx, userID)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+
+	assert.NoError(t, storage.StartTwoFactorEnrollment(ctx, userID, "FIRSTSECRET"))
+	assert.NoError(t, storage.StartTwoFactorEnrollment(ctx, userID, "SECONDSECRET"), "An unconfirmed enrollment should be replaced")
+	secret, confirmed, err := storage.GetTwoFactorSecret(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "SECONDSECRET", secret)
+	assert.False(t, confirmed)
+
+	err = storage.ConfirmTwoFactor(ctx, userID, "FIRSTSECRET", 100, nil)
+	assert.ErrorIs(t, err, repository.ErrTwoFactorNotEnrolled, "Only the pending secret should be confirmed")
+	hashes := []string{models.HashRecoveryCode("aaaa-aaaa"), models.HashRecoveryCode("bbbb-bbbb")}
+	assert.NoError(t, storage.ConfirmTwoFactor(ctx, userID, "SECONDSECRET", 100, hashes))
+
+	err = storage.StartTwoFactorEnrollment(ctx, userID, "THIRDSECRET")
+	assert.ErrorIs(t, err, repository.ErrTwoFactorEnabled, "A confirmed enrollment should not be replaced")
+
+	status, err := storage.GetTwoFactorStatus(ctx, userID)
+	assert.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, 2, status.RecoveryCodesLeft)
+	assert.False(t, status.Required)
+
+	grant := func(sessionID string) models.TokenGrant {
+		_, refreshHash, _ := models.NewRefreshToken()
+		return models.TokenGrant{
+			SessionID:        sessionID,
+			AccessTokenID:    "access-" + sessionID,
+			AccessExpiresAt:  now.Add(15 * time.Minute),
+			RefreshTokenHash: refreshHash,
+			RefreshExpiresAt: now.Add(time.Hour),
+		}
+	}
+
+	challenge := models.HashTwoFactorChallenge("challenge")
+	assert.NoError(t, storage.CreateTwoFactorChallenge(ctx, userID, challenge, now.Add(5*time.Minute)))
+	login, err := storage.GetTwoFactorChallenge(ctx, challenge)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TwoFactorLogin{UserID: userID, Username: "alice", Secret: "SECONDSECRET", LastStep: 100}, *login)
+
+	_, err = storage.CompleteTwoFactorLogin(ctx, challenge, models.TwoFactorProof{Step: 100}, grant("replayed"))
+	assert.ErrorIs(t, err, models.ErrInvalidTwoFactorCode, "A used time step should be rejected")
+	_, err = storage.CompleteTwoFactorLogin(ctx, challenge, models.TwoFactorProof{RecoveryCodeHash: models.HashRecoveryCode("cccc-cccc")}, grant("unknown"))
+	assert.ErrorIs(t, err, models.ErrInvalidTwoFactorCode)
+
+	completed, err := storage.CompleteTwoFactorLogin(ctx, challenge, models.TwoFactorProof{Step: 101}, grant("session-totp"))
+	assert.NoError(t, err, "A rejected code should leave the challenge for another attempt")
+	assert.Equal(t, userID, completed)
+	_, err = storage.GetTwoFactorChallenge(ctx, challenge)
+	assert.ErrorIs(t, err, repository.ErrTwoFactorChallengeInvalid, "A challenge should be used once")
+
+	recovery := models.HashTwoFactorChallenge("recovery")
+	assert.NoError(t, storage.CreateTwoFactorChallenge(ctx, userID, recovery, now.Add(5*time.Minute)))
+	_, err = storage.CompleteTwoFactorLogin(ctx, recovery, models.TwoFactorProof{RecoveryCodeHash: models.HashRecoveryCode("aaaa-aaaa")}, grant("session-recovery"))
+	assert.NoError(t, err)
+	status, err = storage.GetTwoFactorStatus(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, status.RecoveryCodesLeft, "A recovery code should be used once")
+
+	guessed := models.HashTwoFactorChallenge("guessed")
+	assert.NoError(t, storage.CreateTwoFactorChallenge(ctx, userID, guessed, now.Add(5*time.Minute)))
+	assert.NoError(t, storage.FailTwoFactorChallenge(ctx, guessed, 2))
+	_, err = storage.GetTwoFactorChallenge(ctx, guessed)
+	assert.NoError(t, err)
+	assert.NoError(t, storage.FailTwoFactorChallenge(ctx, guessed, 2))
+	_, err = storage.GetTwoFactorChallenge(ctx, guessed)
+	assert.ErrorIs(t, err, repository.ErrTwoFactorChallengeInvalid, "The challenge should be dropped after too many wrong codes")
+
+	expired := models.HashTwoFactorChallenge("expired")
+	assert.NoError(t, storage.CreateTwoFactorChallenge(ctx, userID, expired, now.Add(-time.Minute)))
+	_, err = storage.GetTwoFactorChallenge(ctx, expired)
+	assert.ErrorIs(t, err, repository.ErrTwoFactorChallengeInvalid)
+
+	// A password session without a second factor loses the role once it
+	// requires one; the two-factor session keeps it.
+	assert.NoError(t, storage.CreateSession(ctx, userID, grant("session-password")))
+	roles, err := storage.RequireTwoFactor(ctx, models.RoleHRAdmin, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleHRAdmin}, roles)
+
+	roles, err = storage.GetSessionRoles(ctx, "session-password")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee}, roles)
+	roles, err = storage.GetSessionRoles(ctx, "session-totp")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee, models.RoleHRAdmin}, roles)
+	roles, err = storage.GetDelegatedRoles(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee}, roles, "A service account acting for the user cannot use the role")
+
+	revoked, err := storage.ListRevokedTokens(ctx)
+	assert.NoError(t, err)
+	var revokedIDs []string
+	for _, token := range revoked {
+		revokedIDs = append(revokedIDs, token.ID)
+	}
+	assert.Contains(t, revokedIDs, "access-session-password", "Tokens carrying the role without a second factor should be revoked")
+	assert.NotContains(t, revokedIDs, "access-session-totp")
+
+	status, err = storage.GetTwoFactorStatus(ctx, userID)
+	assert.NoError(t, err)
+	assert.True(t, status.Required)
+
+	roles, err = storage.UnrequireTwoFactor(ctx, models.RoleHRAdmin)
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+	roles, err = storage.GetSessionRoles(ctx, "session-password")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleEmployee, models.RoleHRAdmin}, roles)
+
+	assert.NoError(t, storage.ResetTwoFactor(ctx, "alice"))
+	enabled, err = storage.HasTwoFactor(ctx, userID)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+	status, err = storage.GetTwoFactorStatus(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, status.RecoveryCodesLeft, "Recovery codes should be removed with the enrollment")
+	assert.ErrorIs(t, storage.ResetTwoFactor(ctx, "alice"), repository.ErrTwoFactorNotEnrolled)
+	assert.ErrorIs(t, storage.ResetTwoFactor(ctx, "nobody"), repository.ErrUserNotFound)
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// insertUserSQL creates user ($1, $2) with the employee role and returns its id.
+const insertUserSQL = `
+        WITH u AS (
+            INSERT INTO users (username, password_hash) VALUES ($1, $2) RETURNING id
+        ), r AS (
+            INSERT INTO user_roles (user_id, role) SELECT id, 'employee' FROM u
+        )
+        SELECT id FROM u`
+
+// GetUserRoles returns the roles of the user, ordered by name.
+func (s *Storage) GetUserRoles(ctx context.Context, userID int) ([]models.Role, error) {
+	const op = "domain.repository.GetUserRoles"
+
+	rows, err := s.db.Query(ctx, "SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role", userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roles, err := scanRoles(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// GetSessionRoles returns the roles the session may use: those of its user,
+// except roles that require a second factor if the session was started
+// without one.
+func (s *Storage) GetSessionRoles(ctx context.Context, sessionID string) ([]models.Role, error) {
+	const op = "domain.repository.GetSessionRoles"
+
+	rows, err := s.db.Query(ctx, `
+        SELECT ur.role
+        FROM sessions s
+        JOIN user_roles ur ON ur.user_id = s.user_id
+        WHERE s.id = $1
+          AND (s.two_factor OR NOT EXISTS (SELECT 1 FROM two_factor_roles tr WHERE tr.role = ur.role))
+        ORDER BY ur.role`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roles, err := scanRoles(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// GetDelegatedRoles returns the roles a service account may use when it acts
+// on behalf of the user: those of the user, except roles that require a
+// second factor, since such a request carries no proof of one.
+func (s *Storage) GetDelegatedRoles(ctx context.Context, userID int) ([]models.Role, error) {
+	const op = "domain.repository.GetDelegatedRoles"
+
+	rows, err := s.db.Query(ctx, `
+        SELECT ur.role
+        FROM user_roles ur
+        WHERE ur.user_id = $1
+          AND NOT EXISTS (SELECT 1 FROM two_factor_roles tr WHERE tr.role = ur.role)
+        ORDER BY ur.role`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roles, err := scanRoles(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// ListUserRoles returns the roles of the user with the given name.
+func (s *Storage) ListUserRoles(ctx context.Context, username string) (*models.UserRoles, error) {
+	const op = "domain.repository.ListUserRoles"
+
+	user, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roles, err := s.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &models.UserRoles{Username: username, Roles: roles}, nil
+}
+
+// GrantRole gives the role to the user. If the user did not have it, the
+// access tokens already handed out to them are revoked, so that the next
+// refresh issues tokens that carry the new role.
+func (s *Storage) GrantRole(ctx context.Context, username string, role models.Role, grantedBy int) (*models.UserRoles, error) {
+	const op = "domain.repository.GrantRole"
+
+	roles, err := s.changeRole(ctx, username, func(tx pgx.Tx, userID int) (int64, error) {
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO user_roles (user_id, role, granted_by) VALUES ($1, $2, $3)
+            ON CONFLICT (user_id, role) DO NOTHING`, userID, role, grantedBy)
+		return tag.RowsAffected(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// RevokeRole takes the role away from the user. If the user had it, the
+// access tokens already handed out to them are revoked.
+func (s *Storage) RevokeRole(ctx context.Context, username string, role models.Role) (*models.UserRoles, error) {
+	const op = "domain.repository.RevokeRole"
+
+	roles, err := s.changeRole(ctx, username, func(tx pgx.Tx, userID int) (int64, error) {
+		tag, err := tx.Exec(ctx, "DELETE FROM user_roles WHERE user_id = $1 AND role = $2", userID, role)
+		return tag.RowsAffected(), err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// SeedRoles gives the role to those of the listed users that exist. It is
+// used to bootstrap roles from the configuration on startup.
+func (s *Storage) SeedRoles(ctx context.Context, usernames []string, role models.Role) error {
+	const op = "domain.repository.SeedRoles"
+
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rows, err := tx.Query(ctx, `
+        INSERT INTO user_roles (user_id, role)
+        SELECT id, $2 FROM users WHERE username = ANY($1)
+        ON CONFLICT (user_id, role) DO NOTHING
+        RETURNING user_id`, usernames, role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var userIDs []int
+	for rows.Next() {
+		var userID int
+		if err = rows.Scan(&userID); err != nil {
+			rows.Close()
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, userID := range userIDs {
+		if err = revokeUserAccessTokens(ctx, tx, userID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// changeRole runs change for the user with the given name and, if it affected
+// a row, revokes the user's access tokens. It returns the resulting roles.
+func (s *Storage) changeRole(ctx context.Context, username string, change func(tx pgx.Tx, userID int) (int64, error)) (*models.UserRoles, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var userID int
+	err = tx.QueryRow(ctx, "SELECT id FROM users WHERE username = $1 FOR UPDATE", username).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	changed, err := change(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if changed > 0 {
+		if err = revokeUserAccessTokens(ctx, tx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.Query(ctx, "SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role", userID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := scanRoles(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &models.UserRoles{Username: username, Roles: roles}, nil
+}
+
+// revokeUserAccessTokens adds the unexpired access tokens of the user's live
+// sessions to the denylist. The sessions themselves stay valid.
+func revokeUserAccessTokens(ctx context.Context, tx pgx.Tx, userID int) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO revoked_tokens (token_id, expires_at)
+        SELECT rt.access_token_id, rt.access_expires_at
+        FROM refresh_tokens rt
+        JOIN sessions s ON s.id = rt.session_id
+        WHERE s.user_id = $1 AND s.revoked_at IS NULL AND rt.access_expires_at > LOCALTIMESTAMP
+        ON CONFLICT (token_id) DO NOTHING`, userID)
+	return err
+}
+
+func scanRoles(rows pgx.Rows) ([]models.Role, error) {
+	defer rows.Close()
+
+	roles := []models.Role{}
+	for rows.Next() {
+		var role models.Role
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const selectAPIKeys = `
+	SELECT k.id, a.name, k.key_hash, k.scopes, k.created_at, k.expires_at, k.last_used_at, k.revoked_at
+	FROM api_keys k
+	JOIN service_accounts a ON a.id = k.service_account_id`
+
+// CreateServiceAccount stores a new service account created by the given user.
+func (s *Storage) CreateServiceAccount(ctx context.Context, createdBy int, name, description string) (*models.ServiceAccount, error) {
+	const op = "domain.repository.CreateServiceAccount"
+
+	account := models.ServiceAccount{Name: name, Description: description, Keys: []models.APIKey{}}
+	err := s.db.QueryRow(ctx, `
+        WITH a AS (
+            INSERT INTO service_accounts (name, description, created_by)
+            VALUES ($1, $2, $3)
+            RETURNING created_by, created_at
+        )
+        SELECT COALESCE(u.username, ''), a.created_at FROM a LEFT JOIN users u ON u.id = a.created_by`,
+		name, description, createdBy).Scan(&account.CreatedBy, &account.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case uniqueViolation:
+				return nil, fmt.Errorf("%s: %w", op, ErrServiceAccountExists)
+			case foreignKeyViolation:
+				return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &account, nil
+}
+
+// ListServiceAccounts returns all service accounts by name, each with its
+// keys, newest first.
+func (s *Storage) ListServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error) {
+	const op = "domain.repository.ListServiceAccounts"
+
+	rows, err := s.db.Query(ctx, `
+        SELECT a.name, a.description, COALESCE(u.username, ''), a.created_at
+        FROM service_accounts a
+        LEFT JOIN users u ON u.id = a.created_by
+        ORDER BY a.name`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	accounts := make([]models.ServiceAccount, 0)
+	index := make(map[string]int)
+	for rows.Next() {
+		account := models.ServiceAccount{Keys: []models.APIKey{}}
+		if err := rows.Scan(&account.Name, &account.Description, &account.CreatedBy, &account.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		index[account.Name] = len(accounts)
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	rows.Close()
+
+	keyRows, err := s.db.Query(ctx, selectAPIKeys+" ORDER BY k.created_at DESC, k.id")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer keyRows.Close()
+
+	for keyRows.Next() {
+		key, err := scanAPIKey(keyRows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if i, ok := index[key.Account]; ok {
+			accounts[i].Keys = append(accounts[i].Keys, *key)
+		}
+	}
+	if err := keyRows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return accounts, nil
+}
+
+// CreateAPIKey stores a new key for the service account named key.Account.
+func (s *Storage) CreateAPIKey(ctx context.Context, key models.APIKey) (*models.APIKey, error) {
+	const op = "domain.repository.CreateAPIKey"
+
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	tag, err := s.db.Exec(ctx, `
+        INSERT INTO api_keys (id, service_account_id, key_hash, scopes, expires_at)
+        SELECT $1, id, $3, $4, $5 FROM service_accounts WHERE name = $2`,
+		key.ID, key.Account, key.Hash, scopes, key.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrServiceAccountNotFound)
+	}
+
+	created, err := scanAPIKey(s.db.QueryRow(ctx, selectAPIKeys+" WHERE k.id = $1", key.ID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return created, nil
+}
+
+// GetAPIKey returns the key with the given id, including revoked and expired
+// ones.
+func (s *Storage) GetAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	const op = "domain.repository.GetAPIKey"
+
+	key, err := scanAPIKey(s.db.QueryRow(ctx, selectAPIKeys+" WHERE k.id = $1", id))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+// RevokeAPIKey revokes a key of the service account. Revoking a key twice is
+// not an error.
+func (s *Storage) RevokeAPIKey(ctx context.Context, account, id string) error {
+	const op = "domain.repository.RevokeAPIKey"
+
+	tag, err := s.db.Exec(ctx, `
+        UPDATE api_keys k SET revoked_at = COALESCE(k.revoked_at, LOCALTIMESTAMP)
+        FROM service_accounts a
+        WHERE a.id = k.service_account_id AND a.name = $1 AND k.id = $2`, account, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrAPIKeyNotFound)
+	}
+
+	return nil
+}
+
+// TouchAPIKey records that the key was just used. To spare a write on every
+// request, the time is updated at most once a minute.
+func (s *Storage) TouchAPIKey(ctx context.Context, id string) error {
+	const op = "domain.repository.TouchAPIKey"
+
+	_, err := s.db.Exec(ctx, `
+        UPDATE api_keys SET last_used_at = LOCALTIMESTAMP
+        WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < LOCALTIMESTAMP - INTERVAL '1 minute')`, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var (
+		key    models.APIKey
+		scopes []string
+	)
+	err := row.Scan(&key.ID, &key.Account, &key.Hash, &scopes, &key.CreatedAt, &key.ExpiresAt, &key.LastUsedAt, &key.RevokedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	key.Scopes = make([]models.Scope, len(scopes))
+	for i, scope := range scopes {
+		key.Scopes[i] = models.Scope(scope)
+	}
+
+	return &key, nil
+}
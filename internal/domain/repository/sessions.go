@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CreateSession starts a session for the user with its first token pair.
+func (s *Storage) CreateSession(ctx context.Context, userID int, grant models.TokenGrant) error {
+	const op = "domain.repository.CreateSession"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, "INSERT INTO sessions (id, user_id) VALUES ($1, $2)", grant.SessionID, userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = insertTokenGrant(ctx, tx, grant); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// RotateRefreshToken exchanges a refresh token for the next token pair of the
+// same session and returns the session's user and id. A refresh token that
+// was already rotated is a sign it leaked: the whole session is revoked and
+// models.ErrRefreshTokenReused is returned.
+func (s *Storage) RotateRefreshToken(ctx context.Context, refreshTokenHash string, next models.TokenGrant) (int, string, error) {
+	const op = "domain.repository.RotateRefreshToken"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var (
+		userID                    int
+		sessionID                 string
+		expired, rotated, revoked bool
+	)
+	err = tx.QueryRow(ctx, `
+        SELECT s.user_id, s.id, rt.expires_at <= LOCALTIMESTAMP, rt.rotated_at IS NOT NULL, s.revoked_at IS NOT NULL
+        FROM refresh_tokens rt
+        JOIN sessions s ON s.id = rt.session_id
+        WHERE rt.token_hash = $1
+        FOR UPDATE OF rt, s`, refreshTokenHash).Scan(&userID, &sessionID, &expired, &rotated, &revoked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrUnauthorized
+		}
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch {
+	case revoked, expired:
+		err = ErrUnauthorized
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	case rotated:
+		if err = revokeSession(ctx, tx, sessionID); err != nil {
+			return 0, "", fmt.Errorf("%s: %w", op, err)
+		}
+		if err = tx.Commit(ctx); err != nil {
+			return 0, "", fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+		}
+		return 0, "", fmt.Errorf("%s: %w", op, models.ErrRefreshTokenReused)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE refresh_tokens SET rotated_at = LOCALTIMESTAMP WHERE token_hash = $1", refreshTokenHash)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	next.SessionID = sessionID
+	if err = insertTokenGrant(ctx, tx, next); err != nil {
+		return 0, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, "", fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return userID, sessionID, nil
+}
+
+// RevokeSession ends the session: its refresh tokens stop working and its
+// access tokens that have not expired yet are added to the denylist.
+func (s *Storage) RevokeSession(ctx context.Context, sessionID string) error {
+	const op = "domain.repository.RevokeSession"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM sessions WHERE id = $1)", sessionID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		err = ErrSessionNotFound
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = revokeSession(ctx, tx, sessionID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListRevokedTokens returns the revoked access tokens that have not expired.
+func (s *Storage) ListRevokedTokens(ctx context.Context) ([]models.RevokedToken, error) {
+	const op = "domain.repository.ListRevokedTokens"
+
+	rows, err := s.db.Query(ctx, "SELECT token_id, expires_at FROM revoked_tokens WHERE expires_at > LOCALTIMESTAMP")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tokens []models.RevokedToken
+	for rows.Next() {
+		var token models.RevokedToken
+		if err := rows.Scan(&token.ID, &token.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// PurgeExpiredSessions deletes sessions whose refresh tokens have all
+// expired, denylist entries for access tokens that have expired anyway, and
+// single sign-on logins and second login steps that were never finished.
+func (s *Storage) PurgeExpiredSessions(ctx context.Context) error {
+	const op = "domain.repository.PurgeExpiredSessions"
+
+	_, err := s.db.Exec(ctx, `
+        DELETE FROM sessions s
+        WHERE NOT EXISTS (
+            SELECT 1 FROM refresh_tokens rt
+            WHERE rt.session_id = s.id AND rt.expires_at > LOCALTIMESTAMP)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.db.Exec(ctx, "DELETE FROM revoked_tokens WHERE expires_at <= LOCALTIMESTAMP")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.db.Exec(ctx, "DELETE FROM sso_logins WHERE expires_at <= LOCALTIMESTAMP")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.db.Exec(ctx, "DELETE FROM two_factor_challenges WHERE expires_at <= LOCALTIMESTAMP")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func insertTokenGrant(ctx context.Context, tx pgx.Tx, grant models.TokenGrant) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO refresh_tokens (token_hash, session_id, access_token_id, access_expires_at, expires_at)
+        VALUES ($1, $2, $3, $4, $5)`,
+		grant.RefreshTokenHash, grant.SessionID, grant.AccessTokenID, grant.AccessExpiresAt, grant.RefreshExpiresAt)
+	return err
+}
+
+func revokeSession(ctx context.Context, tx pgx.Tx, sessionID string) error {
+	_, err := tx.Exec(ctx, "UPDATE sessions SET revoked_at = LOCALTIMESTAMP WHERE id = $1 AND revoked_at IS NULL", sessionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO revoked_tokens (token_id, expires_at)
+        SELECT access_token_id, access_expires_at FROM refresh_tokens
+        WHERE session_id = $1 AND access_expires_at > LOCALTIMESTAMP
+        ON CONFLICT (token_id) DO NOTHING`, sessionID)
+	return err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+)
+
+// ListSigningKeys returns the token signing keys that have not retired yet,
+// oldest first.
+func (s *Storage) ListSigningKeys(ctx context.Context) ([]models.SigningKey, error) {
+	const op = "domain.repository.ListSigningKeys"
+
+	rows, err := s.db.Query(ctx, `
+        SELECT kid, algorithm, private_key, created_at, retires_at FROM signing_keys
+        WHERE retires_at IS NULL OR retires_at > LOCALTIMESTAMP
+        ORDER BY created_at, kid`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var keys []models.SigningKey
+	for rows.Next() {
+		var key models.SigningKey
+		if err := rows.Scan(&key.ID, &key.Algorithm, &key.PrivateKey, &key.CreatedAt, &key.RetiresAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// AddSigningKey stores a new signing key, schedules the current keys to
+// retire at retireAt and deletes keys that have already retired.
+func (s *Storage) AddSigningKey(ctx context.Context, key models.SigningKey, retireAt time.Time) error {
+	const op = "domain.repository.AddSigningKey"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, "DELETE FROM signing_keys WHERE retires_at <= LOCALTIMESTAMP")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE signing_keys SET retires_at = $1 WHERE retires_at IS NULL", retireAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO signing_keys (kid, algorithm, private_key, created_at)
+        VALUES ($1, $2, $3, $4)`, key.ID, key.Algorithm, key.PrivateKey, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
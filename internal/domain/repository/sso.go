@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// unusablePasswordHash is stored for users created by single sign-on. It is
+// not a bcrypt hash, so no password matches it.
+const unusablePasswordHash = "!sso"
+
+// CreateSSOLogin remembers a sign-in started with the identity provider.
+func (s *Storage) CreateSSOLogin(ctx context.Context, login models.SSOLogin) error {
+	const op = "domain.repository.CreateSSOLogin"
+
+	_, err := s.db.Exec(ctx, `
+        INSERT INTO sso_logins (state_hash, nonce, code_verifier, expires_at)
+        VALUES ($1, $2, $3, $4)`, login.StateHash, login.Nonce, login.CodeVerifier, login.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// TakeSSOLogin returns and forgets the sign-in with the given state hash, so
+// that a redirect from the provider is accepted only once. An unknown or
+// expired state gives ErrSSOStateInvalid.
+func (s *Storage) TakeSSOLogin(ctx context.Context, stateHash string) (*models.SSOLogin, error) {
+	const op = "domain.repository.TakeSSOLogin"
+
+	var login models.SSOLogin
+	err := s.db.QueryRow(ctx, `
+        DELETE FROM sso_logins WHERE state_hash = $1
+        RETURNING state_hash, nonce, code_verifier, expires_at`, stateHash).
+		Scan(&login.StateHash, &login.Nonce, &login.CodeVerifier, &login.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrSSOStateInvalid
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if !login.ExpiresAt.After(time.Now().UTC()) {
+		return nil, fmt.Errorf("%s: %w", op, ErrSSOStateInvalid)
+	}
+
+	return &login, nil
+}
+
+// SignInExternalUser returns the local user of the identity provider account.
+// An account seen before keeps its user even if its username claim changed.
+// Otherwise the account is linked to the user with the given name, which must
+// be allowed by the policy: an existing user needs LinkExisting
+// (ErrIdentityNotLinked), a missing one Provision (ErrUserNotFound).
+func (s *Storage) SignInExternalUser(ctx context.Context, identity *models.ExternalIdentity, username string, policy models.SSOPolicy) (int, error) {
+	const op = "domain.repository.SignInExternalUser"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var userID int
+	err = tx.QueryRow(ctx, `
+        UPDATE user_identities SET email = $3, last_login_at = LOCALTIMESTAMP
+        WHERE issuer = $1 AND subject = $2
+        RETURNING user_id`, identity.Issuer, identity.Subject, identity.Email).Scan(&userID)
+	if err == nil {
+		if err = tx.Commit(ctx); err != nil {
+			return 0, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+		}
+		return userID, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.QueryRow(ctx, "SELECT id FROM users WHERE username = $1 FOR UPDATE", username).Scan(&userID)
+	switch {
+	case err == nil && !policy.LinkExisting:
+		err = ErrIdentityNotLinked
+		return 0, fmt.Errorf("%s: %w", op, err)
+	case errors.Is(err, pgx.ErrNoRows) && !policy.Provision:
+		err = ErrUserNotFound
+		return 0, fmt.Errorf("%s: %w", op, err)
+	case errors.Is(err, pgx.ErrNoRows):
+		err = tx.QueryRow(ctx, insertUserSQL, username, unusablePasswordHash).Scan(&userID)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+				err = ErrUserExists
+			}
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	case err != nil:
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO user_identities (issuer, subject, user_id, email)
+        VALUES ($1, $2, $3, $4)`, identity.Issuer, identity.Subject, userID, identity.Email)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/models"
+)
+
+const selectItemTransfers = `
+	SELECT t.id, su.username, ru.username, t.item_name, t.quantity, t.created_at
+	FROM item_transfers t
+	JOIN users su ON su.id = t.from_user_id
+	JOIN users ru ON ru.id = t.to_user_id`
+
+// TransferItem moves owned items from one user's inventory to another's and
+// records the movement. Both users are locked in id order so that opposite
+// transfers between the same pair cannot deadlock.
+func (s *Storage) TransferItem(ctx context.Context, fromUserID, toUserID int, item string, quantity int) error {
+	const op = "domain.repository.TransferItem"
+
+	if quantity <= 0 {
+		return fmt.Errorf("%s: %w", op, ErrInvalidQuantity)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			err := tx.Rollback(ctx)
+			if err != nil {
+				log.Printf("%s: rollback failed: %v", op, err)
+			}
+		}
+	}()
+
+	if err = lockUsers(ctx, tx, fromUserID, toUserID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = removeFromInventory(ctx, tx, fromUserID, item, quantity); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = addToInventory(ctx, tx, toUserID, item, quantity); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO item_transfers (from_user_id, to_user_id, item_name, quantity) VALUES ($1, $2, $3, $4)",
+		fromUserID, toUserID, item, quantity)
+	if err != nil {
+		return fmt.Errorf("%s: failed to record transfer: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetUserItemHistory returns the items the user received from and handed
+// over to other users, newest first.
+func (s *Storage) GetUserItemHistory(ctx context.Context, userID int) (received, sent []models.ItemTransfer, err error) {
+	const op = "domain.repository.GetUserItemHistory"
+
+	received, err = s.queryItemTransfers(ctx, selectItemTransfers+" WHERE t.to_user_id = $1 ORDER BY t.created_at DESC, t.id DESC", userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sent, err = s.queryItemTransfers(ctx, selectItemTransfers+" WHERE t.from_user_id = $1 ORDER BY t.created_at DESC, t.id DESC", userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return received, sent, nil
+}
+
+func (s *Storage) queryItemTransfers(ctx context.Context, query string, args ...any) ([]models.ItemTransfer, error) {
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []models.ItemTransfer
+	for rows.Next() {
+		var t models.ItemTransfer
+		if err := rows.Scan(&t.ID, &t.Sender, &t.Recipient, &t.Item, &t.Quantity, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+
+	return transfers, rows.Err()
+}
@@ -0,0 +1,401 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// StartTwoFactorEnrollment stores a new TOTP secret for the user, replacing
+// an enrollment that was never confirmed. A confirmed enrollment is kept and
+// ErrTwoFactorEnabled is returned.
+func (s *Storage) StartTwoFactorEnrollment(ctx context.Context, userID int, secret string) error {
+	const op = "domain.repository.StartTwoFactorEnrollment"
+
+	tag, err := s.db.Exec(ctx, `
+        INSERT INTO two_factor (user_id, secret) VALUES ($1, $2)
+        ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, created_at = LOCALTIMESTAMP
+        WHERE two_factor.confirmed_at IS NULL`, userID, secret)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrTwoFactorEnabled)
+	}
+
+	return nil
+}
+
+// GetTwoFactorSecret returns the user's TOTP secret and whether the
+// enrollment was confirmed.
+func (s *Storage) GetTwoFactorSecret(ctx context.Context, userID int) (string, bool, error) {
+	const op = "domain.repository.GetTwoFactorSecret"
+
+	var (
+		secret    string
+		confirmed bool
+	)
+	err := s.db.QueryRow(ctx, "SELECT secret, confirmed_at IS NOT NULL FROM two_factor WHERE user_id = $1", userID).
+		Scan(&secret, &confirmed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrTwoFactorNotEnrolled
+		}
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return secret, confirmed, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication with the pending secret
+// the code was checked against, and replaces the user's recovery codes. The
+// code's time step counts as used. ErrTwoFactorNotEnrolled is returned if the
+// secret is no longer pending.
+func (s *Storage) ConfirmTwoFactor(ctx context.Context, userID int, secret string, step int64, recoveryCodeHashes []string) error {
+	const op = "domain.repository.ConfirmTwoFactor"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, `
+        UPDATE two_factor SET confirmed_at = LOCALTIMESTAMP, last_used_step = $3
+        WHERE user_id = $1 AND secret = $2 AND confirmed_at IS NULL`, userID, secret, step)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = ErrTwoFactorNotEnrolled
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM recovery_codes WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO recovery_codes (user_id, code_hash)
+        SELECT $1, unnest($2::text[])`, userID, recoveryCodeHashes)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetTwoFactorStatus returns whether the user has two-factor authentication
+// enabled, how many recovery codes are left and whether a role of the user
+// requires it.
+func (s *Storage) GetTwoFactorStatus(ctx context.Context, userID int) (*models.TwoFactorStatus, error) {
+	const op = "domain.repository.GetTwoFactorStatus"
+
+	var status models.TwoFactorStatus
+	err := s.db.QueryRow(ctx, `
+        SELECT
+            (SELECT confirmed_at FROM two_factor WHERE user_id = $1),
+            (SELECT COUNT(*) FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL),
+            EXISTS (
+                SELECT 1 FROM user_roles ur
+                JOIN two_factor_roles tr ON tr.role = ur.role
+                WHERE ur.user_id = $1)`, userID).
+		Scan(&status.ConfirmedAt, &status.RecoveryCodesLeft, &status.Required)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	status.Enabled = status.ConfirmedAt != nil
+
+	return &status, nil
+}
+
+// HasTwoFactor reports whether the user has confirmed two-factor
+// authentication, so their logins need a second step.
+func (s *Storage) HasTwoFactor(ctx context.Context, userID int) (bool, error) {
+	const op = "domain.repository.HasTwoFactor"
+
+	var enabled bool
+	err := s.db.QueryRow(ctx, `
+        SELECT EXISTS (SELECT 1 FROM two_factor WHERE user_id = $1 AND confirmed_at IS NOT NULL)`, userID).
+		Scan(&enabled)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return enabled, nil
+}
+
+// ResetTwoFactor removes the user's enrollment, recovery codes and pending
+// second login steps, so the user signs in with the password alone until
+// they enroll again.
+func (s *Storage) ResetTwoFactor(ctx context.Context, username string) error {
+	const op = "domain.repository.ResetTwoFactor"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var userID int
+	err = tx.QueryRow(ctx, "SELECT id FROM users WHERE username = $1", username).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrUserNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tag, err := tx.Exec(ctx, "DELETE FROM two_factor WHERE user_id = $1", userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = ErrTwoFactorNotEnrolled
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, "DELETE FROM recovery_codes WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err = tx.Exec(ctx, "DELETE FROM two_factor_challenges WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+// CreateTwoFactorChallenge stores a login that passed the password check and
+// waits for the second factor.
+func (s *Storage) CreateTwoFactorChallenge(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	const op = "domain.repository.CreateTwoFactorChallenge"
+
+	_, err := s.db.Exec(ctx, `
+        INSERT INTO two_factor_challenges (token_hash, user_id, expires_at) VALUES ($1, $2, $3)`,
+		tokenHash, userID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetTwoFactorChallenge returns the pending login with the user's TOTP
+// secret. Unknown and expired challenges are ErrTwoFactorChallengeInvalid.
+func (s *Storage) GetTwoFactorChallenge(ctx context.Context, tokenHash string) (*models.TwoFactorLogin, error) {
+	const op = "domain.repository.GetTwoFactorChallenge"
+
+	var login models.TwoFactorLogin
+	err := s.db.QueryRow(ctx, `
+        SELECT c.user_id, u.username, tf.secret, tf.last_used_step
+        FROM two_factor_challenges c
+        JOIN users u ON u.id = c.user_id
+        JOIN two_factor tf ON tf.user_id = c.user_id AND tf.confirmed_at IS NOT NULL
+        WHERE c.token_hash = $1 AND c.expires_at > LOCALTIMESTAMP`, tokenHash).
+		Scan(&login.UserID, &login.Username, &login.Secret, &login.LastStep)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrTwoFactorChallengeInvalid
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &login, nil
+}
+
+// FailTwoFactorChallenge counts a wrong code against the challenge and
+// deletes the challenge once maxAttempts codes were wrong, so the password
+// has to be entered again.
+func (s *Storage) FailTwoFactorChallenge(ctx context.Context, tokenHash string, maxAttempts int) error {
+	const op = "domain.repository.FailTwoFactorChallenge"
+
+	_, err := s.db.Exec(ctx, `
+        WITH failed AS (
+            UPDATE two_factor_challenges SET attempts = attempts + 1
+            WHERE token_hash = $1
+            RETURNING token_hash, attempts)
+        DELETE FROM two_factor_challenges c
+        USING failed f
+        WHERE c.token_hash = f.token_hash AND f.attempts >= $2`, tokenHash, maxAttempts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CompleteTwoFactorLogin uses up the challenge and the second factor and
+// starts a two-factor session with the grant. A recovery code is accepted
+// once; a TOTP step is accepted only if it is later than the last one used,
+// so a code cannot be replayed. Otherwise models.ErrInvalidTwoFactorCode is
+// returned and the challenge stays for another attempt.
+func (s *Storage) CompleteTwoFactorLogin(ctx context.Context, tokenHash string, proof models.TwoFactorProof, grant models.TokenGrant) (int, error) {
+	const op = "domain.repository.CompleteTwoFactorLogin"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var userID int
+	err = tx.QueryRow(ctx, `
+        DELETE FROM two_factor_challenges
+        WHERE token_hash = $1 AND expires_at > LOCALTIMESTAMP
+        RETURNING user_id`, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrTwoFactorChallengeInvalid
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var tag pgconn.CommandTag
+	if proof.RecoveryCodeHash != "" {
+		tag, err = tx.Exec(ctx, `
+            UPDATE recovery_codes SET used_at = LOCALTIMESTAMP
+            WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`, userID, proof.RecoveryCodeHash)
+	} else {
+		tag, err = tx.Exec(ctx, `
+            UPDATE two_factor SET last_used_step = $2
+            WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2`, userID, proof.Step)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = models.ErrInvalidTwoFactorCode
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "INSERT INTO sessions (id, user_id, two_factor) VALUES ($1, $2, TRUE)", grant.SessionID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err = insertTokenGrant(ctx, tx, grant); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return userID, nil
+}
+
+// ListTwoFactorRoles returns the roles that are only granted to sessions
+// started with a second factor.
+func (s *Storage) ListTwoFactorRoles(ctx context.Context) ([]models.Role, error) {
+	const op = "domain.repository.ListTwoFactorRoles"
+
+	rows, err := s.db.Query(ctx, "SELECT role FROM two_factor_roles ORDER BY role")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roles, err := scanRoles(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// RequireTwoFactor makes the role require a second factor. Access tokens
+// that carry the role but belong to sessions started without one are
+// revoked, so their next refresh drops the role.
+func (s *Storage) RequireTwoFactor(ctx context.Context, role models.Role, requiredBy int) ([]models.Role, error) {
+	const op = "domain.repository.RequireTwoFactor"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	tag, err := tx.Exec(ctx, `
+        INSERT INTO two_factor_roles (role, required_by) VALUES ($1, $2)
+        ON CONFLICT (role) DO NOTHING`, role, requiredBy)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO revoked_tokens (token_id, expires_at)
+            SELECT rt.access_token_id, rt.access_expires_at
+            FROM refresh_tokens rt
+            JOIN sessions s ON s.id = rt.session_id
+            JOIN user_roles ur ON ur.user_id = s.user_id
+            WHERE ur.role = $1 AND NOT s.two_factor AND s.revoked_at IS NULL
+              AND rt.access_expires_at > LOCALTIMESTAMP
+            ON CONFLICT (token_id) DO NOTHING`, role)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	rows, err := tx.Query(ctx, "SELECT role FROM two_factor_roles ORDER BY role")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	roles, err := scanRoles(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return roles, nil
+}
+
+// UnrequireTwoFactor lets the role be used without a second factor again. It
+// is picked up by the next refresh.
+func (s *Storage) UnrequireTwoFactor(ctx context.Context, role models.Role) ([]models.Role, error) {
+	const op = "domain.repository.UnrequireTwoFactor"
+
+	if _, err := s.db.Exec(ctx, "DELETE FROM two_factor_roles WHERE role = $1", role); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	roles, err := s.ListTwoFactorRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
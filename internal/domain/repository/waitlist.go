@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// JoinWaitlist subscribes the user to a sold-out product. Joining twice keeps
+// the original place in the queue.
+func (s *Storage) JoinWaitlist(ctx context.Context, userID int, item string) (*models.WaitlistEntry, error) {
+	const op = "domain.repository.JoinWaitlist"
+
+	var stock, reserved *int
+	err := s.db.QueryRow(ctx, `
+        SELECT p.stock, (SELECT SUM(r.quantity) FROM stock_reservations r
+                         WHERE r.product_name = p.name AND r.expires_at > LOCALTIMESTAMP)
+        FROM products p WHERE p.name = $1`, item).Scan(&stock, &reserved)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrItemNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if stock == nil || (reserved == nil && *stock > 0) || (reserved != nil && *stock > *reserved) {
+		return nil, fmt.Errorf("%s: %w", op, ErrItemInStock)
+	}
+
+	_, err = s.db.Exec(ctx, `
+        INSERT INTO waitlist (product_name, user_id) VALUES ($1, $2)
+        ON CONFLICT (product_name, user_id) DO NOTHING`, item, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entries, err := s.queryWaitlist(ctx, "WHERE w.user_id = $1 AND w.product_name = $2", userID, item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotOnWaitlist)
+	}
+
+	return &entries[0], nil
+}
+
+func (s *Storage) LeaveWaitlist(ctx context.Context, userID int, item string) error {
+	const op = "domain.repository.LeaveWaitlist"
+
+	tag, err := s.db.Exec(ctx, "DELETE FROM waitlist WHERE user_id = $1 AND product_name = $2", userID, item)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNotOnWaitlist)
+	}
+
+	return nil
+}
+
+// ListUserWaitlists returns the user's subscriptions with their place in each queue.
+func (s *Storage) ListUserWaitlists(ctx context.Context, userID int) ([]models.WaitlistEntry, error) {
+	const op = "domain.repository.ListUserWaitlists"
+
+	entries, err := s.queryWaitlist(ctx, "WHERE w.user_id = $1", userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// Restock adds units to the product's stock and empties its waitlist. The
+// subscribers are returned in the order they joined; the first ones get a
+// unit reserved for them according to the policy. Products without stock
+// tracking are never sold out, so restocking them is ErrStockNotTracked.
+func (s *Storage) Restock(ctx context.Context, item string, quantity int, policy models.ReservePolicy) ([]models.RestockNotice, error) {
+	const op = "domain.repository.Restock"
+
+	if quantity <= 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidRestock)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var stock *int
+	err = tx.QueryRow(ctx, "SELECT stock FROM products WHERE name = $1 FOR UPDATE", item).Scan(&stock)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = ErrItemNotFound
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return nil, fmt.Errorf("%s: failed to get stock: %w", op, err)
+	}
+	if stock == nil {
+		err = ErrStockNotTracked
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE products SET stock = stock + $1 WHERE name = $2", quantity, item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to update stock: %w", op, err)
+	}
+
+	rows, err := tx.Query(ctx, `
+        SELECT w.user_id, u.username FROM waitlist w
+        JOIN users u ON u.id = w.user_id
+        WHERE w.product_name = $1
+        ORDER BY w.created_at, w.id
+        FOR UPDATE OF w`, item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get waitlist: %w", op, err)
+	}
+
+	var notices []models.RestockNotice
+	for rows.Next() {
+		notice := models.RestockNotice{Product: item}
+		if err = rows.Scan(&notice.UserID, &notice.Username); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		notices = append(notices, notice)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	until := time.Now().UTC().Add(policy.TTL)
+	for i := 0; i < policy.Slots(len(notices), quantity); i++ {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO stock_reservations (product_name, user_id, quantity, expires_at)
+            VALUES ($1, $2, 1, $3)`, item, notices[i].UserID, until)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to reserve stock: %w", op, err)
+		}
+		notices[i].ReservedUntil = &until
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM waitlist WHERE product_name = $1", item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to clear waitlist: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return notices, nil
+}
+
+// queryWaitlist returns waitlist entries matching the filter on the table
+// aliased as w, with each entry's position in its product's queue.
+func (s *Storage) queryWaitlist(ctx context.Context, filter string, args ...any) ([]models.WaitlistEntry, error) {
+	rows, err := s.db.Query(ctx, `
+        SELECT w.product_name, w.created_at,
+               (SELECT COUNT(*) FROM waitlist q
+                WHERE q.product_name = w.product_name AND (q.created_at, q.id) <= (w.created_at, w.id))
+        FROM waitlist w `+filter+`
+        ORDER BY w.created_at, w.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.WaitlistEntry
+	for rows.Next() {
+		var entry models.WaitlistEntry
+		if err := rows.Scan(&entry.Product, &entry.CreatedAt, &entry.Position); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
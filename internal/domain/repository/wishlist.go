@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (s *Storage) AddWishlistItem(ctx context.Context, userID int, item string, note *string) error {
+	const op = "domain.repository.AddWishlistItem"
+
+	_, err := s.db.Exec(ctx, "INSERT INTO wishlist_items (user_id, product_name, note) VALUES ($1, $2, $3)", userID, item, note)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case uniqueViolation:
+				return fmt.Errorf("%s: %w", op, ErrWishlistItemExists)
+			case foreignKeyViolation:
+				return fmt.Errorf("%s: %w", op, ErrItemNotFound)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateWishlistItem(ctx context.Context, userID int, item string, note *string) error {
+	const op = "domain.repository.UpdateWishlistItem"
+
+	tag, err := s.db.Exec(ctx, "UPDATE wishlist_items SET note = $1 WHERE user_id = $2 AND product_name = $3", note, userID, item)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrWishlistItemNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) RemoveWishlistItem(ctx context.Context, userID int, item string) error {
+	const op = "domain.repository.RemoveWishlistItem"
+
+	tag, err := s.db.Exec(ctx, "DELETE FROM wishlist_items WHERE user_id = $1 AND product_name = $2", userID, item)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrWishlistItemNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) SetWishlistPublic(ctx context.Context, userID int, public bool) error {
+	const op = "domain.repository.SetWishlistPublic"
+
+	tag, err := s.db.Exec(ctx, "UPDATE users SET wishlist_public = $1 WHERE id = $2", public, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetWishlist(ctx context.Context, userID int) (*models.Wishlist, error) {
+	const op = "domain.repository.GetWishlist"
+
+	var wishlist models.Wishlist
+	err := s.db.QueryRow(ctx, "SELECT wishlist_public FROM users WHERE id = $1", userID).Scan(&wishlist.Public)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	wishlist.Items, err = s.wishlistItems(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &wishlist, nil
+}
+
+// GetPublicWishlist returns another user's wishlist if they chose to share it.
+func (s *Storage) GetPublicWishlist(ctx context.Context, username string) (*models.Wishlist, error) {
+	const op = "domain.repository.GetPublicWishlist"
+
+	var (
+		userID   int
+		wishlist models.Wishlist
+	)
+	err := s.db.QueryRow(ctx, "SELECT id, wishlist_public FROM users WHERE username = $1", username).Scan(&userID, &wishlist.Public)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !wishlist.Public {
+		return nil, fmt.Errorf("%s: %w", op, ErrWishlistPrivate)
+	}
+
+	wishlist.Items, err = s.wishlistItems(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &wishlist, nil
+}
+
+func (s *Storage) wishlistItems(ctx context.Context, userID int) ([]models.WishlistItem, error) {
+	rows, err := s.db.Query(ctx, `
+        SELECT w.product_name, w.note, `+currentPriceSQL+`, `+availableSQL+`, w.created_at
+        FROM wishlist_items w
+        JOIN products p ON p.name = w.product_name
+        WHERE w.user_id = $1
+        ORDER BY w.created_at, w.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.WishlistItem
+	for rows.Next() {
+		var item models.WishlistItem
+		if err := rows.Scan(&item.Product, &item.Note, &item.Price, &item.Available, &item.AddedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
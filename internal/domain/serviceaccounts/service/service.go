@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"time"
+)
+
+var ErrInvalidAPIKeyRequest = errors.New("invalid API key request")
+
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=ServiceAccountServiceInterface
+type ServiceAccountServiceInterface interface {
+	CreateServiceAccount(ctx context.Context, adminID int, name, description string) (*models.ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error)
+	CreateAPIKey(ctx context.Context, account string, scopes []string, expiresAt *time.Time) (*models.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, account, keyID string) error
+	AuthenticateAPIKey(ctx context.Context, key, onBehalfOf string) (*models.APIPrincipal, error)
+}
+
+type ServiceAccountService struct {
+	storage *repository.Storage
+}
+
+func NewServiceAccountService(storage *repository.Storage) *ServiceAccountService {
+	return &ServiceAccountService{
+		storage: storage,
+	}
+}
+
+func (s *ServiceAccountService) CreateServiceAccount(ctx context.Context, adminID int, name, description string) (*models.ServiceAccount, error) {
+	if err := models.ValidateServiceAccountName(name); err != nil {
+		return nil, err
+	}
+
+	return s.storage.CreateServiceAccount(ctx, adminID, name, description)
+}
+
+func (s *ServiceAccountService) ListServiceAccounts(ctx context.Context) ([]models.ServiceAccount, error) {
+	return s.storage.ListServiceAccounts(ctx)
+}
+
+// CreateAPIKey issues a key for the service account and returns it together
+// with the plain key. The plain key is not stored and cannot be shown again.
+func (s *ServiceAccountService) CreateAPIKey(ctx context.Context, account string, scopes []string, expiresAt *time.Time) (*models.APIKey, string, error) {
+	parsed, err := models.ParseScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if expiresAt != nil {
+		// Timestamps are stored without a time zone, so keep them all in UTC.
+		utc := expiresAt.UTC()
+		if !utc.After(time.Now()) {
+			return nil, "", fmt.Errorf("%w: expiry must be in the future", ErrInvalidAPIKeyRequest)
+		}
+		expiresAt = &utc
+	}
+
+	plain, id, hash, err := models.NewAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	key, err := s.storage.CreateAPIKey(ctx, models.APIKey{
+		ID:        id,
+		Account:   account,
+		Hash:      hash,
+		Scopes:    parsed,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return key, plain, nil
+}
+
+func (s *ServiceAccountService) RevokeAPIKey(ctx context.Context, account, keyID string) error {
+	return s.storage.RevokeAPIKey(ctx, account, keyID)
+}
+
+// AuthenticateAPIKey checks the key and returns who the request acts as.
+// Malformed, unknown, expired and revoked keys are all reported as
+// models.ErrInvalidAPIKey. With onBehalfOf the service account acts as that
+// user, with the user's roles that do not require a second factor.
+func (s *ServiceAccountService) AuthenticateAPIKey(ctx context.Context, key, onBehalfOf string) (*models.APIPrincipal, error) {
+	id, err := models.ParseAPIKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.storage.GetAPIKey(ctx, id)
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, models.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(models.HashAPIKey(key)), []byte(stored.Hash)) != 1 || !stored.Active(time.Now()) {
+		return nil, models.ErrInvalidAPIKey
+	}
+
+	if err := s.storage.TouchAPIKey(ctx, id); err != nil {
+		log.Printf("failed to record API key use: %v", err)
+	}
+
+	principal := &models.APIPrincipal{
+		Account: stored.Account,
+		KeyID:   stored.ID,
+		Scopes:  stored.Scopes,
+	}
+
+	if onBehalfOf != "" {
+		user, err := s.storage.GetUserByUsername(ctx, onBehalfOf)
+		if err != nil {
+			return nil, err
+		}
+		roles, err := s.storage.GetDelegatedRoles(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		principal.UserID = user.ID
+		principal.Roles = roles
+	}
+
+	return principal, nil
+}
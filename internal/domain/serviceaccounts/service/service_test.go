@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/serviceaccounts/service/mocks"
+)
+
+func TestCreateServiceAccount(t *testing.T) {
+	mockService := new(mocks.ServiceAccountServiceInterface)
+
+	testCases := []struct {
+		name        string
+		account     string
+		mockReturn  *models.ServiceAccount
+		mockErr     error
+		expectedErr string
+	}{
+		{
+			name:       "Creates the service account",
+			account:    "chat-bot",
+			mockReturn: &models.ServiceAccount{Name: "chat-bot", CreatedBy: "admin", Keys: []models.APIKey{}},
+		},
+		{
+			name:        "Invalid name",
+			account:     "Chat Bot",
+			mockErr:     models.ErrInvalidServiceAccountName,
+			expectedErr: "service account name",
+		},
+		{
+			name:        "Name already taken",
+			account:     "hr-system",
+			mockErr:     errors.New("domain.repository.CreateServiceAccount: service account already exists"),
+			expectedErr: "already exists",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("CreateServiceAccount", mock.Anything, 1, tc.account, "").
+				Return(tc.mockReturn, tc.mockErr)
+
+			account, err := mockService.CreateServiceAccount(context.Background(), 1, tc.account, "")
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Nil(t, account)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.mockReturn, account)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	mockService := new(mocks.ServiceAccountServiceInterface)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	key := &models.APIKey{
+		ID:        "0123456789abcdef",
+		Account:   "hr-system",
+		Scopes:    []models.Scope{models.ScopeCoinsGrant},
+		ExpiresAt: &expiresAt,
+	}
+
+	mockService.On("CreateAPIKey", mock.Anything, "hr-system", []string{"coins:grant"}, &expiresAt).
+		Return(key, "msk_0123456789abcdef_secret", nil)
+	mockService.On("CreateAPIKey", mock.Anything, "hr-system", []string{"coins:steal"}, (*time.Time)(nil)).
+		Return(nil, "", models.ErrUnknownScope)
+	mockService.On("CreateAPIKey", mock.Anything, "nobody", []string{"coins:grant"}, (*time.Time)(nil)).
+		Return(nil, "", errors.New("domain.repository.CreateAPIKey: service account not found"))
+
+	created, plain, err := mockService.CreateAPIKey(context.Background(), "hr-system", []string{"coins:grant"}, &expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, key, created)
+	assert.Equal(t, "msk_0123456789abcdef_secret", plain)
+
+	_, _, err = mockService.CreateAPIKey(context.Background(), "hr-system", []string{"coins:steal"}, nil)
+	assert.ErrorIs(t, err, models.ErrUnknownScope)
+
+	_, _, err = mockService.CreateAPIKey(context.Background(), "nobody", []string{"coins:grant"}, nil)
+	assert.ErrorContains(t, err, "service account not found")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	mockService := new(mocks.ServiceAccountServiceInterface)
+
+	testCases := []struct {
+		name        string
+		key         string
+		onBehalfOf  string
+		mockReturn  *models.APIPrincipal
+		mockErr     error
+		expectedErr string
+	}{
+		{
+			name: "Service account acting on its own",
+			key:  "msk_0123456789abcdef_secret",
+			mockReturn: &models.APIPrincipal{
+				Account: "hr-system",
+				KeyID:   "0123456789abcdef",
+				Scopes:  []models.Scope{models.ScopeCoinsGrant},
+			},
+		},
+		{
+			name:       "Service account acting on behalf of a user",
+			key:        "msk_fedcba9876543210_secret",
+			onBehalfOf: "alice",
+			mockReturn: &models.APIPrincipal{
+				Account: "chat-bot",
+				KeyID:   "fedcba9876543210",
+				Scopes:  []models.Scope{models.ScopeCoinsSend},
+				UserID:  7,
+				Roles:   []models.Role{models.RoleEmployee},
+			},
+		},
+		{
+			name:        "Revoked or expired key",
+			key:         "msk_0000000000000000_secret",
+			mockErr:     models.ErrInvalidAPIKey,
+			expectedErr: "invalid API key",
+		},
+		{
+			name:        "Unknown user",
+			key:         "msk_fedcba9876543210_secret",
+			onBehalfOf:  "nobody",
+			mockErr:     errors.New("domain.repository.GetUserByUsername: user not found"),
+			expectedErr: "user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("AuthenticateAPIKey", mock.Anything, tc.key, tc.onBehalfOf).
+				Return(tc.mockReturn, tc.mockErr)
+
+			principal, err := mockService.AuthenticateAPIKey(context.Background(), tc.key, tc.onBehalfOf)
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Nil(t, principal)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.mockReturn, principal)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	mockService := new(mocks.ServiceAccountServiceInterface)
+
+	mockService.On("RevokeAPIKey", mock.Anything, "hr-system", "0123456789abcdef").Return(nil)
+	mockService.On("RevokeAPIKey", mock.Anything, "hr-system", "missing").
+		Return(errors.New("domain.repository.RevokeAPIKey: API key not found"))
+
+	assert.NoError(t, mockService.RevokeAPIKey(context.Background(), "hr-system", "0123456789abcdef"))
+	assert.ErrorContains(t, mockService.RevokeAPIKey(context.Background(), "hr-system", "missing"), "not found")
+
+	mockService.AssertExpectations(t)
+}
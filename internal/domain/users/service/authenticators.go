@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Authenticator checks a username and password against one source of
+// accounts and returns the local user they sign in as. A wrong password or an
+// unknown user is models.ErrInvalidCredentials, so that the next
+// authenticator of the chain is tried.
+type Authenticator interface {
+	Authenticate(ctx context.Context, username, password string) (int, error)
+}
+
+// LocalAuthenticator checks the bcrypt hash stored with the user.
+type LocalAuthenticator struct {
+	userRepo *repository.Storage
+
+	// dummyHash is compared against when the user does not exist, so that
+	// unknown usernames take as long to reject as wrong passwords.
+	dummyHash []byte
+}
+
+func NewLocalAuthenticator(userRepo *repository.Storage) *LocalAuthenticator {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+	return &LocalAuthenticator{
+		userRepo:  userRepo,
+		dummyHash: dummyHash,
+	}
+}
+
+func (a *LocalAuthenticator) Authenticate(ctx context.Context, username, password string) (int, error) {
+	user, err := a.userRepo.GetUserByUsername(ctx, username)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return 0, err
+	}
+
+	hash := a.dummyHash
+	if user != nil {
+		hash = []byte(user.PasswordHash)
+	}
+	if compareErr := bcrypt.CompareHashAndPassword(hash, []byte(password)); compareErr != nil || user == nil {
+		return 0, models.ErrInvalidCredentials
+	}
+
+	return user.ID, nil
+}
+
+// Directory is an external directory of accounts, such as LDAP.
+type Directory interface {
+	Authenticate(ctx context.Context, username, password string) (*models.DirectoryUser, error)
+}
+
+// DirectoryAuthenticator checks passwords against a directory. The local user
+// named after the entry is created on the first sign-in if provision is set,
+// or linked if linkExisting is set; later sign-ins find it by the entry's DN
+// and sync its directory attributes.
+type DirectoryAuthenticator struct {
+	directory    Directory
+	userRepo     *repository.Storage
+	linkExisting bool
+	provision    bool
+}
+
+func NewDirectoryAuthenticator(directory Directory, userRepo *repository.Storage, linkExisting, provision bool) *DirectoryAuthenticator {
+	return &DirectoryAuthenticator{
+		directory:    directory,
+		userRepo:     userRepo,
+		linkExisting: linkExisting,
+		provision:    provision,
+	}
+}
+
+func (a *DirectoryAuthenticator) Authenticate(ctx context.Context, username, password string) (int, error) {
+	entry, err := a.directory.Authenticate(ctx, username, password)
+	if err != nil {
+		return 0, err
+	}
+
+	return a.userRepo.SyncDirectoryUser(ctx, entry, a.linkExisting, a.provision)
+}
@@ -4,6 +4,8 @@ package mocks
 
 import (
 	context "context"
+	models "merch-store-service/internal/domain/models"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,27 +15,729 @@ type UserServiceAuth struct {
 	mock.Mock
 }
 
-// PostApiAuth provides a mock function with given fields: ctx, username, password
-func (_m *UserServiceAuth) PostApiAuth(ctx context.Context, username string, password string) (string, error) {
-	ret := _m.Called(ctx, username, password)
+// ChangePassword provides a mock function with given fields: ctx, userID, currentPassword, newPassword
+func (_m *UserServiceAuth) ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) (*models.AuthTokens, error) {
+	ret := _m.Called(ctx, userID, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 *models.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (*models.AuthTokens, error)); ok {
+		return rf(ctx, userID, currentPassword, newPassword)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) *models.AuthTokens); ok {
+		r0 = rf(ctx, userID, currentPassword, newPassword)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, userID, currentPassword, newPassword)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClearLoginFailures provides a mock function with given fields: ctx, kind, key
+func (_m *UserServiceAuth) ClearLoginFailures(ctx context.Context, kind string, key string) error {
+	ret := _m.Called(ctx, kind, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearLoginFailures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, kind, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmTwoFactor provides a mock function with given fields: ctx, userID, code
+func (_m *UserServiceAuth) ConfirmTwoFactor(ctx context.Context, userID int, code string) ([]string, error) {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmTwoFactor")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]string, error)); ok {
+		return rf(ctx, userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []string); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInvite provides a mock function with given fields: ctx, staffID, expiresAt
+func (_m *UserServiceAuth) CreateInvite(ctx context.Context, staffID int, expiresAt *time.Time) (*models.Invite, error) {
+	ret := _m.Called(ctx, staffID, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvite")
+	}
+
+	var r0 *models.Invite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time) (*models.Invite, error)); ok {
+		return rf(ctx, staffID, expiresAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *time.Time) *models.Invite); ok {
+		r0 = rf(ctx, staffID, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *time.Time) error); ok {
+		r1 = rf(ctx, staffID, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePasswordReset provides a mock function with given fields: ctx, adminID, username
+func (_m *UserServiceAuth) CreatePasswordReset(ctx context.Context, adminID int, username string) (*models.PasswordReset, error) {
+	ret := _m.Called(ctx, adminID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordReset")
+	}
+
+	var r0 *models.PasswordReset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*models.PasswordReset, error)); ok {
+		return rf(ctx, adminID, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *models.PasswordReset); ok {
+		r0 = rf(ctx, adminID, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasswordReset)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, adminID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollTwoFactor provides a mock function with given fields: ctx, userID
+func (_m *UserServiceAuth) EnrollTwoFactor(ctx context.Context, userID int) (*models.TwoFactorEnrollment, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollTwoFactor")
+	}
+
+	var r0 *models.TwoFactorEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.TwoFactorEnrollment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.TwoFactorEnrollment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TwoFactorEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishSSO provides a mock function with given fields: ctx, state, code
+func (_m *UserServiceAuth) FinishSSO(ctx context.Context, state string, code string) (*models.AuthTokens, error) {
+	ret := _m.Called(ctx, state, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishSSO")
+	}
+
+	var r0 *models.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.AuthTokens, error)); ok {
+		return rf(ctx, state, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.AuthTokens); ok {
+		r0 = rf(ctx, state, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, state, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfile provides a mock function with given fields: ctx, username
+func (_m *UserServiceAuth) GetProfile(ctx context.Context, username string) (*models.UserProfile, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 *models.UserProfile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UserProfile, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserProfile); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserProfile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTwoFactorStatus provides a mock function with given fields: ctx, userID
+func (_m *UserServiceAuth) GetTwoFactorStatus(ctx context.Context, userID int) (*models.TwoFactorStatus, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTwoFactorStatus")
+	}
+
+	var r0 *models.TwoFactorStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.TwoFactorStatus, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.TwoFactorStatus); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TwoFactorStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantRole provides a mock function with given fields: ctx, adminID, username, role
+func (_m *UserServiceAuth) GrantRole(ctx context.Context, adminID int, username string, role string) (*models.UserRoles, error) {
+	ret := _m.Called(ctx, adminID, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantRole")
+	}
+
+	var r0 *models.UserRoles
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (*models.UserRoles, error)); ok {
+		return rf(ctx, adminID, username, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) *models.UserRoles); ok {
+		r0 = rf(ctx, adminID, username, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserRoles)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, adminID, username, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInvites provides a mock function with given fields: ctx
+func (_m *UserServiceAuth) ListInvites(ctx context.Context) ([]models.Invite, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInvites")
+	}
+
+	var r0 []models.Invite
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Invite, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Invite); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Invite)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLoginFailures provides a mock function with given fields: ctx
+func (_m *UserServiceAuth) ListLoginFailures(ctx context.Context) ([]models.LoginFailures, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLoginFailures")
+	}
+
+	var r0 []models.LoginFailures
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.LoginFailures, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.LoginFailures); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.LoginFailures)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoles provides a mock function with given fields: ctx, username
+func (_m *UserServiceAuth) ListRoles(ctx context.Context, username string) (*models.UserRoles, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoles")
+	}
+
+	var r0 *models.UserRoles
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UserRoles, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserRoles); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserRoles)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTwoFactorRoles provides a mock function with given fields: ctx
+func (_m *UserServiceAuth) ListTwoFactorRoles(ctx context.Context) ([]models.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTwoFactorRoles")
+	}
+
+	var r0 []models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, username, password, clientIP
+func (_m *UserServiceAuth) Login(ctx context.Context, username string, password string, clientIP string) (*models.AuthTokens, error) {
+	ret := _m.Called(ctx, username, password, clientIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *models.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.AuthTokens, error)); ok {
+		return rf(ctx, username, password, clientIP)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.AuthTokens); ok {
+		r0 = rf(ctx, username, password, clientIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, username, password, clientIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, sessionID
+func (_m *UserServiceAuth) Logout(ctx context.Context, sessionID string) error {
+	ret := _m.Called(ctx, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PostApiAuth provides a mock function with given fields: ctx, username, password, clientIP
+func (_m *UserServiceAuth) PostApiAuth(ctx context.Context, username string, password string, clientIP string) (*models.AuthTokens, error) {
+	ret := _m.Called(ctx, username, password, clientIP)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PostApiAuth")
 	}
 
+	var r0 *models.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.AuthTokens, error)); ok {
+		return rf(ctx, username, password, clientIP)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.AuthTokens); ok {
+		r0 = rf(ctx, username, password, clientIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, username, password, clientIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshToken
+func (_m *UserServiceAuth) Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 *models.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AuthTokens, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AuthTokens); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Register provides a mock function with given fields: ctx, username, password, inviteCode
+func (_m *UserServiceAuth) Register(ctx context.Context, username string, password string, inviteCode string) (*models.AuthTokens, error) {
+	ret := _m.Called(ctx, username, password, inviteCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 *models.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.AuthTokens, error)); ok {
+		return rf(ctx, username, password, inviteCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.AuthTokens); ok {
+		r0 = rf(ctx, username, password, inviteCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, username, password, inviteCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequireTwoFactor provides a mock function with given fields: ctx, adminID, role
+func (_m *UserServiceAuth) RequireTwoFactor(ctx context.Context, adminID int, role string) ([]models.Role, error) {
+	ret := _m.Called(ctx, adminID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequireTwoFactor")
+	}
+
+	var r0 []models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]models.Role, error)); ok {
+		return rf(ctx, adminID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []models.Role); ok {
+		r0 = rf(ctx, adminID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, adminID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: ctx, token, newPassword
+func (_m *UserServiceAuth) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	ret := _m.Called(ctx, token, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, token, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetTwoFactor provides a mock function with given fields: ctx, username
+func (_m *UserServiceAuth) ResetTwoFactor(ctx context.Context, username string) error {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetTwoFactor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRole provides a mock function with given fields: ctx, username, role
+func (_m *UserServiceAuth) RevokeRole(ctx context.Context, username string, role string) (*models.UserRoles, error) {
+	ret := _m.Called(ctx, username, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRole")
+	}
+
+	var r0 *models.UserRoles
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.UserRoles, error)); ok {
+		return rf(ctx, username, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.UserRoles); ok {
+		r0 = rf(ctx, username, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserRoles)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, username, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartSSO provides a mock function with given fields: ctx
+func (_m *UserServiceAuth) StartSSO(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartSSO")
+	}
+
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, username, password)
+	if rf, ok := ret.Get(0).(func(context.Context) (string, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, username, password)
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, username, password)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnrequireTwoFactor provides a mock function with given fields: ctx, role
+func (_m *UserServiceAuth) UnrequireTwoFactor(ctx context.Context, role string) ([]models.Role, error) {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnrequireTwoFactor")
+	}
+
+	var r0 []models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Role, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Role); ok {
+		r0 = rf(ctx, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyTwoFactor provides a mock function with given fields: ctx, challengeToken, code, clientIP
+func (_m *UserServiceAuth) VerifyTwoFactor(ctx context.Context, challengeToken string, code string, clientIP string) (*models.AuthTokens, error) {
+	ret := _m.Called(ctx, challengeToken, code, clientIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyTwoFactor")
+	}
+
+	var r0 *models.AuthTokens
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.AuthTokens, error)); ok {
+		return rf(ctx, challengeToken, code, clientIP)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.AuthTokens); ok {
+		r0 = rf(ctx, challengeToken, code, clientIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthTokens)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, challengeToken, code, clientIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2,49 +2,652 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"merch-store-service/internal/domain/access"
+	"merch-store-service/internal/domain/models"
 	"merch-store-service/internal/domain/repository"
 	"merch-store-service/internal/infra/jwtutils"
+	"merch-store-service/internal/infra/notifier"
+	"merch-store-service/internal/infra/oidc"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrInviteRequired = errors.New("an invite code is required to register")
+	ErrInvalidInvite  = errors.New("invalid invite")
+	ErrNoSession      = errors.New("token is not bound to a session")
+	ErrWrongPassword  = errors.New("current password is wrong")
+)
+
 //go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=UserServiceAuth
 type UserServiceAuth interface {
-	PostApiAuth(ctx context.Context, username string, password string) (string, error)
+	PostApiAuth(ctx context.Context, username, password, clientIP string) (*models.AuthTokens, error)
+	Register(ctx context.Context, username, password, inviteCode string) (*models.AuthTokens, error)
+	Login(ctx context.Context, username, password, clientIP string) (*models.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error)
+	Logout(ctx context.Context, sessionID string) error
+	CreateInvite(ctx context.Context, staffID int, expiresAt *time.Time) (*models.Invite, error)
+	ListInvites(ctx context.Context) ([]models.Invite, error)
+	ListRoles(ctx context.Context, username string) (*models.UserRoles, error)
+	GrantRole(ctx context.Context, adminID int, username, role string) (*models.UserRoles, error)
+	RevokeRole(ctx context.Context, username, role string) (*models.UserRoles, error)
+	ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) (*models.AuthTokens, error)
+	CreatePasswordReset(ctx context.Context, adminID int, username string) (*models.PasswordReset, error)
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	ListLoginFailures(ctx context.Context) ([]models.LoginFailures, error)
+	ClearLoginFailures(ctx context.Context, kind, key string) error
+	StartSSO(ctx context.Context) (string, error)
+	FinishSSO(ctx context.Context, state, code string) (*models.AuthTokens, error)
+	GetProfile(ctx context.Context, username string) (*models.UserProfile, error)
+	VerifyTwoFactor(ctx context.Context, challengeToken, code, clientIP string) (*models.AuthTokens, error)
+	GetTwoFactorStatus(ctx context.Context, userID int) (*models.TwoFactorStatus, error)
+	EnrollTwoFactor(ctx context.Context, userID int) (*models.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(ctx context.Context, userID int, code string) ([]string, error)
+	ResetTwoFactor(ctx context.Context, username string) error
+	ListTwoFactorRoles(ctx context.Context) ([]models.Role, error)
+	RequireTwoFactor(ctx context.Context, adminID int, role string) ([]models.Role, error)
+	UnrequireTwoFactor(ctx context.Context, role string) ([]models.Role, error)
 }
 
+// ssoLoginTTL is how long the user has to sign in at the identity provider.
+const ssoLoginTTL = 10 * time.Minute
+
 type UserService struct {
-	userRepo   *repository.Storage
-	jwtManager *jwtutils.JWTManager
+	userRepo       *repository.Storage
+	jwtManager     *jwtutils.JWTManager
+	denylist       *access.Denylist
+	policy         models.RegistrationPolicy
+	sessions       models.SessionPolicy
+	autoProvision  bool
+	sender         notifier.Notifier
+	resetTTL       time.Duration
+	throttle       models.LoginThrottlePolicy
+	sso            *oidc.Provider
+	ssoPolicy      models.SSOPolicy
+	passwordLogin  bool
+	authenticators []Authenticator
+	twoFactor      models.TwoFactorPolicy
 }
 
-func NewUserService(userRepo *repository.Storage, jwtManager *jwtutils.JWTManager) *UserService {
+// UserServiceConfig holds the dependencies and policies of the user service.
+// Authenticators defaults to local passwords only.
+type UserServiceConfig struct {
+	JWTManager     *jwtutils.JWTManager
+	Denylist       *access.Denylist
+	Registration   models.RegistrationPolicy
+	Sessions       models.SessionPolicy
+	AutoProvision  bool
+	Sender         notifier.Notifier
+	ResetTTL       time.Duration
+	Throttle       models.LoginThrottlePolicy
+	SSO            *oidc.Provider
+	SSOPolicy      models.SSOPolicy
+	PasswordLogin  bool
+	Authenticators []Authenticator
+	TwoFactor      models.TwoFactorPolicy
+}
+
+func NewUserService(userRepo *repository.Storage, cfg UserServiceConfig) *UserService {
+	authenticators := cfg.Authenticators
+	if len(authenticators) == 0 {
+		authenticators = []Authenticator{NewLocalAuthenticator(userRepo)}
+	}
+
 	return &UserService{
-		userRepo:   userRepo,
-		jwtManager: jwtManager,
+		userRepo:       userRepo,
+		jwtManager:     cfg.JWTManager,
+		denylist:       cfg.Denylist,
+		policy:         cfg.Registration,
+		sessions:       cfg.Sessions,
+		autoProvision:  cfg.AutoProvision,
+		sender:         cfg.Sender,
+		resetTTL:       cfg.ResetTTL,
+		throttle:       cfg.Throttle,
+		sso:            cfg.SSO,
+		ssoPolicy:      cfg.SSOPolicy,
+		passwordLogin:  cfg.PasswordLogin,
+		authenticators: authenticators,
+		twoFactor:      cfg.TwoFactor,
 	}
 }
 
-func (s *UserService) PostApiAuth(ctx context.Context, username string, password string) (string, error) {
-	user, err := s.userRepo.GetUserByUsername(ctx, username)
+// PostApiAuth is the legacy sign-in endpoint. It only logs in, unless
+// auto-provisioning is enabled: then an unknown username is registered on the
+// spot, subject to the registration policy.
+func (s *UserService) PostApiAuth(ctx context.Context, username, password, clientIP string) (*models.AuthTokens, error) {
+	if !s.passwordLogin {
+		return nil, models.ErrPasswordLoginDisabled
+	}
+	if s.autoProvision {
+		_, err := s.userRepo.GetUserByUsername(ctx, username)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return s.Register(ctx, username, password, "")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Login(ctx, username, password, clientIP)
+}
+
+// Register creates a new account and starts a session for it.
+func (s *UserService) Register(ctx context.Context, username, password, inviteCode string) (*models.AuthTokens, error) {
+	if !s.passwordLogin {
+		return nil, models.ErrPasswordLoginDisabled
+	}
+	if err := s.policy.Check(username, password); err != nil {
+		return nil, err
+	}
+	if s.policy.Mode == models.RegistrationInvite && inviteCode == "" {
+		return nil, ErrInviteRequired
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	var userID int
+	if s.policy.Mode == models.RegistrationInvite {
+		userID, err = s.userRepo.CreateInvitedUser(ctx, username, string(hashedPassword), inviteCode)
+	} else {
+		userID, err = s.userRepo.CreateUser(ctx, username, string(hashedPassword))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startSession(ctx, userID)
+}
+
+// Login checks the credentials with the authenticator chain and starts a
+// session. Unknown usernames and wrong passwords are reported the same way.
+// While recent failures of the username or the client address block further
+// attempts, the password is not checked at all. Every attempt is counted as
+// a failure before the password is checked and given back if it was right,
+// so concurrent guesses cannot get past the throttle. Users with two-factor
+// authentication get a *models.TwoFactorRequiredError instead of tokens.
+func (s *UserService) Login(ctx context.Context, username, password, clientIP string) (*models.AuthTokens, error) {
+	if !s.passwordLogin {
+		return nil, models.ErrPasswordLoginDisabled
+	}
+
+	keys := throttleKeys(username, clientIP)
+	attempt, err := s.reserveLoginAttempt(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.authenticate(ctx, username, password)
+	if errors.Is(err, models.ErrInvalidCredentials) {
+		s.logLockout(attempt)
+		return nil, repository.ErrUnauthorized
+	}
+	s.releaseLoginAttempt(ctx, attempt)
 	if err != nil {
-		hashedPassword, hashErr := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-		if hashErr != nil {
-			return "", hashErr
+		return nil, err
+	}
+
+	// With two-factor authentication the failures are kept until the second
+	// step succeeds too.
+	tokens, err := s.completeLogin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.clearLoginFailures(ctx, keys[0])
+	return tokens, nil
+}
+
+// authenticate asks the authenticators in turn and returns the user of the
+// first one that accepts the password. An authenticator that fails for
+// another reason, such as an unreachable directory, is logged and skipped, so
+// it does not lock out users of the others; its error is returned only if no
+// authenticator could check the password at all.
+func (s *UserService) authenticate(ctx context.Context, username, password string) (int, error) {
+	var failed error
+	rejected := false
+	for _, authenticator := range s.authenticators {
+		userID, err := authenticator.Authenticate(ctx, username, password)
+		switch {
+		case err == nil:
+			return userID, nil
+		case errors.Is(err, models.ErrInvalidCredentials):
+			rejected = true
+		default:
+			log.Printf("authenticator %T failed: %v", authenticator, err)
+			failed = err
 		}
+	}
+
+	if failed != nil && !rejected {
+		return 0, failed
+	}
+	return 0, models.ErrInvalidCredentials
+}
+
+// throttleKeys returns what failed attempts of the username from the client
+// address are counted against.
+func throttleKeys(username, clientIP string) []models.ThrottleKey {
+	keys := []models.ThrottleKey{{Kind: models.ThrottleUsername, Key: username}}
+	if clientIP != "" {
+		keys = append(keys, models.ThrottleKey{Kind: models.ThrottleIP, Key: clientIP})
+	}
+	return keys
+}
+
+// reserveLoginAttempt counts an attempt against the keys before the
+// credentials are checked, or returns a *models.LoginThrottledError.
+func (s *UserService) reserveLoginAttempt(ctx context.Context, keys []models.ThrottleKey) (*models.LoginAttempt, error) {
+	attempt, err := s.userRepo.ReserveLoginAttempt(ctx, keys, s.throttle, time.Now().UTC())
+	var throttled *models.LoginThrottledError
+	if errors.As(err, &throttled) {
+		return nil, throttled
+	}
+	return attempt, err
+}
+
+// releaseLoginAttempt gives back an attempt whose credentials were not
+// wrong. A failure to release is logged rather than reported.
+func (s *UserService) releaseLoginAttempt(ctx context.Context, attempt *models.LoginAttempt) {
+	if err := s.userRepo.ReleaseLoginAttempt(ctx, attempt); err != nil {
+		log.Printf("failed to release login attempt: %v", err)
+	}
+}
+
+// clearLoginFailures forgets the failures of the key once the user signed in.
+func (s *UserService) clearLoginFailures(ctx context.Context, key models.ThrottleKey) {
+	if err := s.userRepo.ClearLoginFailures(ctx, key); err != nil && !errors.Is(err, repository.ErrLoginFailuresNotFound) {
+		log.Printf("failed to clear login failures: %v", err)
+	}
+}
+
+// logLockout logs the keys that the failed attempt locked out.
+func (s *UserService) logLockout(attempt *models.LoginAttempt) {
+	for _, failures := range attempt.Failures {
+		if failures.LockedUntil != nil && failures.Failures == s.throttle.Limit(failures.Kind) {
+			log.Printf("login locked out for %s %q until %s", failures.Kind, failures.Key, failures.LockedUntil.Format(time.DateTime))
+		}
+	}
+}
+
+// StartSSO begins a sign-in with the identity provider and returns the
+// address to send the browser to. The state, nonce and PKCE code verifier are
+// kept until the provider redirects back to FinishSSO.
+func (s *UserService) StartSSO(ctx context.Context) (string, error) {
+	if s.sso == nil {
+		return "", models.ErrSSODisabled
+	}
+
+	state, stateHash, err := models.NewSSOState()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := oidc.NewNonce()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return "", err
+	}
+
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	err = s.userRepo.CreateSSOLogin(ctx, models.SSOLogin{
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().UTC().Add(ssoLoginTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.sso.AuthCodeURL(state, nonce, oidc.CodeChallenge(verifier)), nil
+}
+
+// FinishSSO redeems the code the identity provider redirected back with,
+// finds or provisions the local user of the verified identity and starts a
+// session for it, exactly as a password login would.
+func (s *UserService) FinishSSO(ctx context.Context, state, code string) (*models.AuthTokens, error) {
+	if s.sso == nil {
+		return nil, models.ErrSSODisabled
+	}
+
+	login, err := s.userRepo.TakeSSOLogin(ctx, models.HashSSOState(state))
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.sso.Exchange(ctx, code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := s.ssoPolicy.Username(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.userRepo.SignInExternalUser(ctx, identity, username, s.ssoPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, userID)
+}
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token
+// works once; presenting one again revokes the whole session.
+func (s *UserService) Refresh(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	grant, next, err := s.newGrant()
+	if err != nil {
+		return nil, err
+	}
+
+	userID, sessionID, err := s.userRepo.RotateRefreshToken(ctx, models.HashRefreshToken(refreshToken), grant)
+	if errors.Is(err, models.ErrRefreshTokenReused) {
+		s.syncDenylist(ctx)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	grant.SessionID = sessionID
+	return s.signTokens(ctx, userID, grant, next)
+}
+
+// Logout revokes the session, including access tokens already handed out.
+func (s *UserService) Logout(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return ErrNoSession
+	}
+
+	if err := s.userRepo.RevokeSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	s.syncDenylist(ctx)
+	return nil
+}
+
+// PurgeExpiredSessions deletes sessions and denylist entries that can no
+// longer be used. It is run periodically by the application.
+func (s *UserService) PurgeExpiredSessions(ctx context.Context) error {
+	return s.userRepo.PurgeExpiredSessions(ctx)
+}
+
+func (s *UserService) startSession(ctx context.Context, userID int) (*models.AuthTokens, error) {
+	grant, refreshToken, err := s.newGrant()
+	if err != nil {
+		return nil, err
+	}
+	grant.SessionID = uuid.NewString()
+
+	if err := s.userRepo.CreateSession(ctx, userID, grant); err != nil {
+		return nil, err
+	}
+
+	return s.signTokens(ctx, userID, grant, refreshToken)
+}
+
+// newGrant prepares the next token pair of a session and returns it together
+// with the plain refresh token, which is never stored.
+func (s *UserService) newGrant() (models.TokenGrant, string, error) {
+	refreshToken, refreshHash, err := models.NewRefreshToken()
+	if err != nil {
+		return models.TokenGrant{}, "", err
+	}
 
-		userID, createErr := s.userRepo.CreateUser(ctx, username, string(hashedPassword))
-		if createErr != nil {
-			return "", createErr
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	now := time.Now().UTC()
+	return models.TokenGrant{
+		AccessTokenID:    uuid.NewString(),
+		AccessExpiresAt:  now.Add(s.sessions.AccessTTL),
+		RefreshTokenHash: refreshHash,
+		RefreshExpiresAt: now.Add(s.sessions.RefreshTTL),
+	}, refreshToken, nil
+}
+
+// signTokens signs the access token of the grant with the user's current
+// roles, so a refresh picks up roles granted or revoked since the last one.
+// Roles that require a second factor are left out unless the session was
+// started with one.
+func (s *UserService) signTokens(ctx context.Context, userID int, grant models.TokenGrant, refreshToken string) (*models.AuthTokens, error) {
+	roles, err := s.userRepo.GetSessionRoles(ctx, grant.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+
+	accessToken, err := s.jwtManager.NewSessionToken(userID, grant.SessionID, grant.AccessTokenID, names, grant.AccessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    grant.AccessExpiresAt,
+	}, nil
+}
+
+// syncDenylist reloads the denylist right after a revocation, so this
+// instance rejects the revoked tokens without waiting for the next sync.
+func (s *UserService) syncDenylist(ctx context.Context) {
+	if err := s.denylist.Sync(ctx); err != nil {
+		log.Printf("failed to sync token denylist: %v", err)
+	}
+}
+
+func (s *UserService) CreateInvite(ctx context.Context, staffID int, expiresAt *time.Time) (*models.Invite, error) {
+	if expiresAt != nil {
+		// Timestamps are stored without a time zone, so keep them all in UTC.
+		utc := expiresAt.UTC()
+		if !utc.After(time.Now()) {
+			return nil, fmt.Errorf("%w: expiry must be in the future", ErrInvalidInvite)
 		}
+		expiresAt = &utc
+	}
 
-		return s.jwtManager.NewToken(userID, 24*time.Hour)
+	code, err := models.NewInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.CreateInvite(ctx, staffID, code, expiresAt)
+}
+
+func (s *UserService) ListInvites(ctx context.Context) ([]models.Invite, error) {
+	return s.userRepo.ListInvites(ctx)
+}
+
+// GetProfile returns the directory attributes synced at the user's last
+// sign-in through the directory.
+func (s *UserService) GetProfile(ctx context.Context, username string) (*models.UserProfile, error) {
+	return s.userRepo.GetUserProfile(ctx, username)
+}
+
+func (s *UserService) ListRoles(ctx context.Context, username string) (*models.UserRoles, error) {
+	return s.userRepo.ListUserRoles(ctx, username)
+}
+
+// GrantRole gives the role to the user. The user's access tokens are revoked,
+// so the role takes effect on their next refresh rather than on token expiry.
+func (s *UserService) GrantRole(ctx context.Context, adminID int, username, role string) (*models.UserRoles, error) {
+	parsed, err := models.ParseRole(role)
+	if err != nil {
+		return nil, err
 	}
 
-	if compareErr := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); compareErr != nil {
-		return "", repository.ErrUnauthorized
+	roles, err := s.userRepo.GrantRole(ctx, username, parsed, adminID)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.jwtManager.NewToken(user.ID, 24*time.Hour)
+	s.syncDenylist(ctx)
+	return roles, nil
+}
+
+// RevokeRole takes the role away from the user and, like GrantRole, revokes
+// the access tokens that still carry it.
+func (s *UserService) RevokeRole(ctx context.Context, username, role string) (*models.UserRoles, error) {
+	parsed, err := models.ParseRole(role)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.userRepo.RevokeRole(ctx, username, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syncDenylist(ctx)
+	return roles, nil
+}
+
+// ChangePassword sets a new password after checking the current one. All of
+// the user's sessions are revoked, including the one making the request, and
+// a new session is started for it. Users created by single sign-on or from a
+// directory have no password to change.
+func (s *UserService) ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) (*models.AuthTokens, error) {
+	if !s.passwordLogin {
+		return nil, models.ErrPasswordLoginDisabled
+	}
+
+	username, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// The marker stored for external users is not a bcrypt hash.
+	if _, costErr := bcrypt.Cost([]byte(user.PasswordHash)); costErr != nil {
+		return nil, repository.ErrPasswordManagedExternally
+	}
+	if compareErr := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)); compareErr != nil {
+		return nil, ErrWrongPassword
+	}
+
+	if err := s.policy.CheckPassword(newPassword); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.ChangePassword(ctx, userID, string(hashedPassword)); err != nil {
+		return nil, err
+	}
+
+	s.syncDenylist(ctx)
+	return s.startSession(ctx, userID)
+}
+
+// CreatePasswordReset issues a one-time reset token for the user and sends
+// it to them. The token is not returned to the administrator.
+func (s *UserService) CreatePasswordReset(ctx context.Context, adminID int, username string) (*models.PasswordReset, error) {
+	if !s.passwordLogin {
+		return nil, models.ErrPasswordLoginDisabled
+	}
+
+	token, hash, err := models.NewPasswordResetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	reset := &models.PasswordReset{
+		Username:  username,
+		Token:     token,
+		ExpiresAt: time.Now().UTC().Add(s.resetTTL),
+	}
+
+	if err := s.userRepo.CreatePasswordReset(ctx, username, hash, adminID, reset.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	err = s.sender.Notify(ctx, notifier.Message{
+		To:      reset.Username,
+		Subject: reset.Subject(),
+		Body:    reset.Body(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send password reset token: %w", err)
+	}
+
+	return reset, nil
+}
+
+// ResetPassword sets a new password with a reset token and revokes all of the
+// user's sessions.
+func (s *UserService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if !s.passwordLogin {
+		return models.ErrPasswordLoginDisabled
+	}
+	if err := s.policy.CheckPassword(newPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.ResetPassword(ctx, models.HashPasswordResetToken(token), string(hashedPassword)); err != nil {
+		return err
+	}
+
+	s.syncDenylist(ctx)
+	return nil
+}
+
+// ListLoginFailures returns the usernames and addresses with recent failed
+// logins, and until when each of them is blocked, if it is.
+func (s *UserService) ListLoginFailures(ctx context.Context) ([]models.LoginFailures, error) {
+	now := time.Now().UTC()
+	failures, err := s.userRepo.ListLoginFailures(ctx, now.Add(-s.throttle.Lockout))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range failures {
+		if at := s.throttle.RetryAt(&failures[i]); at.After(now) {
+			failures[i].BlockedUntil = &at
+		}
+	}
+
+	return failures, nil
+}
+
+// ClearLoginFailures lifts the backoff and lockout of a username or address.
+func (s *UserService) ClearLoginFailures(ctx context.Context, kind, key string) error {
+	parsed, err := models.ParseThrottleKind(kind)
+	if err != nil {
+		return err
+	}
+
+	return s.userRepo.ClearLoginFailures(ctx, models.ThrottleKey{Kind: parsed, Key: key})
+}
+
+// PurgeLoginFailures deletes failed logins that no longer count. It is run
+// periodically by the application.
+func (s *UserService) PurgeLoginFailures(ctx context.Context) error {
+	return s.userRepo.PurgeLoginFailures(ctx, time.Now().UTC().Add(-s.throttle.Lockout))
 }
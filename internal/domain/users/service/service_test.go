@@ -4,12 +4,22 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"merch-store-service/internal/domain/models"
 	"merch-store-service/internal/domain/users/service/mocks"
 )
 
+func authTokens(accessToken string) *models.AuthTokens {
+	return &models.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: accessToken + "-refresh",
+		ExpiresAt:    time.Date(2025, 3, 1, 18, 15, 0, 0, time.UTC),
+	}
+}
+
 func TestPostApiAuth(t *testing.T) {
 	mockService := new(mocks.UserServiceAuth)
 
@@ -18,7 +28,7 @@ func TestPostApiAuth(t *testing.T) {
 		name        string
 		username    string
 		password    string
-		mockReturn  string
+		mockReturn  *models.AuthTokens
 		mockError   error
 		expectError bool
 	}{
@@ -26,7 +36,7 @@ func TestPostApiAuth(t *testing.T) {
 			name:        "Successful authentication",
 			username:    "testuser",
 			password:    "password123",
-			mockReturn:  "valid-token",
+			mockReturn:  authTokens("valid-token"),
 			mockError:   nil,
 			expectError: false,
 		},
@@ -34,7 +44,7 @@ func TestPostApiAuth(t *testing.T) {
 			name:        "User not found - should create new user",
 			username:    "newuser",
 			password:    "newpassword",
-			mockReturn:  "new-user-token",
+			mockReturn:  authTokens("new-user-token"),
 			mockError:   nil,
 			expectError: false,
 		},
@@ -42,7 +52,7 @@ func TestPostApiAuth(t *testing.T) {
 			name:        "Incorrect password",
 			username:    "testuser",
 			password:    "wrongpassword",
-			mockReturn:  "",
+			mockReturn:  nil,
 			mockError:   errors.New("unauthorized"),
 			expectError: true,
 		},
@@ -50,7 +60,129 @@ func TestPostApiAuth(t *testing.T) {
 			name:        "Database error",
 			username:    "erroruser",
 			password:    "password",
-			mockReturn:  "",
+			mockReturn:  nil,
+			mockError:   errors.New("database error"),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("PostApiAuth", mock.Anything, tc.username, tc.password, "192.0.2.1").
+				Return(tc.mockReturn, tc.mockError)
+
+			token, err := mockService.PostApiAuth(context.Background(), tc.username, tc.password, "192.0.2.1")
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.mockReturn, token)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	testCases := []struct {
+		name        string
+		username    string
+		password    string
+		inviteCode  string
+		mockReturn  *models.AuthTokens
+		mockError   error
+		expectedErr string
+	}{
+		{
+			name:       "New account",
+			username:   "newuser",
+			password:   "correct-horse",
+			mockReturn: authTokens("new-user-token"),
+		},
+		{
+			name:        "Username taken",
+			username:    "testuser",
+			password:    "correct-horse",
+			mockError:   errors.New("domain.repository.CreateUser: username is already taken"),
+			expectedErr: "already taken",
+		},
+		{
+			name:        "Username breaks the policy",
+			username:    "a b",
+			password:    "correct-horse",
+			mockError:   errors.New("invalid username: use 3 to 32 latin letters, digits, '.', '-' or '_', starting with a letter or digit"),
+			expectedErr: "invalid username",
+		},
+		{
+			name:        "Invite already used",
+			username:    "inviteduser",
+			password:    "correct-horse",
+			inviteCode:  "5f2b9c0e1a7d",
+			mockError:   errors.New("domain.repository.CreateInvitedUser: invite code is invalid, used or expired"),
+			expectedErr: "invite code is invalid",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("Register", mock.Anything, tc.username, tc.password, tc.inviteCode).
+				Return(tc.mockReturn, tc.mockError)
+
+			token, err := mockService.Register(context.Background(), tc.username, tc.password, tc.inviteCode)
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.mockReturn, token)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	testCases := []struct {
+		name        string
+		username    string
+		password    string
+		mockReturn  *models.AuthTokens
+		mockError   error
+		expectError bool
+	}{
+		{
+			name:       "Correct credentials",
+			username:   "testuser",
+			password:   "password123",
+			mockReturn: authTokens("valid-token"),
+		},
+		{
+			name:        "Unknown user is not created",
+			username:    "typo-user",
+			password:    "password123",
+			mockError:   errors.New("user unauthorized"),
+			expectError: true,
+		},
+		{
+			name:        "Too many failed attempts",
+			username:    "victim",
+			password:    "guess",
+			mockError:   &models.LoginThrottledError{RetryAt: time.Now().Add(time.Minute)},
+			expectError: true,
+		},
+		{
+			name:        "Database error",
+			username:    "erroruser",
+			password:    "password",
 			mockError:   errors.New("database error"),
 			expectError: true,
 		},
@@ -58,10 +190,10 @@ func TestPostApiAuth(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			mockService.On("PostApiAuth", mock.Anything, tc.username, tc.password).
+			mockService.On("Login", mock.Anything, tc.username, tc.password, "192.0.2.1").
 				Return(tc.mockReturn, tc.mockError)
 
-			token, err := mockService.PostApiAuth(context.Background(), tc.username, tc.password)
+			token, err := mockService.Login(context.Background(), tc.username, tc.password, "192.0.2.1")
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -75,3 +207,361 @@ func TestPostApiAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestRefresh(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	testCases := []struct {
+		name         string
+		refreshToken string
+		mockReturn   *models.AuthTokens
+		mockError    error
+		expectedErr  string
+	}{
+		{
+			name:         "Rotates the refresh token",
+			refreshToken: "refresh-1",
+			mockReturn:   authTokens("access-2"),
+		},
+		{
+			name:         "Unknown refresh token",
+			refreshToken: "forged",
+			mockError:    errors.New("domain.repository.RotateRefreshToken: user unauthorized"),
+			expectedErr:  "unauthorized",
+		},
+		{
+			name:         "Reused refresh token revokes the session",
+			refreshToken: "refresh-0",
+			mockError:    errors.New("domain.repository.RotateRefreshToken: refresh token has already been used, the session is revoked"),
+			expectedErr:  "session is revoked",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("Refresh", mock.Anything, tc.refreshToken).
+				Return(tc.mockReturn, tc.mockError)
+
+			tokens, err := mockService.Refresh(context.Background(), tc.refreshToken)
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Nil(t, tokens)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.mockReturn, tokens)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	mockService.On("Logout", mock.Anything, "session-1").Return(nil)
+	mockService.On("Logout", mock.Anything, "").Return(errors.New("token is not bound to a session"))
+
+	assert.NoError(t, mockService.Logout(context.Background(), "session-1"))
+	assert.ErrorContains(t, mockService.Logout(context.Background(), ""), "not bound to a session")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGrantRole(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	testCases := []struct {
+		name        string
+		username    string
+		role        string
+		mockReturn  *models.UserRoles
+		mockError   error
+		expectedErr string
+	}{
+		{
+			name:     "Grants the role",
+			username: "alice",
+			role:     "auditor",
+			mockReturn: &models.UserRoles{
+				Username: "alice",
+				Roles:    []models.Role{models.RoleAuditor, models.RoleEmployee},
+			},
+		},
+		{
+			name:        "Unknown role",
+			username:    "alice",
+			role:        "root",
+			mockError:   models.ErrUnknownRole,
+			expectedErr: "unknown role",
+		},
+		{
+			name:        "Unknown user",
+			username:    "nobody",
+			role:        "auditor",
+			mockError:   errors.New("domain.repository.GrantRole: user not found"),
+			expectedErr: "user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("GrantRole", mock.Anything, 1, tc.username, tc.role).
+				Return(tc.mockReturn, tc.mockError)
+
+			roles, err := mockService.GrantRole(context.Background(), 1, tc.username, tc.role)
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Nil(t, roles)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.mockReturn, roles)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRevokeRole(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	remaining := &models.UserRoles{Username: "alice", Roles: []models.Role{models.RoleEmployee}}
+	mockService.On("RevokeRole", mock.Anything, "alice", "auditor").Return(remaining, nil)
+
+	roles, err := mockService.RevokeRole(context.Background(), "alice", "auditor")
+	assert.NoError(t, err)
+	assert.Equal(t, remaining, roles)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestChangePassword(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	testCases := []struct {
+		name            string
+		currentPassword string
+		newPassword     string
+		mockReturn      *models.AuthTokens
+		mockError       error
+		expectedErr     string
+	}{
+		{
+			name:            "Changes the password and starts a new session",
+			currentPassword: "correct-horse",
+			newPassword:     "battery-staple",
+			mockReturn:      authTokens("access-new"),
+		},
+		{
+			name:            "Wrong current password",
+			currentPassword: "guess",
+			newPassword:     "battery-staple",
+			mockError:       errors.New("current password is wrong"),
+			expectedErr:     "current password is wrong",
+		},
+		{
+			name:            "Weak new password",
+			currentPassword: "correct-horse",
+			newPassword:     "short",
+			mockError:       models.ErrWeakPassword,
+			expectedErr:     "too weak",
+		},
+		{
+			name:            "Single sign-on user has no password",
+			currentPassword: "anything",
+			newPassword:     "battery-staple",
+			mockError:       errors.New("password is managed by single sign-on or the directory"),
+			expectedErr:     "managed by single sign-on or the directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService.On("ChangePassword", mock.Anything, 1, tc.currentPassword, tc.newPassword).
+				Return(tc.mockReturn, tc.mockError)
+
+			tokens, err := mockService.ChangePassword(context.Background(), 1, tc.currentPassword, tc.newPassword)
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				assert.Nil(t, tokens)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.mockReturn, tokens)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	reset := &models.PasswordReset{
+		Username:  "alice",
+		Token:     "reset-token",
+		ExpiresAt: time.Date(2025, 3, 1, 19, 0, 0, 0, time.UTC),
+	}
+	mockService.On("CreatePasswordReset", mock.Anything, 1, "alice").Return(reset, nil)
+	mockService.On("CreatePasswordReset", mock.Anything, 1, "nobody").
+		Return(nil, errors.New("domain.repository.CreatePasswordReset: user not found"))
+	mockService.On("ResetPassword", mock.Anything, "reset-token", "battery-staple").Return(nil)
+	mockService.On("ResetPassword", mock.Anything, "reset-token", "battery-staple-2").
+		Return(errors.New("domain.repository.ResetPassword: password reset token is invalid, used or expired"))
+	mockService.On("ResetPassword", mock.Anything, "sso-reset-token", "battery-staple").
+		Return(errors.New("domain.repository.ResetPassword: password is managed by single sign-on or the directory"))
+
+	created, err := mockService.CreatePasswordReset(context.Background(), 1, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, reset, created)
+
+	_, err = mockService.CreatePasswordReset(context.Background(), 1, "nobody")
+	assert.ErrorContains(t, err, "user not found")
+
+	assert.NoError(t, mockService.ResetPassword(context.Background(), "reset-token", "battery-staple"))
+	assert.ErrorContains(t, mockService.ResetPassword(context.Background(), "reset-token", "battery-staple-2"), "used or expired")
+	assert.ErrorContains(t, mockService.ResetPassword(context.Background(), "sso-reset-token", "battery-staple"), "managed by single sign-on")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLoginFailures(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	blockedUntil := time.Date(2025, 3, 1, 18, 30, 0, 0, time.UTC)
+	failures := []models.LoginFailures{
+		{
+			Kind:          models.ThrottleUsername,
+			Key:           "alice",
+			Failures:      10,
+			LastFailureAt: time.Date(2025, 3, 1, 18, 15, 0, 0, time.UTC),
+			LockedUntil:   &blockedUntil,
+			BlockedUntil:  &blockedUntil,
+		},
+	}
+	mockService.On("ListLoginFailures", mock.Anything).Return(failures, nil)
+	mockService.On("ClearLoginFailures", mock.Anything, "username", "alice").Return(nil)
+	mockService.On("ClearLoginFailures", mock.Anything, "email", "alice").Return(models.ErrUnknownThrottleKind)
+	mockService.On("ClearLoginFailures", mock.Anything, "ip", "192.0.2.1").
+		Return(errors.New("domain.repository.ClearLoginFailures: no failed login attempts recorded"))
+
+	listed, err := mockService.ListLoginFailures(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, failures, listed)
+
+	assert.NoError(t, mockService.ClearLoginFailures(context.Background(), "username", "alice"))
+	assert.ErrorIs(t, mockService.ClearLoginFailures(context.Background(), "email", "alice"), models.ErrUnknownThrottleKind)
+	assert.ErrorContains(t, mockService.ClearLoginFailures(context.Background(), "ip", "192.0.2.1"), "no failed login attempts")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSSO(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	authURL := "http://idp.test/authorize?client_id=merch-store&code_challenge_method=S256&state=abc"
+	mockService.On("StartSSO", mock.Anything).Return(authURL, nil).Once()
+	mockService.On("StartSSO", mock.Anything).Return("", models.ErrSSODisabled).Once()
+	mockService.On("FinishSSO", mock.Anything, "abc", "code-1").Return(authTokens("sso-token"), nil)
+	mockService.On("FinishSSO", mock.Anything, "abc", "code-2").
+		Return(nil, errors.New("domain.repository.TakeSSOLogin: single sign-on state is invalid, used or expired"))
+	mockService.On("FinishSSO", mock.Anything, "def", "code-3").Return(nil, models.ErrSSOIdentityRejected)
+
+	started, err := mockService.StartSSO(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, authURL, started)
+
+	_, err = mockService.StartSSO(context.Background())
+	assert.ErrorIs(t, err, models.ErrSSODisabled)
+
+	tokens, err := mockService.FinishSSO(context.Background(), "abc", "code-1")
+	assert.NoError(t, err)
+	assert.Equal(t, authTokens("sso-token"), tokens)
+
+	_, err = mockService.FinishSSO(context.Background(), "abc", "code-2")
+	assert.ErrorContains(t, err, "used or expired")
+
+	_, err = mockService.FinishSSO(context.Background(), "def", "code-3")
+	assert.ErrorIs(t, err, models.ErrSSOIdentityRejected)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProfile(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	profile := &models.UserProfile{
+		Username:    "alice",
+		Source:      models.AuthenticatorLDAP,
+		DN:          "uid=alice,ou=people,dc=example,dc=org",
+		DisplayName: "Alice",
+		Department:  "Sales",
+		SyncedAt:    time.Date(2025, 3, 1, 18, 15, 0, 0, time.UTC),
+	}
+	mockService.On("GetProfile", mock.Anything, "alice").Return(profile, nil)
+	mockService.On("GetProfile", mock.Anything, "bob").
+		Return(nil, errors.New("domain.repository.GetUserProfile: user has no directory profile"))
+
+	got, err := mockService.GetProfile(context.Background(), "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, profile, got)
+
+	_, err = mockService.GetProfile(context.Background(), "bob")
+	assert.EqualError(t, err, "domain.repository.GetUserProfile: user has no directory profile")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestTwoFactor(t *testing.T) {
+	mockService := new(mocks.UserServiceAuth)
+
+	challenge := &models.TwoFactorRequiredError{ChallengeToken: "challenge", ExpiresAt: time.Date(2025, 3, 1, 18, 5, 0, 0, time.UTC)}
+	mockService.On("Login", mock.Anything, "alice", "password123", "").Return(nil, challenge)
+	mockService.On("VerifyTwoFactor", mock.Anything, "challenge", "123456", "192.0.2.1").Return(authTokens("2fa-token"), nil)
+	mockService.On("VerifyTwoFactor", mock.Anything, "challenge", "654321", "192.0.2.1").Return(nil, models.ErrInvalidTwoFactorCode)
+	mockService.On("VerifyTwoFactor", mock.Anything, "challenge", "000000", "192.0.2.1").
+		Return(nil, &models.LoginThrottledError{RetryAt: time.Date(2025, 3, 1, 18, 1, 0, 0, time.UTC)})
+	mockService.On("EnrollTwoFactor", mock.Anything, 1).Return(&models.TwoFactorEnrollment{
+		Secret:          "JBSWY3DPEHPK3PXP",
+		ProvisioningURI: "otpauth://totp/Merch%20Store:alice?issuer=Merch+Store&secret=JBSWY3DPEHPK3PXP",
+	}, nil)
+	mockService.On("ConfirmTwoFactor", mock.Anything, 1, "123456").Return([]string{"abcd-efgh", "ijkl-mnop"}, nil)
+	mockService.On("RequireTwoFactor", mock.Anything, 2, "hr-admin").Return([]models.Role{models.RoleHRAdmin}, nil)
+	mockService.On("RequireTwoFactor", mock.Anything, 2, "owner").Return(nil, models.ErrUnknownRole)
+
+	_, err := mockService.Login(context.Background(), "alice", "password123", "")
+	var required *models.TwoFactorRequiredError
+	assert.ErrorAs(t, err, &required)
+	assert.Equal(t, "challenge", required.ChallengeToken)
+
+	tokens, err := mockService.VerifyTwoFactor(context.Background(), "challenge", "123456", "192.0.2.1")
+	assert.NoError(t, err)
+	assert.Equal(t, authTokens("2fa-token"), tokens)
+
+	_, err = mockService.VerifyTwoFactor(context.Background(), "challenge", "654321", "192.0.2.1")
+	assert.ErrorIs(t, err, models.ErrInvalidTwoFactorCode)
+
+	_, err = mockService.VerifyTwoFactor(context.Background(), "challenge", "000000", "192.0.2.1")
+	assert.ErrorIs(t, err, models.ErrTooManyLoginAttempts, "Wrong codes should be throttled like wrong passwords")
+
+	enrollment, err := mockService.EnrollTwoFactor(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", enrollment.Secret)
+
+	codes, err := mockService.ConfirmTwoFactor(context.Background(), 1, "123456")
+	assert.NoError(t, err)
+	assert.Len(t, codes, 2)
+
+	roles, err := mockService.RequireTwoFactor(context.Background(), 2, "hr-admin")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Role{models.RoleHRAdmin}, roles)
+
+	_, err = mockService.RequireTwoFactor(context.Background(), 2, "owner")
+	assert.ErrorIs(t, err, models.ErrUnknownRole)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// completeLogin starts a session for a user whose password or identity
+// provider account was accepted. If the user has two-factor authentication,
+// a challenge is stored instead and returned as a
+// *models.TwoFactorRequiredError, to be redeemed with VerifyTwoFactor.
+func (s *UserService) completeLogin(ctx context.Context, userID int) (*models.AuthTokens, error) {
+	enabled, err := s.userRepo.HasTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return s.startSession(ctx, userID)
+	}
+
+	token, tokenHash, err := models.NewTwoFactorChallenge()
+	if err != nil {
+		return nil, err
+	}
+
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	expiresAt := time.Now().UTC().Add(s.twoFactor.ChallengeTTL)
+	if err := s.userRepo.CreateTwoFactorChallenge(ctx, userID, tokenHash, expiresAt); err != nil {
+		return nil, err
+	}
+
+	return nil, &models.TwoFactorRequiredError{ChallengeToken: token, ExpiresAt: expiresAt}
+}
+
+// VerifyTwoFactor finishes a login with a TOTP code or a recovery code and
+// starts a two-factor session. After MaxAttempts wrong codes the challenge is
+// dropped and the login has to start over. Wrong codes also count as failed
+// logins of the user and the client address, with the same backoff and
+// lockout as wrong passwords.
+func (s *UserService) VerifyTwoFactor(ctx context.Context, challengeToken, code, clientIP string) (*models.AuthTokens, error) {
+	tokenHash := models.HashTwoFactorChallenge(challengeToken)
+	login, err := s.userRepo.GetTwoFactorChallenge(ctx, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := throttleKeys(login.Username, clientIP)
+	attempt, err := s.reserveLoginAttempt(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var proof models.TwoFactorProof
+	if step, ok := models.VerifyTOTP(login.Secret, code, time.Now()); ok {
+		proof.Step = step
+	} else if models.IsRecoveryCode(code) {
+		proof.RecoveryCodeHash = models.HashRecoveryCode(code)
+	} else {
+		s.failTwoFactor(ctx, tokenHash, attempt)
+		return nil, models.ErrInvalidTwoFactorCode
+	}
+
+	grant, refreshToken, err := s.newGrant()
+	if err != nil {
+		s.releaseLoginAttempt(ctx, attempt)
+		return nil, err
+	}
+	grant.SessionID = uuid.NewString()
+
+	userID, err := s.userRepo.CompleteTwoFactorLogin(ctx, tokenHash, proof, grant)
+	if errors.Is(err, models.ErrInvalidTwoFactorCode) {
+		s.failTwoFactor(ctx, tokenHash, attempt)
+		return nil, err
+	}
+	s.releaseLoginAttempt(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.clearLoginFailures(ctx, keys[0])
+	return s.signTokens(ctx, userID, grant, refreshToken)
+}
+
+// failTwoFactor counts a wrong code against the challenge. The attempt stays
+// counted as a failed login.
+func (s *UserService) failTwoFactor(ctx context.Context, tokenHash string, attempt *models.LoginAttempt) {
+	s.logLockout(attempt)
+	if err := s.userRepo.FailTwoFactorChallenge(ctx, tokenHash, s.twoFactor.MaxAttempts); err != nil {
+		log.Printf("failed to record two-factor attempt: %v", err)
+	}
+}
+
+func (s *UserService) GetTwoFactorStatus(ctx context.Context, userID int) (*models.TwoFactorStatus, error) {
+	return s.userRepo.GetTwoFactorStatus(ctx, userID)
+}
+
+// EnrollTwoFactor generates a new TOTP secret for the user. It takes effect
+// only once confirmed with a code; enrolling again before that replaces the
+// secret.
+func (s *UserService) EnrollTwoFactor(ctx context.Context, userID int) (*models.TwoFactorEnrollment, error) {
+	username, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := models.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.StartTwoFactorEnrollment(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: models.TOTPProvisioningURI(s.twoFactor.Issuer, username, secret),
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication once the user proves
+// their authenticator app produces the right codes, and returns the recovery
+// codes. They are shown only this once.
+func (s *UserService) ConfirmTwoFactor(ctx context.Context, userID int, code string) ([]string, error) {
+	secret, confirmed, err := s.userRepo.GetTwoFactorSecret(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if confirmed {
+		return nil, repository.ErrTwoFactorEnabled
+	}
+
+	step, ok := models.VerifyTOTP(secret, code, time.Now())
+	if !ok {
+		return nil, models.ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := models.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.ConfirmTwoFactor(ctx, userID, secret, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// ResetTwoFactor removes the user's enrollment, for example after they lost
+// their phone. Their existing sessions are kept.
+func (s *UserService) ResetTwoFactor(ctx context.Context, username string) error {
+	return s.userRepo.ResetTwoFactor(ctx, username)
+}
+
+func (s *UserService) ListTwoFactorRoles(ctx context.Context) ([]models.Role, error) {
+	return s.userRepo.ListTwoFactorRoles(ctx)
+}
+
+// RequireTwoFactor makes the role available only to sessions started with a
+// second factor. Access tokens that carry the role without one are revoked,
+// so it is dropped on their next refresh.
+func (s *UserService) RequireTwoFactor(ctx context.Context, adminID int, role string) ([]models.Role, error) {
+	parsed, err := models.ParseRole(role)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.userRepo.RequireTwoFactor(ctx, parsed, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syncDenylist(ctx)
+	return roles, nil
+}
+
+// UnrequireTwoFactor lets sessions without a second factor use the role
+// again, starting with their next refresh.
+func (s *UserService) UnrequireTwoFactor(ctx context.Context, role string) ([]models.Role, error) {
+	parsed, err := models.ParseRole(role)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userRepo.UnrequireTwoFactor(ctx, parsed)
+}
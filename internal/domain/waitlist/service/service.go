@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"merch-store-service/internal/domain/models"
+)
+
+type WaitlistServiceInterface interface {
+	Join(ctx context.Context, userID int, item string) (*models.WaitlistEntry, error)
+	Leave(ctx context.Context, userID int, item string) error
+	List(ctx context.Context, userID int) ([]models.WaitlistEntry, error)
+}
+
+// WaitlistStorage is the part of the repository the waitlist service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=WaitlistStorage
+type WaitlistStorage interface {
+	JoinWaitlist(ctx context.Context, userID int, item string) (*models.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, userID int, item string) error
+	ListUserWaitlists(ctx context.Context, userID int) ([]models.WaitlistEntry, error)
+}
+
+type WaitlistService struct {
+	storage WaitlistStorage
+}
+
+func NewWaitlistService(storage WaitlistStorage) *WaitlistService {
+	return &WaitlistService{
+		storage: storage,
+	}
+}
+
+// Join puts the user on the waitlist of a sold-out item.
+func (s *WaitlistService) Join(ctx context.Context, userID int, item string) (*models.WaitlistEntry, error) {
+	return s.storage.JoinWaitlist(ctx, userID, item)
+}
+
+func (s *WaitlistService) Leave(ctx context.Context, userID int, item string) error {
+	return s.storage.LeaveWaitlist(ctx, userID, item)
+}
+
+func (s *WaitlistService) List(ctx context.Context, userID int) ([]models.WaitlistEntry, error) {
+	return s.storage.ListUserWaitlists(ctx, userID)
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/internal/domain/waitlist/service/mocks"
+)
+
+func TestJoin(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewWaitlistStorage(t)
+	service := NewWaitlistService(storage)
+
+	storage.On("JoinWaitlist", ctx, 1, "hoody").Return(&models.WaitlistEntry{Product: "hoody", Position: 3}, nil).Once()
+
+	entry, err := service.Join(ctx, 1, "hoody")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, entry.Position)
+
+	storage.On("JoinWaitlist", ctx, 1, "pen").Return(nil, repository.ErrItemInStock).Once()
+
+	_, err = service.Join(ctx, 1, "pen")
+	assert.ErrorIs(t, err, repository.ErrItemInStock)
+
+	storage.On("JoinWaitlist", ctx, 1, "unknown").Return(nil, repository.ErrItemNotFound).Once()
+
+	_, err = service.Join(ctx, 1, "unknown")
+	assert.ErrorIs(t, err, repository.ErrItemNotFound)
+}
+
+func TestLeave(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewWaitlistStorage(t)
+	service := NewWaitlistService(storage)
+
+	storage.On("LeaveWaitlist", ctx, 1, "hoody").Return(nil).Once()
+	storage.On("LeaveWaitlist", ctx, 1, "cup").Return(repository.ErrNotOnWaitlist).Once()
+
+	assert.NoError(t, service.Leave(ctx, 1, "hoody"))
+	assert.ErrorIs(t, service.Leave(ctx, 1, "cup"), repository.ErrNotOnWaitlist)
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewWaitlistStorage(t)
+	service := NewWaitlistService(storage)
+
+	storage.On("ListUserWaitlists", ctx, 1).Return([]models.WaitlistEntry{
+		{Product: "hoody", Position: 1},
+		{Product: "cup", Position: 4},
+	}, nil).Once()
+
+	entries, err := service.List(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
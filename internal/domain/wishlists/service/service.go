@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"merch-store-service/internal/domain/models"
+)
+
+type WishlistServiceInterface interface {
+	GetWishlist(ctx context.Context, userID int) (*models.Wishlist, error)
+	GetSharedWishlist(ctx context.Context, username string) (*models.Wishlist, error)
+	AddItem(ctx context.Context, userID int, item string, note *string) (*models.Wishlist, error)
+	UpdateItem(ctx context.Context, userID int, item string, note *string) (*models.Wishlist, error)
+	RemoveItem(ctx context.Context, userID int, item string) error
+	SetPublic(ctx context.Context, userID int, public bool) (*models.Wishlist, error)
+}
+
+// WishlistStorage is the part of the repository the wishlist service uses.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.48.0 --name=WishlistStorage
+type WishlistStorage interface {
+	GetWishlist(ctx context.Context, userID int) (*models.Wishlist, error)
+	GetPublicWishlist(ctx context.Context, username string) (*models.Wishlist, error)
+	AddWishlistItem(ctx context.Context, userID int, item string, note *string) error
+	UpdateWishlistItem(ctx context.Context, userID int, item string, note *string) error
+	RemoveWishlistItem(ctx context.Context, userID int, item string) error
+	SetWishlistPublic(ctx context.Context, userID int, public bool) error
+}
+
+type WishlistService struct {
+	storage WishlistStorage
+}
+
+func NewWishlistService(storage WishlistStorage) *WishlistService {
+	return &WishlistService{
+		storage: storage,
+	}
+}
+
+func (s *WishlistService) GetWishlist(ctx context.Context, userID int) (*models.Wishlist, error) {
+	return s.storage.GetWishlist(ctx, userID)
+}
+
+// GetSharedWishlist returns the wishlist of another user if it is public.
+func (s *WishlistService) GetSharedWishlist(ctx context.Context, username string) (*models.Wishlist, error) {
+	return s.storage.GetPublicWishlist(ctx, username)
+}
+
+func (s *WishlistService) AddItem(ctx context.Context, userID int, item string, note *string) (*models.Wishlist, error) {
+	if err := models.ValidateWishlistNote(note); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.AddWishlistItem(ctx, userID, item, note); err != nil {
+		return nil, err
+	}
+
+	return s.storage.GetWishlist(ctx, userID)
+}
+
+func (s *WishlistService) UpdateItem(ctx context.Context, userID int, item string, note *string) (*models.Wishlist, error) {
+	if err := models.ValidateWishlistNote(note); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.UpdateWishlistItem(ctx, userID, item, note); err != nil {
+		return nil, err
+	}
+
+	return s.storage.GetWishlist(ctx, userID)
+}
+
+func (s *WishlistService) RemoveItem(ctx context.Context, userID int, item string) error {
+	return s.storage.RemoveWishlistItem(ctx, userID, item)
+}
+
+// SetPublic shares the wishlist with colleagues or makes it private again.
+func (s *WishlistService) SetPublic(ctx context.Context, userID int, public bool) (*models.Wishlist, error) {
+	if err := s.storage.SetWishlistPublic(ctx, userID, public); err != nil {
+		return nil, err
+	}
+
+	return s.storage.GetWishlist(ctx, userID)
+}
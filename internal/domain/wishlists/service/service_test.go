@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"merch-store-service/internal/domain/models"
+	"merch-store-service/internal/domain/repository"
+	"merch-store-service/internal/domain/wishlists/service/mocks"
+)
+
+func TestAddItem(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewWishlistStorage(t)
+	service := NewWishlistService(storage)
+
+	note := "size L"
+	storage.On("AddWishlistItem", ctx, 1, "hoody", &note).Return(nil).Once()
+	storage.On("GetWishlist", ctx, 1).Return(&models.Wishlist{
+		Items: []models.WishlistItem{{Product: "hoody", Note: &note, Price: 300, Available: true}},
+	}, nil).Once()
+
+	wishlist, err := service.AddItem(ctx, 1, "hoody", &note)
+	assert.NoError(t, err)
+	assert.Len(t, wishlist.Items, 1)
+
+	long := strings.Repeat("x", models.MaxWishlistNoteLength+1)
+	_, err = service.AddItem(ctx, 1, "cup", &long)
+	assert.ErrorIs(t, err, models.ErrWishlistNoteTooLong, "A long note should be rejected before storing")
+
+	storage.On("AddWishlistItem", ctx, 1, "hoody", (*string)(nil)).Return(repository.ErrWishlistItemExists).Once()
+
+	_, err = service.AddItem(ctx, 1, "hoody", nil)
+	assert.ErrorIs(t, err, repository.ErrWishlistItemExists)
+
+	storage.On("AddWishlistItem", ctx, 1, "unknown", (*string)(nil)).Return(repository.ErrItemNotFound).Once()
+
+	_, err = service.AddItem(ctx, 1, "unknown", nil)
+	assert.ErrorIs(t, err, repository.ErrItemNotFound)
+}
+
+func TestUpdateItem(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewWishlistStorage(t)
+	service := NewWishlistService(storage)
+
+	long := strings.Repeat("x", models.MaxWishlistNoteLength+1)
+	_, err := service.UpdateItem(ctx, 1, "hoody", &long)
+	assert.ErrorIs(t, err, models.ErrWishlistNoteTooLong)
+
+	storage.On("UpdateWishlistItem", ctx, 1, "cup", (*string)(nil)).Return(repository.ErrWishlistItemNotFound).Once()
+
+	_, err = service.UpdateItem(ctx, 1, "cup", nil)
+	assert.ErrorIs(t, err, repository.ErrWishlistItemNotFound)
+
+	storage.On("RemoveWishlistItem", ctx, 1, "cup").Return(repository.ErrWishlistItemNotFound).Once()
+
+	assert.ErrorIs(t, service.RemoveItem(ctx, 1, "cup"), repository.ErrWishlistItemNotFound)
+}
+
+func TestSharedWishlist(t *testing.T) {
+	ctx := context.Background()
+	storage := mocks.NewWishlistStorage(t)
+	service := NewWishlistService(storage)
+
+	storage.On("SetWishlistPublic", ctx, 1, true).Return(nil).Once()
+	storage.On("GetWishlist", ctx, 1).Return(&models.Wishlist{Public: true}, nil).Once()
+
+	wishlist, err := service.SetPublic(ctx, 1, true)
+	assert.NoError(t, err)
+	assert.True(t, wishlist.Public)
+
+	storage.On("GetPublicWishlist", ctx, "alice").Return(&models.Wishlist{Public: true}, nil).Once()
+	storage.On("GetPublicWishlist", ctx, "bob").Return(nil, repository.ErrWishlistPrivate).Once()
+
+	_, err = service.GetSharedWishlist(ctx, "alice")
+	assert.NoError(t, err)
+
+	_, err = service.GetSharedWishlist(ctx, "bob")
+	assert.ErrorIs(t, err, repository.ErrWishlistPrivate)
+}
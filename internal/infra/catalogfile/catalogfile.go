@@ -0,0 +1,235 @@
+// Package catalogfile reads and writes the product catalog as YAML or CSV.
+package catalogfile
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"merch-store-service/internal/domain/models"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	YAML Format = "yaml"
+	CSV  Format = "csv"
+)
+
+var ErrUnknownFormat = errors.New("unknown catalog format")
+
+var csvHeader = []string{"name", "price"}
+
+// ParseFormat returns the format with the given name. An empty name is
+// inferred from the extension of path, or is YAML when there is no path.
+func ParseFormat(name, path string) (Format, error) {
+	if name == "" && path == "" {
+		return YAML, nil
+	}
+	if name == "" {
+		name = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	switch name {
+	case "yaml", "yml":
+		return YAML, nil
+	case "csv":
+		return CSV, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+	}
+}
+
+// yamlCatalog is the layout of a YAML catalog file.
+type yamlCatalog struct {
+	Products []yamlProduct `yaml:"products"`
+}
+
+type yamlProduct struct {
+	Name  string `yaml:"name"`
+	Price int    `yaml:"price"`
+}
+
+// Write writes the entries in the given format.
+func Write(w io.Writer, format Format, entries []models.CatalogEntry) error {
+	switch format {
+	case YAML:
+		catalog := yamlCatalog{Products: make([]yamlProduct, 0, len(entries))}
+		for _, entry := range entries {
+			catalog.Products = append(catalog.Products, yamlProduct{Name: entry.Name, Price: entry.Price})
+		}
+
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(catalog); err != nil {
+			return err
+		}
+		return enc.Close()
+	case CSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := cw.Write([]string{entry.Name, strconv.Itoa(entry.Price)}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+// Read parses a catalog file and validates its entries. Problems with
+// individual rows are all reported together as models.CatalogErrors.
+func Read(r io.Reader, format Format) ([]models.CatalogEntry, error) {
+	var (
+		entries []models.CatalogEntry
+		errs    models.CatalogErrors
+		err     error
+	)
+	switch format {
+	case YAML:
+		entries, errs, err = readYAML(r)
+	case CSV:
+		entries, errs, err = readCSV(r)
+	default:
+		err = fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	errs = append(errs, models.ValidateCatalog(entries)...)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return entries, nil
+}
+
+func readYAML(r io.Reader) ([]models.CatalogEntry, models.CatalogErrors, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, models.CatalogErrors{{Line: root.Line, Message: "expected a mapping with a products list"}}, nil
+	}
+
+	var (
+		products *yaml.Node
+		errs     models.CatalogErrors
+	)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value != "products" {
+			errs = append(errs, models.CatalogRowError{Line: key.Line, Message: fmt.Sprintf("unknown key %q", key.Value)})
+			continue
+		}
+		products = value
+	}
+	if products == nil {
+		return nil, append(errs, models.CatalogRowError{Line: root.Line, Message: "products list is missing"}), nil
+	}
+	if products.Kind != yaml.SequenceNode {
+		return nil, append(errs, models.CatalogRowError{Line: products.Line, Message: "products must be a list"}), nil
+	}
+
+	entries := make([]models.CatalogEntry, 0, len(products.Content))
+	for _, item := range products.Content {
+		if item.Kind != yaml.MappingNode {
+			errs = append(errs, models.CatalogRowError{Line: item.Line, Message: "product must be a mapping with name and price"})
+			continue
+		}
+
+		entry := models.CatalogEntry{Line: item.Line, Price: -1}
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			key, value := item.Content[i], item.Content[i+1]
+			switch key.Value {
+			case "name":
+				entry.Name = value.Value
+			case "price":
+				price, err := strconv.Atoi(value.Value)
+				if err != nil || value.Kind != yaml.ScalarNode {
+					errs = append(errs, models.CatalogRowError{Line: value.Line, Message: fmt.Sprintf("price %q is not an integer", value.Value)})
+					entry.Price = 0
+					continue
+				}
+				entry.Price = price
+			default:
+				errs = append(errs, models.CatalogRowError{Line: key.Line, Message: fmt.Sprintf("unknown field %q", key.Value)})
+			}
+		}
+
+		if entry.Price == -1 {
+			errs = append(errs, models.CatalogRowError{Line: item.Line, Message: "price is required"})
+			entry.Price = 0
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, errs, nil
+}
+
+func readCSV(r io.Reader) ([]models.CatalogEntry, models.CatalogErrors, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i, column := range csvHeader {
+		if strings.TrimSpace(header[i]) != column {
+			return nil, models.CatalogErrors{{Line: 1, Message: fmt.Sprintf("expected header %q", strings.Join(csvHeader, ","))}}, nil
+		}
+	}
+
+	var (
+		entries []models.CatalogEntry
+		errs    models.CatalogErrors
+	)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				errs = append(errs, models.CatalogRowError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+				if errors.Is(parseErr.Err, csv.ErrFieldCount) {
+					continue
+				}
+			}
+			return entries, errs, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		entry := models.CatalogEntry{Name: record[0], Line: line}
+		price, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			errs = append(errs, models.CatalogRowError{Line: line, Message: fmt.Sprintf("price %q is not an integer", record[1])})
+		}
+		entry.Price = price
+		entries = append(entries, entry)
+	}
+
+	return entries, errs, nil
+}
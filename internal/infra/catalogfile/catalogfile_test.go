@@ -0,0 +1,107 @@
+package catalogfile
+
+import (
+	"bytes"
+	"errors"
+	"merch-store-service/internal/domain/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	entries := []models.CatalogEntry{
+		{Name: "cup", Price: 20},
+		{Name: "t-shirt", Price: 80},
+	}
+
+	for _, format := range []Format{YAML, CSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, Write(&buf, format, entries))
+
+			got, err := Read(&buf, format)
+			assert.NoError(t, err)
+			assert.Len(t, got, len(entries))
+			for i := range got {
+				assert.Equal(t, entries[i].Name, got[i].Name)
+				assert.Equal(t, entries[i].Price, got[i].Price)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   []string
+	}{
+		{
+			name:   "yaml",
+			format: YAML,
+			input: `products:
+  - name: cup
+    price: 20
+  - name: pen
+    price: ten
+  - name: cup
+    price: 5
+  - name: book
+    colour: red
+    price: 50
+  - name: hoody
+`,
+			want: []string{
+				`line 5: price "ten" is not an integer`,
+				`line 9: unknown field "colour"`,
+				`line 11: price is required`,
+				`line 6: duplicate product "cup", first listed on line 2`,
+			},
+		},
+		{
+			name:   "csv",
+			format: CSV,
+			input:  "name,price\ncup,20\npen,ten\nbook\ncup,-5\n",
+			want: []string{
+				`line 3: price "ten" is not an integer`,
+				`line 4: wrong number of fields`,
+				`line 5: duplicate product "cup", first listed on line 2`,
+				`line 5: price cannot be negative`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.input), tt.format)
+
+			var errs models.CatalogErrors
+			assert.True(t, errors.As(err, &errs))
+			got := make([]string, 0, len(errs))
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("", "catalog.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, YAML, format)
+
+	format, err = ParseFormat("csv", "")
+	assert.NoError(t, err)
+	assert.Equal(t, CSV, format)
+
+	format, err = ParseFormat("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, YAML, format)
+
+	_, err = ParseFormat("", "catalog.json")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -14,12 +14,84 @@ type Config struct {
 	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 	Port        int           `yaml:"PORT" env-default:"8080"`
-	JWTSecret   string        `yaml:"jwtsecret" env-default:"secret-code"`
 	DBUser      string        `yaml:"dbuser" env-default:"postgres"`
 	DBPassword  string        `yaml:"dbpassword" env-default:"postgres"`
 	DBHost      string        `yaml:"dbhost" env-default:"db"`
 	DBPort      string        `yaml:"dbport" env-default:"5432"`
 	DBName      string        `yaml:"dbname" env-default:"avito_db"`
+
+	MaxOrderQuantity int           `yaml:"max_order_quantity" env-default:"10"`
+	ShopStaff        []string      `yaml:"shop_staff"`
+	HRAdmins         []string      `yaml:"hr_admins"`
+	ReturnWindow     time.Duration `yaml:"return_window" env-default:"0s"`
+
+	Notifier             string        `yaml:"notifier" env-default:"log"`
+	NotifierFile         string        `yaml:"notifier_file" env-default:"notifications.log"`
+	WaitlistReserveCount int           `yaml:"waitlist_reserve_count" env-default:"0"`
+	WaitlistReserveTTL   time.Duration `yaml:"waitlist_reserve_ttl" env-default:"24h"`
+
+	MarketplaceFeePercent int           `yaml:"marketplace_fee_percent" env-default:"5"`
+	AuctionCloseInterval  time.Duration `yaml:"auction_close_interval" env-default:"30s"`
+	RaffleDrawInterval    time.Duration `yaml:"raffle_draw_interval" env-default:"30s"`
+
+	AuthAutoProvision     bool          `yaml:"auth_auto_provision" env-default:"false"`
+	RegistrationMode      string        `yaml:"registration_mode" env-default:"open"`
+	RegistrationAllowlist []string      `yaml:"registration_allowlist"`
+	PasswordMinLength     int           `yaml:"password_min_length" env-default:"8"`
+	PasswordResetTTL      time.Duration `yaml:"password_reset_ttl" env-default:"1h"`
+
+	LoginFreeAttempts     int           `yaml:"login_free_attempts" env-default:"3"`
+	LoginBackoffBase      time.Duration `yaml:"login_backoff_base" env-default:"1s"`
+	LoginBackoffMax       time.Duration `yaml:"login_backoff_max" env-default:"5m"`
+	LoginMaxFailures      int           `yaml:"login_max_failures" env-default:"10"`
+	LoginMaxFailuresPerIP int           `yaml:"login_max_failures_per_ip" env-default:"100"`
+	LoginLockout          time.Duration `yaml:"login_lockout" env-default:"15m"`
+	TrustForwardedFor     bool          `yaml:"trust_forwarded_for" env-default:"false"`
+	TrustedProxies        []string      `yaml:"trusted_proxies"`
+
+	PasswordLogin     bool     `yaml:"password_login" env-default:"true"`
+	OIDCIssuer        string   `yaml:"oidc_issuer"`
+	OIDCClientID      string   `yaml:"oidc_client_id"`
+	OIDCClientSecret  string   `yaml:"oidc_client_secret"`
+	OIDCRedirectURL   string   `yaml:"oidc_redirect_url"`
+	OIDCScopes        []string `yaml:"oidc_scopes"`
+	OIDCUsernameClaim string   `yaml:"oidc_username_claim" env-default:"email"`
+	OIDCEmailDomain   string   `yaml:"oidc_email_domain"`
+	OIDCLinkExisting  bool     `yaml:"oidc_link_existing" env-default:"true"`
+	OIDCProvision     bool     `yaml:"oidc_provision" env-default:"true"`
+
+	Authenticators           []string      `yaml:"authenticators"`
+	LDAPURL                  string        `yaml:"ldap_url"`
+	LDAPStartTLS             bool          `yaml:"ldap_start_tls" env-default:"false"`
+	LDAPCAFile               string        `yaml:"ldap_ca_file"`
+	LDAPBindDN               string        `yaml:"ldap_bind_dn"`
+	LDAPBindPassword         string        `yaml:"ldap_bind_password"`
+	LDAPUserDNs              []string      `yaml:"ldap_user_dns"`
+	LDAPBaseDNs              []string      `yaml:"ldap_base_dns"`
+	LDAPUserFilter           string        `yaml:"ldap_user_filter" env-default:"(uid=%s)"`
+	LDAPUsernameAttribute    string        `yaml:"ldap_username_attribute" env-default:"uid"`
+	LDAPDisplayNameAttribute string        `yaml:"ldap_display_name_attribute" env-default:"displayName"`
+	LDAPEmailAttribute       string        `yaml:"ldap_email_attribute" env-default:"mail"`
+	LDAPDepartmentAttribute  string        `yaml:"ldap_department_attribute" env-default:"departmentNumber"`
+	LDAPTimeout              time.Duration `yaml:"ldap_timeout" env-default:"5s"`
+	LDAPLinkExisting         bool          `yaml:"ldap_link_existing" env-default:"true"`
+	LDAPProvision            bool          `yaml:"ldap_provision" env-default:"true"`
+
+	TwoFactorIssuer       string        `yaml:"two_factor_issuer" env-default:"Merch Store"`
+	TwoFactorChallengeTTL time.Duration `yaml:"two_factor_challenge_ttl" env-default:"5m"`
+	TwoFactorMaxAttempts  int           `yaml:"two_factor_max_attempts" env-default:"5"`
+
+	JWTAlgorithm        string        `yaml:"jwt_algorithm" env-default:"RS256"`
+	JWTIssuer           string        `yaml:"jwt_issuer" env-default:"merch-store-service"`
+	JWTAudience         string        `yaml:"jwt_audience" env-default:"merch-store-service"`
+	JWTKeyRotation      time.Duration `yaml:"jwt_key_rotation" env-default:"720h"`
+	JWTKeyGracePeriod   time.Duration `yaml:"jwt_key_grace_period" env-default:"24h"`
+	JWTKeyCheckInterval time.Duration `yaml:"jwt_key_check_interval" env-default:"1m"`
+
+	AccessTokenTTL            time.Duration `yaml:"access_token_ttl" env-default:"15m"`
+	RefreshTokenTTL           time.Duration `yaml:"refresh_token_ttl" env-default:"720h"`
+	TokenDenylistSyncInterval time.Duration `yaml:"token_denylist_sync_interval" env-default:"10s"`
+	SessionPurgeInterval      time.Duration `yaml:"session_purge_interval" env-default:"1h"`
 }
 
 func LoadConfig() *Config {
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"fmt"
+	"merch-store-service/internal/api"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Rule says who may call an operation.
+type Rule struct {
+	// Public operations can be called without a token.
+	Public bool
+	// Roles lists the roles allowed to call the operation; holding any one of
+	// them is enough. An empty list allows every authenticated user.
+	Roles []string
+	// Scopes lists the API key scopes that let a service account call the
+	// operation. Without scopes, service accounts cannot call it.
+	Scopes []string
+}
+
+// Permissions declares the rule of every operation, keyed by method and chi
+// route pattern, e.g. "POST /api/staff/drops". Operations without a rule are
+// forbidden.
+type Permissions map[string]Rule
+
+// Authorize enforces the permissions. It must run after routing, so that the
+// route pattern is known, and after AuthMiddleware, which puts the user and
+// the roles from the token, or the service account and the scopes of its API
+// key, into the request context.
+func Authorize(permissions Permissions) api.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rule, ok := permissions[operation(r.Method, chi.RouteContext(r.Context()).RoutePattern())]
+			if !ok {
+				writeError(w, http.StatusForbidden, "Forbidden")
+				return
+			}
+
+			if rule.Public {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			_, isUser := r.Context().Value(ctxkeys.UserIDKey).(int)
+			roles, _ := r.Context().Value(ctxkeys.RolesKey).([]string)
+
+			if _, ok := r.Context().Value(ctxkeys.ServiceAccountKey).(string); ok {
+				// A service account acting on behalf of a user needs both
+				// the scope and one of the user's roles.
+				scopes, _ := r.Context().Value(ctxkeys.ScopesKey).([]string)
+				if !intersects(scopes, rule.Scopes) || (isUser && !rule.allowsRoles(roles)) {
+					writeError(w, http.StatusForbidden, "Forbidden")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !isUser {
+				writeError(w, http.StatusUnauthorized, "Unauthorized")
+				return
+			}
+
+			if !rule.allowsRoles(roles) {
+				writeError(w, http.StatusForbidden, "Forbidden")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Check reports routes of the router that have no rule, and rules that do not
+// match any route, so that a new operation cannot be left undeclared.
+func (p Permissions) Check(routes chi.Routes) error {
+	declared := make(map[string]struct{}, len(p))
+	for op := range p {
+		declared[op] = struct{}{}
+	}
+
+	var missing []string
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		op := operation(method, route)
+		if _, ok := declared[op]; !ok {
+			missing = append(missing, op)
+		}
+		delete(declared, op)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var unknown []string
+	for op := range declared {
+		unknown = append(unknown, op)
+	}
+	sort.Strings(missing)
+	sort.Strings(unknown)
+
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "no rule for "+strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		problems = append(problems, "rules for unknown routes "+strings.Join(unknown, ", "))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("permissions: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (r Rule) allowsRoles(roles []string) bool {
+	return len(r.Roles) == 0 || intersects(roles, r.Roles)
+}
+
+func intersects(held, allowed []string) bool {
+	return slices.ContainsFunc(held, func(value string) bool {
+		return slices.Contains(allowed, value)
+	})
+}
+
+func operation(method, route string) string {
+	return method + " " + route
+}
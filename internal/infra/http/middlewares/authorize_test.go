@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"merch-store-service/pkg/ctxkeys"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAuthorizedRouter(permissions Permissions) chi.Router {
+	router := chi.NewRouter()
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	router.Group(func(r chi.Router) {
+		r.Use(Authorize(permissions))
+		r.Post("/api/auth/login", ok)
+		r.Get("/api/info", ok)
+		r.Get("/api/staff/orders", ok)
+		r.Post("/api/staff/orders/{orderId}/cancel", ok)
+		r.Get("/api/secret", ok)
+	})
+
+	return router
+}
+
+func TestAuthorize(t *testing.T) {
+	router := newAuthorizedRouter(Permissions{
+		"POST /api/auth/login":                    {Public: true},
+		"GET /api/info":                           {},
+		"GET /api/staff/orders":                   {Roles: []string{"shop-manager", "auditor"}},
+		"POST /api/staff/orders/{orderId}/cancel": {Roles: []string{"shop-manager"}},
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		userID         int
+		roles          []string
+		expectedStatus int
+	}{
+		{"Public operation without a token", http.MethodPost, "/api/auth/login", 0, nil, http.StatusOK},
+		{"Authenticated operation without a token", http.MethodGet, "/api/info", 0, nil, http.StatusUnauthorized},
+		{"Authenticated operation with any role", http.MethodGet, "/api/info", 1, []string{"employee"}, http.StatusOK},
+		{"Authenticated operation without roles", http.MethodGet, "/api/info", 1, nil, http.StatusOK},
+		{"Role operation without a token", http.MethodGet, "/api/staff/orders", 0, nil, http.StatusUnauthorized},
+		{"Role operation with a matching role", http.MethodGet, "/api/staff/orders", 1, []string{"employee", "auditor"}, http.StatusOK},
+		{"Role operation without a matching role", http.MethodGet, "/api/staff/orders", 1, []string{"employee"}, http.StatusForbidden},
+		{"Route pattern with a parameter", http.MethodPost, "/api/staff/orders/7/cancel", 1, []string{"shop-manager"}, http.StatusOK},
+		{"Read-only role on a write operation", http.MethodPost, "/api/staff/orders/7/cancel", 1, []string{"auditor"}, http.StatusForbidden},
+		{"Undeclared operation", http.MethodGet, "/api/secret", 1, []string{"employee", "shop-manager", "hr-admin", "auditor"}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.userID != 0 {
+				ctx := context.WithValue(req.Context(), ctxkeys.UserIDKey, tt.userID)
+				ctx = context.WithValue(ctx, ctxkeys.RolesKey, tt.roles)
+				req = req.WithContext(ctx)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestAuthorizeServiceAccount(t *testing.T) {
+	router := newAuthorizedRouter(Permissions{
+		"GET /api/info":                           {},
+		"GET /api/staff/orders":                   {Roles: []string{"shop-manager"}, Scopes: []string{"catalog:write"}},
+		"POST /api/staff/orders/{orderId}/cancel": {Roles: []string{"employee"}, Scopes: []string{"coins:send"}},
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		scopes         []string
+		userID         int
+		roles          []string
+		expectedStatus int
+	}{
+		{"Operation without scopes", http.MethodGet, "/api/info", []string{"catalog:write"}, 0, nil, http.StatusForbidden},
+		{"Matching scope", http.MethodGet, "/api/staff/orders", []string{"coins:send", "catalog:write"}, 0, nil, http.StatusOK},
+		{"Missing scope", http.MethodGet, "/api/staff/orders", []string{"coins:send"}, 0, nil, http.StatusForbidden},
+		{"On behalf of a user with the role", http.MethodPost, "/api/staff/orders/7/cancel", []string{"coins:send"}, 7, []string{"employee"}, http.StatusOK},
+		{"On behalf of a user without the role", http.MethodPost, "/api/staff/orders/7/cancel", []string{"coins:send"}, 7, []string{"auditor"}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			ctx := context.WithValue(req.Context(), ctxkeys.ServiceAccountKey, "chat-bot")
+			ctx = context.WithValue(ctx, ctxkeys.ScopesKey, tt.scopes)
+			if tt.userID != 0 {
+				ctx = context.WithValue(ctx, ctxkeys.UserIDKey, tt.userID)
+				ctx = context.WithValue(ctx, ctxkeys.RolesKey, tt.roles)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req.WithContext(ctx))
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
+
+func TestPermissionsCheck(t *testing.T) {
+	complete := Permissions{
+		"POST /api/auth/login":                    {Public: true},
+		"GET /api/info":                           {},
+		"GET /api/staff/orders":                   {Roles: []string{"shop-manager"}},
+		"POST /api/staff/orders/{orderId}/cancel": {Roles: []string{"shop-manager"}},
+		"GET /api/secret":                         {},
+	}
+	assert.NoError(t, complete.Check(newAuthorizedRouter(complete)))
+
+	incomplete := Permissions{
+		"POST /api/auth/login":  {Public: true},
+		"GET /api/info":         {},
+		"GET /api/staff/orders": {Roles: []string{"shop-manager"}},
+		"DELETE /api/info":      {},
+	}
+	err := incomplete.Check(newAuthorizedRouter(incomplete))
+	assert.EqualError(t, err, "permissions: no rule for GET /api/secret, POST /api/staff/orders/{orderId}/cancel; rules for unknown routes DELETE /api/info")
+}
@@ -3,20 +3,44 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"merch-store-service/internal/api"
+	"merch-store-service/internal/domain/models"
 	"merch-store-service/internal/infra/jwtutils"
 	"merch-store-service/pkg/ctxkeys"
 	"net/http"
 	"strings"
 )
 
+// RevocationChecker reports whether the access token with the given id (jti)
+// has been revoked.
+type RevocationChecker interface {
+	IsRevoked(tokenID string) bool
+}
+
+// APIKeyAuthenticator checks the API key of a service account. With a
+// non-empty onBehalfOf the service account acts as that user.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key, onBehalfOf string) (*models.APIPrincipal, error)
+}
+
+const (
+	// APIKeyHeader carries the API key of a service account. It is separate
+	// from Authorization, which is reserved for user tokens.
+	APIKeyHeader = "X-API-Key"
+	// OnBehalfOfHeader names the user a service account acts for.
+	OnBehalfOfHeader = "X-On-Behalf-Of"
+)
+
 type AuthMiddleware struct {
 	JWTManager *jwtutils.JWTManager
+	Revoked    RevocationChecker
+	APIKeys    APIKeyAuthenticator
 }
 
-func NewAuthMiddleware(jwtManager *jwtutils.JWTManager) *AuthMiddleware {
-	return &AuthMiddleware{JWTManager: jwtManager}
+func NewAuthMiddleware(jwtManager *jwtutils.JWTManager, revoked RevocationChecker, apiKeys APIKeyAuthenticator) *AuthMiddleware {
+	return &AuthMiddleware{JWTManager: jwtManager, Revoked: revoked, APIKeys: apiKeys}
 }
 
 func writeError(w http.ResponseWriter, statusCode int, errMsg string) {
@@ -35,6 +59,15 @@ func (m *AuthMiddleware) Middleware() api.MiddlewareFunc {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 
+			if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+				if authHeader != "" {
+					writeError(w, http.StatusUnauthorized, "Unauthorized")
+					return
+				}
+				m.serveAPIKey(w, r, next, apiKey)
+				return
+			}
+
 			if authHeader == "" {
 				next.ServeHTTP(w, r)
 				return
@@ -54,11 +87,54 @@ func (m *AuthMiddleware) Middleware() api.MiddlewareFunc {
 				return
 			}
 
+			if m.Revoked != nil && m.Revoked.IsRevoked(claims.ID) {
+				writeError(w, http.StatusUnauthorized, "Unauthorized")
+				return
+			}
+
 			userID := claims.UserID
 
 			ctx := context.WithValue(r.Context(), ctxkeys.UserIDKey, userID)
+			if claims.SessionID != "" {
+				ctx = context.WithValue(ctx, ctxkeys.SessionIDKey, claims.SessionID)
+			}
+			ctx = context.WithValue(ctx, ctxkeys.RolesKey, claims.Roles)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+func (m *AuthMiddleware) serveAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler, apiKey string) {
+	if m.APIKeys == nil {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	principal, err := m.APIKeys.AuthenticateAPIKey(r.Context(), apiKey, r.Header.Get(OnBehalfOfHeader))
+	if err != nil {
+		if !errors.Is(err, models.ErrInvalidAPIKey) {
+			log.Printf("API key authentication failed: %v", err)
+		}
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	scopes := make([]string, len(principal.Scopes))
+	for i, scope := range principal.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	ctx := context.WithValue(r.Context(), ctxkeys.ServiceAccountKey, principal.Account)
+	ctx = context.WithValue(ctx, ctxkeys.ScopesKey, scopes)
+	if principal.UserID != 0 {
+		roles := make([]string, len(principal.Roles))
+		for i, role := range principal.Roles {
+			roles[i] = string(role)
+		}
+		ctx = context.WithValue(ctx, ctxkeys.UserIDKey, principal.UserID)
+		ctx = context.WithValue(ctx, ctxkeys.RolesKey, roles)
+	}
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"merch-store-service/internal/domain/models"
 	"merch-store-service/internal/infra/config"
 	"merch-store-service/internal/infra/jwtutils"
 	"merch-store-service/pkg/ctxkeys"
@@ -23,12 +24,39 @@ func (m *mockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	m.ctx = r.Context()
 }
 
+type revokedSet map[string]bool
+
+func (r revokedSet) IsRevoked(tokenID string) bool {
+	return r[tokenID]
+}
+
+type apiKeySet map[string]*models.APIPrincipal
+
+func (k apiKeySet) AuthenticateAPIKey(_ context.Context, key, onBehalfOf string) (*models.APIPrincipal, error) {
+	principal, ok := k[key]
+	if !ok {
+		return nil, models.ErrInvalidAPIKey
+	}
+	result := *principal
+	if onBehalfOf == "alice" {
+		result.UserID = 7
+		result.Roles = []models.Role{models.RoleEmployee}
+	}
+	return &result, nil
+}
+
 func TestAuthMiddleware(t *testing.T) {
-	jwtManager := jwtutils.NewJWTManager(&config.Config{JWTSecret: "test-secret"})
-	middleware := NewAuthMiddleware(jwtManager)
+	jwtManager := jwtutils.NewJWTManager(&config.Config{
+		JWTAlgorithm: jwtutils.AlgorithmEdDSA,
+		JWTIssuer:    "merch-store-service",
+		JWTAudience:  "merch-store-service",
+	}, jwtutils.NewMemoryKeyStore())
+	assert.NoError(t, jwtManager.Rotate(context.Background()))
+	middleware := NewAuthMiddleware(jwtManager, revokedSet{"revoked-token": true}, nil)
 
-	validToken, _ := jwtManager.NewToken(12345, time.Minute*10)
-	expiredToken, _ := jwtManager.NewToken(12345, -time.Minute)
+	validToken, _ := jwtManager.NewSessionToken(12345, "session-1", "active-token", nil, time.Now().Add(time.Minute))
+	expiredToken, _ := jwtManager.NewSessionToken(12345, "session-1", "expired-token", nil, time.Now().Add(-time.Minute))
+	revokedToken, _ := jwtManager.NewSessionToken(12345, "session-1", "revoked-token", nil, time.Now().Add(time.Minute))
 
 	tests := []struct {
 		name           string
@@ -41,6 +69,7 @@ func TestAuthMiddleware(t *testing.T) {
 		{"Malformed JWT", "Bearer invalid.token.string", http.StatusUnauthorized, 0},
 		{"Expired token", "Bearer " + expiredToken, http.StatusUnauthorized, 0},
 		{"Valid token", "Bearer " + validToken, http.StatusOK, 12345},
+		{"Revoked token", "Bearer " + revokedToken, http.StatusUnauthorized, 0},
 	}
 
 	for _, tt := range tests {
@@ -62,3 +91,60 @@ func TestAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthMiddlewareAPIKey(t *testing.T) {
+	jwtManager := jwtutils.NewJWTManager(&config.Config{
+		JWTAlgorithm: jwtutils.AlgorithmEdDSA,
+		JWTIssuer:    "merch-store-service",
+		JWTAudience:  "merch-store-service",
+	}, jwtutils.NewMemoryKeyStore())
+	assert.NoError(t, jwtManager.Rotate(context.Background()))
+	middleware := NewAuthMiddleware(jwtManager, nil, apiKeySet{
+		"msk_0123456789abcdef_secret": {Account: "chat-bot", Scopes: []models.Scope{models.ScopeCoinsSend}},
+	})
+	validToken, _ := jwtManager.NewSessionToken(12345, "session-1", "active-token", nil, time.Now().Add(time.Minute))
+
+	tests := []struct {
+		name            string
+		apiKey          string
+		onBehalfOf      string
+		authHeader      string
+		expectedStatus  int
+		expectedAccount string
+		expectedUserID  int
+	}{
+		{"Valid key", "msk_0123456789abcdef_secret", "", "", http.StatusOK, "chat-bot", 0},
+		{"Valid key on behalf of a user", "msk_0123456789abcdef_secret", "alice", "", http.StatusOK, "chat-bot", 7},
+		{"Unknown key", "msk_fedcba9876543210_secret", "", "", http.StatusUnauthorized, "", 0},
+		{"Key together with a token", "msk_0123456789abcdef_secret", "", "Bearer " + validToken, http.StatusUnauthorized, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(APIKeyHeader, tt.apiKey)
+			if tt.onBehalfOf != "" {
+				req.Header.Set(OnBehalfOfHeader, tt.onBehalfOf)
+			}
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+
+			recorder := httptest.NewRecorder()
+			mock := &mockHandler{}
+
+			middleware.Middleware()(mock).ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedAccount != "" {
+				account, _ := mock.ctx.Value(ctxkeys.ServiceAccountKey).(string)
+				scopes, _ := mock.ctx.Value(ctxkeys.ScopesKey).([]string)
+				userID, _ := mock.ctx.Value(ctxkeys.UserIDKey).(int)
+				assert.Equal(t, tt.expectedAccount, account)
+				assert.Equal(t, []string{"coins:send"}, scopes)
+				assert.Equal(t, tt.expectedUserID, userID)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package jwtutils
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"merch-store-service/internal/infra/config"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -13,50 +16,161 @@ var (
 	ErrInvalidToken       = errors.New("Unauthorized")
 	ErrInvalidSigning     = errors.New("Unauthorized")
 	ErrInvalidTokenClaims = errors.New("Unauthorized")
+	ErrNoSigningKey       = errors.New("no signing key loaded")
 )
 
+// JWTManager signs access tokens with the newest key from the key store and
+// verifies them with any key that has not retired. Keys are identified by
+// the kid header, so verifiers only need the public keys from JWKS.
 type JWTManager struct {
-	SecretKey string
+	algorithm string
+	issuer    string
+	audience  string
+	rotation  time.Duration
+	grace     time.Duration
+	store     KeyStore
+
+	mu      sync.RWMutex
+	signing *signingKey
+	keys    map[string]*signingKey
 }
 
-func NewJWTManager(config *config.Config) *JWTManager {
+func NewJWTManager(config *config.Config, store KeyStore) *JWTManager {
 	return &JWTManager{
-		SecretKey: config.JWTSecret,
+		algorithm: config.JWTAlgorithm,
+		issuer:    config.JWTIssuer,
+		audience:  config.JWTAudience,
+		rotation:  config.JWTKeyRotation,
+		grace:     config.JWTKeyGracePeriod,
+		store:     store,
+		keys:      make(map[string]*signingKey),
 	}
 }
 
+// Claims is the payload of an access token. The token id (jti) is used to
+// revoke the token; SessionID ties it to the session it was issued for.
+// Roles are the user's roles at the time the token was issued.
 type Claims struct {
-	UserID int `json:"user_id"`
+	UserID    int      `json:"user_id"`
+	SessionID string   `json:"sid,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func (j *JWTManager) NewToken(UserID int, duration time.Duration) (string, error) {
-	expirationTime := time.Now().Add(duration)
-	if duration == 0 {
-		expirationTime = time.Now().Add(time.Hour * 24)
+// Rotate adds a new signing key when there is none or the newest one is
+// older than the rotation period, then reloads the keys from the store. The
+// previous keys keep verifying tokens for the grace period. It is run on
+// startup and periodically, which also picks up keys added by other
+// instances.
+func (j *JWTManager) Rotate(ctx context.Context) error {
+	keys, err := j.store.ListSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	// Timestamps are stored without a time zone, so keep them all in UTC.
+	now := time.Now().UTC()
+	if len(keys) == 0 || (j.rotation > 0 && !keys[len(keys)-1].CreatedAt.Add(j.rotation).After(now)) {
+		key, err := generateSigningKey(j.algorithm, now)
+		if err != nil {
+			return err
+		}
+		if err := j.store.AddSigningKey(ctx, key, now.Add(j.grace)); err != nil {
+			return fmt.Errorf("failed to store signing key: %w", err)
+		}
+
+		if keys, err = j.store.ListSigningKeys(ctx); err != nil {
+			return fmt.Errorf("failed to load signing keys: %w", err)
+		}
+	}
+
+	parsed := make(map[string]*signingKey, len(keys))
+	var newest *signingKey
+	for _, key := range keys {
+		k, err := parseSigningKey(key)
+		if err != nil {
+			return err
+		}
+		parsed[k.id] = k
+		if newest == nil || !k.created.Before(newest.created) {
+			newest = k
+		}
+	}
+
+	j.mu.Lock()
+	j.keys = parsed
+	j.signing = newest
+	j.mu.Unlock()
+
+	return nil
+}
+
+// JWKS returns the public keys that can verify tokens, ordered by key id.
+func (j *JWTManager) JWKS() []JWK {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	keys := make([]JWK, 0, len(j.keys))
+	for _, key := range j.keys {
+		keys = append(keys, key.jwk())
+	}
+	sort.Slice(keys, func(a, b int) bool { return keys[a].KeyID < keys[b].KeyID })
+
+	return keys
+}
+
+// NewSessionToken issues an access token for the session. The token id is
+// chosen by the caller, so it can be recorded before the token is handed out.
+func (j *JWTManager) NewSessionToken(userID int, sessionID, tokenID string, roles []string, expiresAt time.Time) (string, error) {
+	return j.sign(userID, sessionID, tokenID, roles, expiresAt)
+}
+
+func (j *JWTManager) sign(userID int, sessionID, tokenID string, roles []string, expiresAt time.Time) (string, error) {
+	j.mu.RLock()
+	key := j.signing
+	j.mu.RUnlock()
+	if key == nil {
+		return "", ErrNoSigningKey
 	}
 
 	claims := Claims{
-		UserID: UserID,
+		UserID:    userID,
+		SessionID: sessionID,
+		Roles:     roles,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ID:        tokenID,
+			Issuer:    j.issuer,
+			Audience:  jwt.ClaimStrings{j.audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
 
-	return token.SignedString([]byte(j.SecretKey))
+	return token.SignedString(key.private)
 }
 
 // ValidateToken проверяет JWT и возвращает payload
 func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 	parsedToken, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+
+		j.mu.RLock()
+		key, ok := j.keys[kid]
+		j.mu.RUnlock()
+
+		if !ok || token.Method.Alg() != key.method.Alg() {
 			return nil, ErrInvalidSigning
 		}
-		return []byte(j.SecretKey), nil
-	})
+		return key.private.Public(), nil
+	},
+		jwt.WithValidMethods([]string{AlgorithmRS256, AlgorithmEdDSA}),
+		jwt.WithIssuer(j.issuer),
+		jwt.WithAudience(j.audience),
+		jwt.WithExpirationRequired(),
+	)
 
 	if err != nil {
 		return nil, fmt.Errorf("token parsing failed: %w", err)
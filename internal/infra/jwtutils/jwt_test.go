@@ -1,15 +1,43 @@
 package jwtutils
 
 import (
+	"context"
 	"merch-store-service/internal/infra/config"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+func newTestManager(t *testing.T, cfg config.Config, store KeyStore) *JWTManager {
+	if cfg.JWTAlgorithm == "" {
+		cfg.JWTAlgorithm = AlgorithmEdDSA
+	}
+	if cfg.JWTIssuer == "" {
+		cfg.JWTIssuer = "merch-store-service"
+	}
+	if cfg.JWTAudience == "" {
+		cfg.JWTAudience = "merch-store-service"
+	}
+
+	if store == nil {
+		store = NewMemoryKeyStore()
+	}
+
+	jwtManager := NewJWTManager(&cfg, store)
+	assert.NoError(t, jwtManager.Rotate(context.Background()))
+	return jwtManager
+}
+
+// newToken issues a session token for userID that expires after d.
+func newToken(j *JWTManager, userID int, d time.Duration) (string, error) {
+	return j.NewSessionToken(userID, "session-1", uuid.NewString(), nil, time.Now().Add(d))
+}
+
 func TestJWTManager(t *testing.T) {
-	jwtManager := NewJWTManager(&config.Config{JWTSecret: "test-secret"})
+	jwtManager := newTestManager(t, config.Config{}, nil)
 
 	tests := []struct {
 		name        string
@@ -19,13 +47,12 @@ func TestJWTManager(t *testing.T) {
 	}{
 		{"Valid token", 12345, time.Minute * 10, false},
 		{"Expired token", 12345, -time.Minute, true},
-		{"Zero duration (valid for 24h)", 12345, 0, false},
 		{"Negative duration", 12345, -time.Hour, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			token, err := jwtManager.NewToken(tt.userID, tt.duration)
+			token, err := newToken(jwtManager, tt.userID, tt.duration)
 			assert.NoError(t, err, "Ошибка при создании токена")
 			assert.NotEmpty(t, token, "Токен не должен быть пустым")
 
@@ -41,7 +68,9 @@ func TestJWTManager(t *testing.T) {
 }
 
 func TestInvalidTokens(t *testing.T) {
-	jwtManager := NewJWTManager(&config.Config{JWTSecret: "test-secret"})
+	// Managers sharing the key store differ only in their claims.
+	store := NewMemoryKeyStore()
+	jwtManager := newTestManager(t, config.Config{}, store)
 
 	tests := []struct {
 		name        string
@@ -49,9 +78,25 @@ func TestInvalidTokens(t *testing.T) {
 	}{
 		{"Invalid format", "invalid.token.string"},
 		{"Different signing key", func() string {
-			jwtManager1 := NewJWTManager(&config.Config{JWTSecret: "secret-1"})
+			jwtManager1 := newTestManager(t, config.Config{}, nil)
 
-			token, _ := jwtManager1.NewToken(12345, time.Minute*10)
+			token, _ := newToken(jwtManager1, 12345, time.Minute*10)
+			return token
+		}()},
+		{"Different issuer", func() string {
+			jwtManager1 := newTestManager(t, config.Config{JWTIssuer: "someone-else"}, store)
+
+			token, _ := newToken(jwtManager1, 12345, time.Minute*10)
+			return token
+		}()},
+		{"Different audience", func() string {
+			jwtManager1 := newTestManager(t, config.Config{JWTAudience: "another-service"}, store)
+
+			token, _ := newToken(jwtManager1, 12345, time.Minute*10)
+			return token
+		}()},
+		{"HS256 token", func() string {
+			token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{UserID: 12345}).SignedString([]byte("secret"))
 			return token
 		}()},
 		{"Empty token", ""},
@@ -65,3 +110,73 @@ func TestInvalidTokens(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionToken(t *testing.T) {
+	jwtManager := newTestManager(t, config.Config{}, nil)
+
+	token, err := jwtManager.NewSessionToken(12345, "session-1", "token-1", []string{"employee", "auditor"}, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
+	claims, err := jwtManager.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 12345, claims.UserID)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, "token-1", claims.ID)
+	assert.Equal(t, []string{"employee", "auditor"}, claims.Roles)
+	assert.Equal(t, "merch-store-service", claims.Issuer)
+	assert.Equal(t, jwt.ClaimStrings{"merch-store-service"}, claims.Audience)
+}
+
+func TestKeyRotation(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			jwtManager := newTestManager(t, config.Config{
+				JWTAlgorithm:      algorithm,
+				JWTKeyRotation:    time.Nanosecond,
+				JWTKeyGracePeriod: time.Hour,
+			}, nil)
+
+			oldToken, err := newToken(jwtManager, 12345, time.Minute)
+			assert.NoError(t, err)
+			oldKid := jwtManager.JWKS()[0].KeyID
+
+			assert.NoError(t, jwtManager.Rotate(context.Background()))
+
+			jwks := jwtManager.JWKS()
+			assert.Len(t, jwks, 2, "The previous key should be published during the grace period")
+			for _, key := range jwks {
+				assert.Equal(t, algorithm, key.Algorithm)
+				assert.Equal(t, "sig", key.Use)
+			}
+
+			_, err = jwtManager.ValidateToken(oldToken)
+			assert.NoError(t, err, "Tokens signed with the previous key should stay valid")
+
+			newToken, err := newToken(jwtManager, 12345, time.Minute)
+			assert.NoError(t, err)
+			parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &Claims{})
+			assert.NoError(t, err)
+			assert.NotEqual(t, oldKid, parsed.Header["kid"], "New tokens should be signed with the new key")
+		})
+	}
+
+	t.Run("grace period over", func(t *testing.T) {
+		jwtManager := newTestManager(t, config.Config{JWTKeyRotation: time.Nanosecond}, nil)
+
+		oldToken, err := newToken(jwtManager, 12345, time.Minute)
+		assert.NoError(t, err)
+
+		assert.NoError(t, jwtManager.Rotate(context.Background()))
+		assert.Len(t, jwtManager.JWKS(), 1)
+
+		_, err = jwtManager.ValidateToken(oldToken)
+		assert.Error(t, err, "Tokens signed with a retired key should be rejected")
+	})
+
+	t.Run("no rotation before the period ends", func(t *testing.T) {
+		jwtManager := newTestManager(t, config.Config{JWTKeyRotation: time.Hour}, nil)
+
+		assert.NoError(t, jwtManager.Rotate(context.Background()))
+		assert.Len(t, jwtManager.JWKS(), 1)
+	})
+}
@@ -0,0 +1,174 @@
+package jwtutils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"merch-store-service/internal/domain/models"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+var ErrUnknownAlgorithm = errors.New("unknown signing algorithm")
+
+// KeyStore keeps the signing keys shared by all instances of the service.
+type KeyStore interface {
+	// ListSigningKeys returns the keys that have not retired yet, oldest first.
+	ListSigningKeys(ctx context.Context) ([]models.SigningKey, error)
+	// AddSigningKey stores a new key and schedules every key that is not
+	// retiring yet to retire at retireAt.
+	AddSigningKey(ctx context.Context, key models.SigningKey, retireAt time.Time) error
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// signingKey is a parsed models.SigningKey.
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	created time.Time
+}
+
+// generateSigningKey creates a key pair for the algorithm. The key id is
+// derived from the public key.
+func generateSigningKey(algorithm string, now time.Time) (models.SigningKey, error) {
+	var (
+		private any
+		err     error
+	)
+	switch algorithm {
+	case AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return models.SigningKey{}, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, algorithm)
+	}
+	if err != nil {
+		return models.SigningKey{}, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return models.SigningKey{}, err
+	}
+	public, err := x509.MarshalPKIXPublicKey(private.(crypto.Signer).Public())
+	if err != nil {
+		return models.SigningKey{}, err
+	}
+	thumbprint := sha256.Sum256(public)
+
+	return models.SigningKey{
+		ID:         hex.EncodeToString(thumbprint[:8]),
+		Algorithm:  algorithm,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		CreatedAt:  now,
+	}, nil
+}
+
+func parseSigningKey(key models.SigningKey) (*signingKey, error) {
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("key %s: invalid PEM", key.ID)
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", key.ID, err)
+	}
+
+	parsed := &signingKey{id: key.ID, created: key.CreatedAt}
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		parsed.method, parsed.private = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		parsed.method, parsed.private = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("key %s: %w: %T", key.ID, ErrUnknownAlgorithm, private)
+	}
+	if parsed.method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("key %s: stored as %s but is a %s key", key.ID, key.Algorithm, parsed.method.Alg())
+	}
+
+	return parsed, nil
+}
+
+func (k *signingKey) jwk() JWK {
+	jwk := JWK{KeyID: k.id, Use: "sig", Algorithm: k.method.Alg()}
+	switch public := k.private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// MemoryKeyStore is a KeyStore for a single instance that does not need its
+// keys to survive a restart, such as tests.
+type MemoryKeyStore struct {
+	mu   sync.Mutex
+	keys []models.SigningKey
+}
+
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{}
+}
+
+func (s *MemoryKeyStore) ListSigningKeys(_ context.Context) ([]models.SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	keys := make([]models.SigningKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		if key.RetiresAt == nil || key.RetiresAt.After(now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *MemoryKeyStore) AddSigningKey(_ context.Context, key models.SigningKey, retireAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.keys {
+		if s.keys[i].RetiresAt == nil {
+			s.keys[i].RetiresAt = &retireAt
+		}
+	}
+	s.keys = append(s.keys, key)
+	return nil
+}
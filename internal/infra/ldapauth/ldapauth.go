@@ -0,0 +1,273 @@
+// Package ldapauth checks passwords by binding to an LDAP directory.
+package ldapauth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid ldap config")
+	ErrAmbiguousUser = errors.New("ldap search found more than one entry for the user")
+)
+
+// Config describes how users are found in the directory. With BaseDNs set,
+// the user is searched for with UserFilter under each base in turn, bound as
+// BindDN (or anonymously if it is empty), and then the found entry is bound
+// with the user's password. Otherwise each UserDNs template is bound
+// directly. Both UserFilter and UserDNs contain a single %s, which is
+// replaced by the escaped username.
+//
+// The local user is named after the entry's UsernameAttribute, such as uid
+// or sAMAccountName, rather than after what was typed, so that "Alice" and
+// "alice" sign in as the same user. Without it the typed username is
+// lowercased.
+type Config struct {
+	URL          string
+	StartTLS     bool
+	CAFile       string
+	BindDN       string
+	BindPassword string
+	UserDNs      []string
+	BaseDNs      []string
+	UserFilter   string
+	Timeout      time.Duration
+
+	UsernameAttribute    string
+	DisplayNameAttribute string
+	EmailAttribute       string
+	DepartmentAttribute  string
+}
+
+// Directory authenticates users against an LDAP server. A connection is
+// opened for each sign-in, so it is safe for concurrent use.
+type Directory struct {
+	cfg Config
+	tls *tls.Config
+}
+
+func New(cfg Config) (*Directory, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("%w: no server url", ErrInvalidConfig)
+	}
+	if len(cfg.BaseDNs) == 0 && len(cfg.UserDNs) == 0 {
+		return nil, fmt.Errorf("%w: neither base DNs to search nor user DN templates", ErrInvalidConfig)
+	}
+	for _, template := range append([]string{cfg.UserFilter}, cfg.UserDNs...) {
+		if template != "" && strings.Count(template, "%s") != 1 {
+			return nil, fmt.Errorf("%w: %q must contain %%s exactly once", ErrInvalidConfig, template)
+		}
+	}
+	if len(cfg.BaseDNs) > 0 && cfg.UserFilter == "" {
+		return nil, fmt.Errorf("%w: base DNs need a user filter", ErrInvalidConfig)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificates in %s", ErrInvalidConfig, cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &Directory{cfg: cfg, tls: tlsConfig}, nil
+}
+
+// Authenticate binds as the user with the password and returns the user's
+// entry. A wrong password or an unknown user is models.ErrInvalidCredentials;
+// any other error means the directory could not be asked.
+func (d *Directory) Authenticate(ctx context.Context, username, password string) (*models.DirectoryUser, error) {
+	// An empty password would be an unauthenticated bind, which succeeds
+	// for any DN on many servers.
+	if username == "" || password == "" {
+		return nil, models.ErrInvalidCredentials
+	}
+
+	conn, err := d.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if len(d.cfg.BaseDNs) > 0 {
+		return d.searchAndBind(conn, username, password)
+	}
+	return d.bindDirect(conn, username, password)
+}
+
+func (d *Directory) dial(ctx context.Context) (*ldap.Conn, error) {
+	timeout := d.cfg.Timeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+
+	conn, err := ldap.DialURL(d.cfg.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(d.tls))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ldap: %w", err)
+	}
+	conn.SetTimeout(timeout)
+
+	if d.cfg.StartTLS {
+		if err := conn.StartTLS(d.tls); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap starttls failed: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// searchAndBind finds the user's entry with the service account and then
+// checks the password by binding as that entry.
+func (d *Directory) searchAndBind(conn *ldap.Conn, username, password string) (*models.DirectoryUser, error) {
+	if d.cfg.BindDN != "" {
+		if err := conn.Bind(d.cfg.BindDN, d.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("ldap service bind failed: %w", err)
+		}
+	}
+
+	filter := fmt.Sprintf(d.cfg.UserFilter, ldap.EscapeFilter(username))
+	var entry *ldap.Entry
+	for _, base := range d.cfg.BaseDNs {
+		found, err := d.search(conn, base, ldap.ScopeWholeSubtree, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) > 1 {
+			return nil, fmt.Errorf("%w: %q under %s", ErrAmbiguousUser, username, base)
+		}
+		if len(found) == 1 {
+			entry = found[0]
+			break
+		}
+	}
+	if entry == nil {
+		return nil, models.ErrInvalidCredentials
+	}
+
+	if err := bind(conn, entry.DN, password); err != nil {
+		return nil, err
+	}
+
+	return d.directoryUser(username, entry)
+}
+
+// bindDirect tries each DN template in turn and reads the entry it binds as.
+func (d *Directory) bindDirect(conn *ldap.Conn, username, password string) (*models.DirectoryUser, error) {
+	for _, template := range d.cfg.UserDNs {
+		dn := fmt.Sprintf(template, EscapeDN(username))
+		err := bind(conn, dn, password)
+		if errors.Is(err, models.ErrInvalidCredentials) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		found, err := d.search(conn, dn, ldap.ScopeBaseObject, "(objectClass=*)")
+		if err != nil {
+			return nil, err
+		}
+		if len(found) != 1 {
+			return nil, fmt.Errorf("ldap entry %s cannot be read after bind", dn)
+		}
+		return d.directoryUser(username, found[0])
+	}
+
+	return nil, models.ErrInvalidCredentials
+}
+
+func (d *Directory) search(conn *ldap.Conn, base string, scope int, filter string) ([]*ldap.Entry, error) {
+	var attributes []string
+	for _, attribute := range []string{d.cfg.UsernameAttribute, d.cfg.DisplayNameAttribute, d.cfg.EmailAttribute, d.cfg.DepartmentAttribute} {
+		if attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	if len(attributes) == 0 {
+		// No attributes are synced, so only the DN is needed.
+		attributes = []string{"1.1"}
+	}
+
+	req := ldap.NewSearchRequest(base, scope, ldap.NeverDerefAliases, 2, int(d.cfg.Timeout.Seconds()), false,
+		filter, attributes, nil)
+
+	result, err := conn.Search(req)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ldap search under %s failed: %w", base, err)
+	}
+	return result.Entries, nil
+}
+
+func (d *Directory) directoryUser(username string, entry *ldap.Entry) (*models.DirectoryUser, error) {
+	if d.cfg.UsernameAttribute == "" {
+		username = strings.ToLower(username)
+	} else {
+		username = entry.GetAttributeValue(d.cfg.UsernameAttribute)
+		if username == "" {
+			return nil, fmt.Errorf("ldap entry %s has no %s attribute", entry.DN, d.cfg.UsernameAttribute)
+		}
+	}
+
+	return &models.DirectoryUser{
+		Source:      models.AuthenticatorLDAP,
+		DN:          entry.DN,
+		Username:    username,
+		DisplayName: entry.GetAttributeValue(d.cfg.DisplayNameAttribute),
+		Email:       entry.GetAttributeValue(d.cfg.EmailAttribute),
+		Department:  entry.GetAttributeValue(d.cfg.DepartmentAttribute),
+	}, nil
+}
+
+func bind(conn *ldap.Conn, dn, password string) error {
+	err := conn.Bind(dn, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return models.ErrInvalidCredentials
+	}
+	if err != nil {
+		return fmt.Errorf("ldap bind failed: %w", err)
+	}
+	return nil
+}
+
+// EscapeDN escapes a value for use in a distinguished name (RFC 4514).
+func EscapeDN(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == 0:
+			b.WriteString(`\00`)
+			continue
+		case strings.IndexByte(`"+,;<>\=`, c) >= 0,
+			i == 0 && (c == ' ' || c == '#'),
+			i == len(value)-1 && c == ' ':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
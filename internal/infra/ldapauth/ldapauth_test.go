@@ -0,0 +1,250 @@
+package ldapauth
+
+import (
+	"context"
+	"fmt"
+	"merch-store-service/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	baseDN        = "dc=example,dc=org"
+	adminDN       = "cn=admin,dc=example,dc=org"
+	adminPassword = "admin"
+)
+
+func TestEscapeDN(t *testing.T) {
+	tests := map[string]string{
+		"alice":        "alice",
+		"smith, john":  `smith\, john`,
+		"a+b=c":        `a\+b\=c`,
+		"#admin":       `\#admin`,
+		" padded ":     `\ padded\ `,
+		`quote"<>;\`:   `quote\"\<\>\;\\`,
+		"nul\x00byte":  `nul\00byte`,
+		"inner space":  "inner space",
+		"trailing#":    "trailing#",
+		"ou=x,dc=evil": `ou\=x\,dc\=evil`,
+	}
+
+	for value, want := range tests {
+		assert.Equal(t, want, EscapeDN(value), value)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "direct bind", cfg: Config{URL: "ldap://ldap.test", UserDNs: []string{"uid=%s,ou=people," + baseDN}}},
+		{name: "search", cfg: Config{URL: "ldap://ldap.test", BaseDNs: []string{baseDN}, UserFilter: "(uid=%s)"}},
+		{name: "no url", cfg: Config{UserDNs: []string{"uid=%s," + baseDN}}, wantErr: true},
+		{name: "nowhere to look", cfg: Config{URL: "ldap://ldap.test"}, wantErr: true},
+		{name: "template without placeholder", cfg: Config{URL: "ldap://ldap.test", UserDNs: []string{"uid=alice," + baseDN}}, wantErr: true},
+		{name: "filter with two placeholders", cfg: Config{URL: "ldap://ldap.test", BaseDNs: []string{baseDN}, UserFilter: "(|(uid=%s)(mail=%s))"}, wantErr: true},
+		{name: "search without filter", cfg: Config{URL: "ldap://ldap.test", BaseDNs: []string{baseDN}}, wantErr: true},
+		{name: "missing ca file", cfg: Config{URL: "ldaps://ldap.test", UserDNs: []string{"uid=%s," + baseDN}, CAFile: "/nonexistent/ca.pem"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthenticateEmptyPassword(t *testing.T) {
+	// The server is never dialled, an empty password is rejected up front.
+	directory, err := New(Config{URL: "ldap://127.0.0.1:1", UserDNs: []string{"uid=%s," + baseDN}})
+	assert.NoError(t, err)
+
+	_, err = directory.Authenticate(context.Background(), "alice", "")
+	assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+}
+
+func TestAuthenticateUnreachable(t *testing.T) {
+	directory, err := New(Config{URL: "ldap://127.0.0.1:1", UserDNs: []string{"uid=%s," + baseDN}, Timeout: time.Second})
+	assert.NoError(t, err)
+
+	_, err = directory.Authenticate(context.Background(), "alice", "alice-secret")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, models.ErrInvalidCredentials, "A directory outage should not look like a wrong password")
+}
+
+// setupLDAP starts an OpenLDAP container and fills it with test users.
+func setupLDAP(t *testing.T) string {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		t.Fatalf("Could not connect to Docker: %s", err)
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "osixia/openldap",
+		Tag:        "1.5.0",
+		Env: []string{
+			"LDAP_ORGANISATION=Example",
+			"LDAP_DOMAIN=example.org",
+			"LDAP_ADMIN_PASSWORD=" + adminPassword,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Could not start resource: %s", err)
+	}
+	t.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			t.Errorf("Could not purge resource: %s", err)
+		}
+	})
+
+	url := fmt.Sprintf("ldap://localhost:%s", resource.GetPort("389/tcp"))
+
+	var conn *ldap.Conn
+	pool.MaxWait = 2 * time.Minute
+	err = pool.Retry(func() error {
+		conn, err = ldap.DialURL(url)
+		if err != nil {
+			return err
+		}
+		if err = conn.Bind(adminDN, adminPassword); err != nil {
+			conn.Close()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Could not connect to LDAP after retries: %s", err)
+	}
+	defer conn.Close()
+
+	add := func(dn string, attributes map[string][]string) {
+		req := ldap.NewAddRequest(dn, nil)
+		for name, values := range attributes {
+			req.Attribute(name, values)
+		}
+		if err := conn.Add(req); err != nil {
+			t.Fatalf("Could not add %s: %s", dn, err)
+		}
+	}
+	person := func(uid, cn, department string) map[string][]string {
+		return map[string][]string{
+			"objectClass":      {"inetOrgPerson"},
+			"uid":              {uid},
+			"cn":               {cn},
+			"sn":               {cn},
+			"displayName":      {cn},
+			"mail":             {uid + "@example.org"},
+			"departmentNumber": {department},
+			"userPassword":     {uid + "-secret"},
+		}
+	}
+
+	add("ou=people,"+baseDN, map[string][]string{"objectClass": {"organizationalUnit"}, "ou": {"people"}})
+	add("ou=contractors,"+baseDN, map[string][]string{"objectClass": {"organizationalUnit"}, "ou": {"contractors"}})
+	add("uid=alice,ou=people,"+baseDN, person("alice", "Alice Smith", "Sales"))
+	add("uid=bob,ou=contractors,"+baseDN, person("bob", "Bob Jones", "Logistics"))
+	add("uid=carol,ou=people,"+baseDN, person("carol", "Carol Brown", "Sales"))
+	add("uid=carol,ou=contractors,"+baseDN, person("carol", "Carol White", "Logistics"))
+
+	return url
+}
+
+func TestDirectory(t *testing.T) {
+	url := setupLDAP(t)
+	ctx := context.Background()
+
+	t.Run("search and bind", func(t *testing.T) {
+		directory, err := New(Config{
+			URL:                  url,
+			BindDN:               adminDN,
+			BindPassword:         adminPassword,
+			BaseDNs:              []string{"ou=people," + baseDN, "ou=contractors," + baseDN},
+			UserFilter:           "(&(objectClass=inetOrgPerson)(uid=%s))",
+			UsernameAttribute:    "uid",
+			DisplayNameAttribute: "displayName",
+			EmailAttribute:       "mail",
+			DepartmentAttribute:  "departmentNumber",
+		})
+		assert.NoError(t, err)
+
+		alice, err := directory.Authenticate(ctx, "alice", "alice-secret")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.DirectoryUser{
+			Source:      models.AuthenticatorLDAP,
+			DN:          "uid=alice,ou=people," + baseDN,
+			Username:    "alice",
+			DisplayName: "Alice Smith",
+			Email:       "alice@example.org",
+			Department:  "Sales",
+		}, alice)
+
+		shouted, err := directory.Authenticate(ctx, "ALICE", "alice-secret")
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", shouted.Username, "The user should be named after the entry, not the typed name")
+
+		bob, err := directory.Authenticate(ctx, "bob", "bob-secret")
+		assert.NoError(t, err)
+		assert.Equal(t, "uid=bob,ou=contractors,"+baseDN, bob.DN, "The next base DN should be searched")
+
+		// Each base DN is searched on its own, so the first match wins.
+		carol, err := directory.Authenticate(ctx, "carol", "carol-secret")
+		assert.NoError(t, err)
+		assert.Equal(t, "Carol Brown", carol.DisplayName)
+
+		_, err = directory.Authenticate(ctx, "alice", "wrong")
+		assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+		_, err = directory.Authenticate(ctx, "dave", "dave-secret")
+		assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+		_, err = directory.Authenticate(ctx, "*", "alice-secret")
+		assert.ErrorIs(t, err, models.ErrInvalidCredentials, "The username should be escaped in the filter")
+	})
+
+	t.Run("ambiguous user", func(t *testing.T) {
+		directory, err := New(Config{
+			URL:          url,
+			BindDN:       adminDN,
+			BindPassword: adminPassword,
+			BaseDNs:      []string{baseDN},
+			UserFilter:   "(uid=%s)",
+		})
+		assert.NoError(t, err)
+
+		_, err = directory.Authenticate(ctx, "carol", "carol-secret")
+		assert.ErrorIs(t, err, ErrAmbiguousUser)
+	})
+
+	t.Run("direct bind", func(t *testing.T) {
+		directory, err := New(Config{
+			URL:                  url,
+			UserDNs:              []string{"uid=%s,ou=people," + baseDN, "uid=%s,ou=contractors," + baseDN},
+			DisplayNameAttribute: "displayName",
+			DepartmentAttribute:  "departmentNumber",
+		})
+		assert.NoError(t, err)
+
+		bob, err := directory.Authenticate(ctx, "bob", "bob-secret")
+		assert.NoError(t, err)
+		assert.Equal(t, "uid=bob,ou=contractors,"+baseDN, bob.DN)
+		assert.Equal(t, "Bob Jones", bob.DisplayName)
+		assert.Equal(t, "Logistics", bob.Department)
+		assert.Empty(t, bob.Email, "Attributes that are not configured should not be synced")
+
+		shouted, err := directory.Authenticate(ctx, "Bob", "bob-secret")
+		assert.NoError(t, err)
+		assert.Equal(t, "bob", shouted.Username, "Without a username attribute the typed name should be lowercased")
+
+		_, err = directory.Authenticate(ctx, "bob", "wrong")
+		assert.ErrorIs(t, err, models.ErrInvalidCredentials)
+	})
+}
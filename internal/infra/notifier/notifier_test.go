@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"merch-store-service/internal/infra/config"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	n := NewFileNotifier(path)
+
+	messages := []Message{
+		{To: "alice", Subject: "hoody is back in stock", Body: "first"},
+		{To: "bob", Subject: "hoody is back in stock", Body: "second"},
+	}
+	for _, msg := range messages {
+		assert.NoError(t, n.Notify(context.Background(), msg))
+	}
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var got []Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var msg Message
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		assert.False(t, msg.SentAt.IsZero(), "Messages should be timestamped")
+		got = append(got, msg)
+	}
+
+	assert.Len(t, got, 2)
+	assert.Equal(t, "alice", got[0].To, "Messages should be written in order")
+	assert.Equal(t, "second", got[1].Body)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		notifier  string
+		expectErr bool
+	}{
+		{"Default", "", false},
+		{"Log", "log", false},
+		{"File", "file", false},
+		{"Unknown", "pigeon", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(&config.Config{Notifier: tt.notifier, NotifierFile: "notifications.log"})
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"merch-store-service/internal/infra/oidc/mockidp"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const redirectURL = "http://merch.test/api/auth/sso/callback"
+
+func newTestProvider(t *testing.T) (*mockidp.IdP, *Provider) {
+	idp, err := mockidp.New("merch-store")
+	if err != nil {
+		t.Fatalf("failed to start mock IdP: %v", err)
+	}
+	srv := httptest.NewServer(idp)
+	t.Cleanup(srv.Close)
+	idp.Issuer = srv.URL
+
+	idp.AddUser("alice", mockidp.User{Subject: "mock|alice", Email: "alice@example.com", EmailVerified: true})
+
+	provider, err := Discover(context.Background(), Config{
+		Issuer:      srv.URL,
+		ClientID:    "merch-store",
+		RedirectURL: redirectURL,
+	}, srv.Client())
+	if err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+	return idp, provider
+}
+
+// authorize follows the authorization URL for the login and returns the
+// parameters the provider redirects back with.
+func authorize(t *testing.T, provider *Provider, login, state, nonce, challenge string) url.Values {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := client.Get(provider.AuthCodeURL(state, nonce, challenge) + "&login_hint=" + login)
+	if err != nil {
+		t.Fatalf("authorization request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if resp.StatusCode != http.StatusFound || err != nil {
+		t.Fatalf("expected a redirect back, got status %d", resp.StatusCode)
+	}
+	return location.Query()
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	_, provider := newTestProvider(t)
+	ctx := context.Background()
+
+	verifier, err := NewCodeVerifier()
+	assert.NoError(t, err)
+	nonce, err := NewNonce()
+	assert.NoError(t, err)
+
+	params := authorize(t, provider, "alice", "state-1", nonce, CodeChallenge(verifier))
+	assert.Equal(t, "state-1", params.Get("state"))
+	assert.NotEmpty(t, params.Get("code"))
+
+	identity, err := provider.Exchange(ctx, params.Get("code"), verifier, nonce)
+	assert.NoError(t, err)
+	assert.Equal(t, provider.Issuer(), identity.Issuer)
+	assert.Equal(t, "mock|alice", identity.Subject)
+	assert.Equal(t, "alice@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+
+	_, err = provider.Exchange(ctx, params.Get("code"), verifier, nonce)
+	assert.ErrorIs(t, err, ErrTokenExchange, "a code works once")
+}
+
+func TestAuthorizationCodeFlowRejects(t *testing.T) {
+	_, provider := newTestProvider(t)
+	ctx := context.Background()
+
+	verifier, err := NewCodeVerifier()
+	assert.NoError(t, err)
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		params := authorize(t, provider, "alice", "s", "n", CodeChallenge(verifier))
+		_, err := provider.Exchange(ctx, params.Get("code"), "another-verifier", "n")
+		assert.ErrorIs(t, err, ErrTokenExchange)
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		params := authorize(t, provider, "alice", "s", "n", CodeChallenge(verifier))
+		_, err := provider.Exchange(ctx, params.Get("code"), verifier, "other")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		params := authorize(t, provider, "mallory", "s", "n", CodeChallenge(verifier))
+		assert.Equal(t, "access_denied", params.Get("error"))
+		assert.Empty(t, params.Get("code"))
+	})
+
+	t.Run("tampered token", func(t *testing.T) {
+		_, err := provider.VerifyIDToken(ctx, "eyJhbGciOiJub25lIn0.eyJzdWIiOiJ4In0.", "n")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestDiscoverIssuerMismatch(t *testing.T) {
+	idp, err := mockidp.New("merch-store")
+	assert.NoError(t, err)
+	srv := httptest.NewServer(idp)
+	defer srv.Close()
+	idp.Issuer = "https://elsewhere.example"
+
+	_, err = Discover(context.Background(), Config{Issuer: srv.URL, ClientID: "merch-store"}, srv.Client())
+	assert.ErrorIs(t, err, ErrDiscovery)
+}
+
+func TestPublicKeys(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	set := jsonWebKeySet{Keys: []jsonWebKey{
+		{KeyType: "EC", KeyID: "ec", Curve: "P-256", X: b64(ecKey.X.Bytes()), Y: b64(ecKey.Y.Bytes())},
+		{KeyType: "OKP", KeyID: "ed", Curve: "Ed25519", X: b64(edKey)},
+		{KeyType: "EC", KeyID: "off-curve", Curve: "P-256", X: b64([]byte{1}), Y: b64([]byte{2})},
+		{KeyType: "RSA", KeyID: "enc", Use: "enc", N: b64([]byte{1}), E: b64([]byte{3})},
+		{KeyType: "oct", KeyID: "secret"},
+	}}
+
+	keys := set.publicKeys()
+	assert.Len(t, keys, 2)
+	assert.Equal(t, &ecKey.PublicKey, keys["ec"])
+	assert.Equal(t, edKey, keys["ed"])
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS wishlist_public;
+DROP TABLE IF EXISTS wishlist_items;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS wishlist_items
+(
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    note VARCHAR(255),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_name) REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT unique_wishlist_item UNIQUE (user_id, product_name)
+);
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS wishlist_public BOOLEAN NOT NULL DEFAULT FALSE;
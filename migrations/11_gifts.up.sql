@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS gifts
+(
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL UNIQUE,
+    sender_id INT NOT NULL,
+    recipient_id INT NOT NULL,
+    message VARCHAR(255),
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (recipient_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_gifts_recipient ON gifts(recipient_id);
+CREATE INDEX IF NOT EXISTS idx_gifts_sender ON gifts(sender_id);
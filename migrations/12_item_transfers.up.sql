@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS item_transfers
+(
+    id SERIAL PRIMARY KEY,
+    from_user_id INT NOT NULL,
+    to_user_id INT NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (from_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (item_name) REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_transfers_from_user ON item_transfers(from_user_id);
+CREATE INDEX IF NOT EXISTS idx_item_transfers_to_user ON item_transfers(to_user_id);
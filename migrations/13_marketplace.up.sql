@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS market_listings
+(
+    id SERIAL PRIMARY KEY,
+    seller_id INT NOT NULL,
+    item_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    remaining INT NOT NULL CHECK (remaining >= 0),
+    price INT NOT NULL CHECK (price > 0),
+    status VARCHAR(16) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'sold', 'cancelled')),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (seller_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (item_name) REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_listings_status ON market_listings(status, item_name);
+
+CREATE TABLE IF NOT EXISTS market_trades
+(
+    id SERIAL PRIMARY KEY,
+    listing_id INT NOT NULL,
+    buyer_id INT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    total INT NOT NULL,
+    fee INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (listing_id) REFERENCES market_listings(id) ON DELETE CASCADE,
+    FOREIGN KEY (buyer_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_market_trades_listing ON market_trades(listing_id);
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS auctions
+(
+    id SERIAL PRIMARY KEY,
+    product_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    starts_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    reserve_price INT NOT NULL DEFAULT 0 CHECK (reserve_price >= 0),
+    min_increment INT NOT NULL DEFAULT 1 CHECK (min_increment > 0),
+    extension_seconds INT NOT NULL DEFAULT 0 CHECK (extension_seconds >= 0),
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'sold', 'unsold')),
+    leader_id INT,
+    current_bid INT,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP WITHOUT TIME ZONE,
+    FOREIGN KEY (product_name) REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (leader_id) REFERENCES users(id) ON DELETE SET NULL,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auctions_open ON auctions(status, ends_at);
+
+CREATE TABLE IF NOT EXISTS auction_bids
+(
+    id SERIAL PRIMARY KEY,
+    auction_id INT NOT NULL,
+    user_id INT NOT NULL,
+    amount INT NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (auction_id) REFERENCES auctions(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_auction_bids_auction ON auction_bids(auction_id);
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS raffles
+(
+    id SERIAL PRIMARY KEY,
+    product_name VARCHAR(255) NOT NULL,
+    prizes INT NOT NULL DEFAULT 1 CHECK (prizes > 0),
+    ticket_price INT NOT NULL CHECK (ticket_price > 0),
+    max_tickets_per_user INT CHECK (max_tickets_per_user > 0),
+    draw_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    seed_commitment CHAR(64) NOT NULL,
+    seed CHAR(64) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'drawn', 'cancelled')),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    drawn_at TIMESTAMP WITHOUT TIME ZONE,
+    FOREIGN KEY (product_name) REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_raffles_open ON raffles(status, draw_at);
+
+CREATE TABLE IF NOT EXISTS raffle_tickets
+(
+    id SERIAL PRIMARY KEY,
+    raffle_id INT NOT NULL,
+    number INT NOT NULL CHECK (number > 0),
+    user_id INT NOT NULL,
+    prize_rank INT,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (raffle_id) REFERENCES raffles(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (raffle_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_raffle_tickets_user ON raffle_tickets(raffle_id, user_id);
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS order_items DROP COLUMN IF EXISTS bundle_name;
+DROP TABLE IF EXISTS bundle_items;
+DROP TABLE IF EXISTS bundles;
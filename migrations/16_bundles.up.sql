@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS bundles
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    price INT NOT NULL CHECK (price > 0),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS bundle_items
+(
+    bundle_id INT NOT NULL,
+    product_name VARCHAR(255) NOT NULL,
+    quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    PRIMARY KEY (bundle_id, product_name),
+    FOREIGN KEY (bundle_id) REFERENCES bundles(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_name) REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS bundle_name VARCHAR(255);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS invites
+(
+    code VARCHAR(64) PRIMARY KEY,
+    created_by INT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITHOUT TIME ZONE,
+    used_by INT UNIQUE,
+    used_at TIMESTAMP WITHOUT TIME ZONE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (used_by) REFERENCES users(id) ON DELETE SET NULL
+);
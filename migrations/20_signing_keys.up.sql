@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS signing_keys
+(
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(16) NOT NULL,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    retires_at TIMESTAMP WITHOUT TIME ZONE
+);
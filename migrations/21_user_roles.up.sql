@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_roles
+(
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(32) NOT NULL,
+    granted_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    granted_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT LOCALTIMESTAMP,
+    PRIMARY KEY (user_id, role)
+);
+
+INSERT INTO user_roles (user_id, role)
+SELECT id, 'employee' FROM users
+ON CONFLICT DO NOTHING;
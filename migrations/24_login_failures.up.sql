@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS login_failures
+(
+    kind VARCHAR(16) NOT NULL,
+    key TEXT NOT NULL,
+    failures INTEGER NOT NULL,
+    last_failure_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITHOUT TIME ZONE,
+    PRIMARY KEY (kind, key)
+);
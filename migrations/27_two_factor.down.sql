@@ -0,0 +1,5 @@
+ALTER TABLE IF EXISTS sessions DROP COLUMN IF EXISTS two_factor;
+DROP TABLE IF EXISTS two_factor_roles;
+DROP TABLE IF EXISTS two_factor_challenges;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS two_factor;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS item_name VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS quantity INT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS transactions DROP COLUMN IF EXISTS order_id;
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS transactions DROP COLUMN IF EXISTS kind;
+ALTER TABLE IF EXISTS orders DROP COLUMN IF EXISTS delivered_at;
+ALTER TABLE IF EXISTS products DROP COLUMN IF EXISTS stock;
@@ -0,0 +1,10 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS stock INT CHECK (stock >= 0);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP WITHOUT TIME ZONE;
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'transfer';
+
+UPDATE transactions SET kind = 'purchase' WHERE from_user_id = to_user_id;
@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS transactions
+    DROP COLUMN IF EXISTS promo_code_id,
+    DROP COLUMN IF EXISTS discount;
+ALTER TABLE IF EXISTS orders DROP COLUMN IF EXISTS discount;
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_codes;
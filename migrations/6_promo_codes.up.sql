@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS promo_codes
+(
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    discount_type VARCHAR(16) NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+    discount_value INT NOT NULL CHECK (discount_value > 0),
+    product_name VARCHAR(255) REFERENCES products(name) ON DELETE CASCADE,
+    valid_from TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    valid_until TIMESTAMP WITHOUT TIME ZONE,
+    max_uses INT,
+    max_uses_per_user INT,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions
+(
+    id SERIAL PRIMARY KEY,
+    promo_code_id INT NOT NULL,
+    user_id INT NOT NULL,
+    order_id INT NOT NULL,
+    discount INT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (promo_code_id) REFERENCES promo_codes(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_code_user ON promo_redemptions(promo_code_id, user_id);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS discount INT NOT NULL DEFAULT 0;
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS discount INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS promo_code_id INT REFERENCES promo_codes(id) ON DELETE SET NULL;
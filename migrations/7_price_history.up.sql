@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS product_prices
+(
+    id SERIAL PRIMARY KEY,
+    product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    price INT NOT NULL CHECK (price >= 0),
+    effective_from TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_product_price UNIQUE (product_name, effective_from)
+);
+
+INSERT INTO product_prices (product_name, price, effective_from)
+SELECT name, price, '1970-01-01' FROM products
+ON CONFLICT (product_name, effective_from) DO NOTHING;
+
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS unit_price INT;
+
+UPDATE transactions t SET unit_price = i.unit_price
+FROM order_items i
+WHERE t.order_id = i.order_id AND t.kind = 'purchase' AND t.item_name = i.item_name;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS drop_allocations;
+DROP TABLE IF EXISTS drops;
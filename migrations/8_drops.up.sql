@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS drops
+(
+    id SERIAL PRIMARY KEY,
+    product_name VARCHAR(255) NOT NULL REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    starts_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITHOUT TIME ZONE,
+    stock INT NOT NULL CHECK (stock > 0),
+    remaining INT NOT NULL CHECK (remaining >= 0),
+    per_user_limit INT NOT NULL CHECK (per_user_limit > 0),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_drops_product ON drops(product_name, starts_at);
+
+CREATE TABLE IF NOT EXISTS drop_allocations
+(
+    id SERIAL PRIMARY KEY,
+    drop_id INT NOT NULL,
+    user_id INT NOT NULL,
+    order_id INT NOT NULL,
+    quantity INT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (drop_id) REFERENCES drops(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_drop_allocations_drop_user ON drop_allocations(drop_id, user_id);
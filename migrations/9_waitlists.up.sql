@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS waitlist
+(
+    id SERIAL PRIMARY KEY,
+    product_name VARCHAR(255) NOT NULL,
+    user_id INT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_name) REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT unique_waitlist UNIQUE (product_name, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS stock_reservations
+(
+    id SERIAL PRIMARY KEY,
+    product_name VARCHAR(255) NOT NULL,
+    user_id INT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (product_name) REFERENCES products(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_product ON stock_reservations(product_name, expires_at);
@@ -3,5 +3,10 @@ package ctxkeys
 type ContextKey string
 
 const (
-	UserIDKey ContextKey = "userID"
+	UserIDKey    ContextKey = "userID"
+	SessionIDKey ContextKey = "sessionID"
+	RolesKey     ContextKey = "roles"
+
+	ServiceAccountKey ContextKey = "serviceAccount"
+	ScopesKey         ContextKey = "scopes"
 )